@@ -31,6 +31,19 @@ type config struct {
 	Model           string `json:"model"`
 	DBPath          string `json:"db_path"`
 	Lang            string `json:"lang"`
+
+	// LLM budgets in tokens (input + output); 0 means unlimited.
+	TokensPerMinute int `json:"tokens_per_minute"`
+	SessionTokens   int `json:"session_tokens"`
+
+	// Somatic feedback regime: "keyword" (default), "evaluator" or "blend".
+	// EvaluatorWeight is the evaluator's share in blend mode (default 0.5).
+	FeedbackMode    string  `json:"feedback_mode"`
+	EvaluatorWeight float64 `json:"evaluator_weight"`
+
+	// Pricing in USD per million tokens, used for cost accounting.
+	InputPricePerMTok  float64 `json:"input_price_per_mtok"`
+	OutputPricePerMTok float64 `json:"output_price_per_mtok"`
 }
 
 func main() {
@@ -178,8 +191,19 @@ func run() error {
 		fmt.Printf(tr.CLI.ScenarioLoaded+"\n", *scenarioFile)
 	}
 
-	// Build components.
-	llm := consciousness.NewClaudeAdapter(model)
+	// Build components. Every LLM call goes through the meter so budgets and
+	// cost accounting cover the engine and the reviewer alike.
+	meter := consciousness.NewMeteredLLM(consciousness.NewClaudeAdapter(model), consciousness.MeterConfig{
+		Budget: consciousness.Budget{
+			TokensPerMinute: fileCfg.TokensPerMinute,
+			SessionTokens:   fileCfg.SessionTokens,
+		},
+		Pricing: consciousness.Pricing{
+			InputPerMTok:  fileCfg.InputPricePerMTok,
+			OutputPerMTok: fileCfg.OutputPricePerMTok,
+		},
+	})
+	var llm consciousness.LLM = meter
 
 	consciousnessEngine := consciousness.NewEngine(consciousness.EngineConfig{
 		LLM:                 llm,
@@ -199,11 +223,25 @@ func run() error {
 		MaxThoughts: 20,
 	})
 
+	var evaluator consciousness.Evaluator
+	feedbackMode := consciousness.FeedbackMode(fileCfg.FeedbackMode)
+	switch feedbackMode {
+	case "", consciousness.FeedbackKeyword:
+	case consciousness.FeedbackEvaluator, consciousness.FeedbackBlend:
+		evaluator = consciousness.NewLLMEvaluator(consciousness.EvaluatorConfig{
+			LLM:         llm,
+			MinInterval: time.Second,
+		})
+	default:
+		return fmt.Errorf("unknown feedback_mode %q", fileCfg.FeedbackMode)
+	}
+
 	// Determine input source: stdin (default) or pipe from WebSocket hub.
 	var input io.Reader = os.Stdin
 	var hub *server.Hub
 	var pipeWriter *io.PipeWriter
 	var onStateSnapshot func(bio *biology.State, psych psychology.State)
+	var onCommand func(simulation.CommandResult)
 
 	if *serverMode {
 		pr, pw := io.Pipe()
@@ -241,6 +279,21 @@ func run() error {
 				PsychState: &psychPayload,
 			})
 		}
+
+		// Answer operator commands; every client sees every acknowledgement.
+		onCommand = func(res simulation.CommandResult) {
+			msg := server.ServerMessage{
+				Type:      "ack",
+				Command:   res.Line,
+				Content:   res.Message,
+				Timestamp: time.Now(),
+			}
+			if res.Err != nil {
+				msg.Type = "error"
+				msg.Content = res.Err.Error()
+			}
+			hub.Broadcast(msg)
+		}
 	}
 
 	cfg := simulation.Config{
@@ -251,6 +304,11 @@ func run() error {
 		Display:         display,
 		Store:           store,
 		Reviewer:        psychReviewer,
+		FeedbackMode:    feedbackMode,
+		Evaluator:       evaluator,
+		EvaluatorWeight: fileCfg.EvaluatorWeight,
+		Meter:           meter,
+		Model:           string(model),
 		Personality:     personality,
 		BioState:        bioState,
 		Identity:        identity,
@@ -259,6 +317,7 @@ func run() error {
 		SimStart:        time.Date(2024, 6, 15, 8, 0, 0, 0, time.Local),
 		Input:           input,
 		OnStateSnapshot: onStateSnapshot,
+		OnCommand:       onCommand,
 	}
 
 	loop := simulation.NewLoop(cfg)
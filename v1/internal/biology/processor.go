@@ -7,6 +7,10 @@ import (
 	"github.com/marczahn/person/internal/sense"
 )
 
+// MaxAdvanceSeconds caps a single biology step. Larger steps (after a pause, a
+// stalled loop or an extreme clock speed) are clamped to prevent huge jumps.
+const MaxAdvanceSeconds = 300.0
+
 // Processor manages biological state updates through interaction rules,
 // circadian modulation, decay toward baseline, and threshold monitoring.
 type Processor struct {
@@ -36,10 +40,18 @@ func (p *Processor) Tick(s *State) TickResult {
 		return TickResult{}
 	}
 
-	// Cap dt to prevent huge jumps after pauses (e.g., 5 minutes max).
-	if dt > 300 {
-		dt = 300
+	return p.Advance(s, dt)
+}
+
+// Advance advances the biological state by dt simulated seconds, independent
+// of wall time, so a scaled or paused simulation clock drives biology.
+// LastUpdate is stamped with the current wall time. dt is capped at
+// MaxAdvanceSeconds; NaN is treated as no time passing.
+func (p *Processor) Advance(s *State, dt float64) TickResult {
+	if !(dt > 0) {
+		return TickResult{}
 	}
+	dt = math.Min(dt, MaxAdvanceSeconds)
 
 	var allChanges []StateChange
 
@@ -97,7 +109,7 @@ func (p *Processor) Tick(s *State) TickResult {
 	// 10. Evaluate critical thresholds.
 	thresholds := EvaluateThresholds(s)
 
-	s.LastUpdate = now
+	s.LastUpdate = time.Now()
 
 	return TickResult{
 		Changes:    allChanges,
@@ -1,6 +1,7 @@
 package biology
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -952,3 +953,27 @@ func TestTick_SpO2RecoveryFasterWithHyperventilation(t *testing.T) {
 			sHyper.SpO2, sNormal.SpO2)
 	}
 }
+
+func TestAdvance_CapsStepAndIgnoresNonFiniteDt(t *testing.T) {
+	p := NewProcessor()
+
+	capped := NewDefaultState()
+	p.Advance(&capped, MaxAdvanceSeconds)
+	huge := NewDefaultState()
+	p.Advance(&huge, 1e308)
+	if huge.Fatigue != capped.Fatigue || huge.CircadianPhase != capped.CircadianPhase {
+		t.Errorf("a huge dt should advance like MaxAdvanceSeconds: fatigue %v vs %v", huge.Fatigue, capped.Fatigue)
+	}
+
+	inf := NewDefaultState()
+	p.Advance(&inf, math.Inf(1))
+	if math.IsNaN(inf.Fatigue) || inf.Fatigue != capped.Fatigue {
+		t.Errorf("an infinite dt should be capped, got fatigue %v", inf.Fatigue)
+	}
+
+	nan := NewDefaultState()
+	before := nan.Fatigue
+	if result := p.Advance(&nan, math.NaN()); len(result.Changes) != 0 || nan.Fatigue != before {
+		t.Errorf("a NaN dt should not advance biology, got %d changes", len(result.Changes))
+	}
+}
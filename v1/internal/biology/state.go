@@ -119,6 +119,16 @@ func (v Variable) String() string {
 	return "unknown"
 }
 
+// ParseVariable returns the variable with the given snake_case name.
+func ParseVariable(name string) (Variable, bool) {
+	for i, n := range variableNames {
+		if n == name {
+			return Variable(i), true
+		}
+	}
+	return 0, false
+}
+
 // Get returns the current value of the given variable from the state.
 func (s *State) Get(v Variable) float64 {
 	switch v {
@@ -107,6 +107,15 @@ func TestVariable_String_AllVariables(t *testing.T) {
 			t.Errorf("Variable(%d).String() = %q, want %q", tt.v, got, tt.want)
 		}
 	}
+	for _, tt := range tests {
+		got, ok := ParseVariable(tt.want)
+		if !ok || got != tt.v {
+			t.Errorf("ParseVariable(%q) = %v, %v; want %v", tt.want, got, ok, tt.v)
+		}
+	}
+	if _, ok := ParseVariable("mood"); ok {
+		t.Error("ParseVariable accepted an unknown name")
+	}
 }
 
 func TestVariable_String_OutOfRange(t *testing.T) {
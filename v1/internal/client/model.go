@@ -164,6 +164,10 @@ func (m *Model) handleSubmit() (tea.Model, tea.Cmd) {
 		Type:    m.mode.String(),
 		Content: text,
 	}
+	// A leading slash sends an operator command whatever the input mode.
+	if strings.HasPrefix(text, "/") {
+		msg.Type = "command"
+	}
 
 	m.input.SetValue("")
 
@@ -176,18 +180,28 @@ func (m *Model) handleSubmit() (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) addThought(msg server.ServerMessage) {
-	if msg.Type != "thought" {
-		return
-	}
 	var line string
 	ts := msg.Timestamp.Format("15:04:05")
-	if msg.Trigger != "" {
+	switch {
+	case msg.Type == "ack":
+		line = fmt.Sprintf("%s %s",
+			triggerStyle.Render(ts),
+			triggerStyle.Render(msg.Command+": "+msg.Content),
+		)
+	case msg.Type == "error":
+		line = fmt.Sprintf("%s %s",
+			triggerStyle.Render(ts),
+			triggerStyle.Render(msg.Command+": error: "+msg.Content),
+		)
+	case msg.Type != "thought":
+		return
+	case msg.Trigger != "":
 		line = fmt.Sprintf("%s %s (trigger: %s)",
 			triggerStyle.Render(ts),
 			thoughtStyle.Render(msg.Content),
 			triggerStyle.Render(msg.Trigger),
 		)
-	} else {
+	default:
 		line = fmt.Sprintf("%s %s",
 			triggerStyle.Render(ts),
 			thoughtStyle.Render(msg.Content),
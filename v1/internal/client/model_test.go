@@ -1,6 +1,7 @@
 package client
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -58,3 +59,16 @@ func TestModel_AddThought_ThoughtWithTriggerIncludesTrigger(t *testing.T) {
 		t.Error("thought line must not be empty")
 	}
 }
+
+func TestModel_AddThought_ShowsCommandAnswers(t *testing.T) {
+	m := &Model{}
+
+	m.addThought(server.ServerMessage{Type: "ack", Command: "/pause", Content: "paused", Timestamp: time.Now()})
+	m.addThought(server.ServerMessage{Type: "error", Command: "/speed x", Content: "speed must be a positive number", Timestamp: time.Now()})
+	if len(m.thoughts) != 2 {
+		t.Fatalf("expected acks and errors to be shown, got %d lines", len(m.thoughts))
+	}
+	if !strings.Contains(m.thoughts[0], "paused") || !strings.Contains(m.thoughts[1], "error") {
+		t.Errorf("lines = %q", m.thoughts)
+	}
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/anthropics/anthropic-sdk-go/packages/param"
 )
 
-var _ LLM = (*ClaudeAdapter)(nil)
+var (
+	_ LLM      = (*ClaudeAdapter)(nil)
+	_ UsageLLM = (*ClaudeAdapter)(nil)
+)
 
 // ClaudeAdapter implements the LLM interface using the Anthropic Claude API.
 type ClaudeAdapter struct {
@@ -30,6 +33,12 @@ func NewClaudeAdapter(model anthropic.Model) *ClaudeAdapter {
 
 // Complete sends a system prompt and user message to Claude, returning the text response.
 func (ca *ClaudeAdapter) Complete(ctx context.Context, systemPrompt, userMessage string) (string, error) {
+	text, _, err := ca.CompleteWithUsage(ctx, systemPrompt, userMessage)
+	return text, err
+}
+
+// CompleteWithUsage is Complete plus the token usage reported by the API.
+func (ca *ClaudeAdapter) CompleteWithUsage(ctx context.Context, systemPrompt, userMessage string) (string, TokenUsage, error) {
 	resp, err := ca.client.Messages.New(ctx, anthropic.MessageNewParams{
 		Model:     ca.model,
 		MaxTokens: 1024,
@@ -42,10 +51,14 @@ func (ca *ClaudeAdapter) Complete(ctx context.Context, systemPrompt, userMessage
 		},
 	})
 	if err != nil {
-		return "", fmt.Errorf("claude API call: %w", err)
+		return "", TokenUsage{}, fmt.Errorf("claude API call: %w", err)
 	}
 
-	return extractText(resp), nil
+	usage := TokenUsage{
+		InputTokens:  int(resp.Usage.InputTokens),
+		OutputTokens: int(resp.Usage.OutputTokens),
+	}
+	return extractText(resp), usage, nil
 }
 
 // extractText concatenates all text blocks from a Claude response.
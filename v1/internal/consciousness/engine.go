@@ -2,6 +2,7 @@ package consciousness
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -104,7 +105,10 @@ func (e *Engine) React(ctx context.Context, ps *psychology.State, dt float64) (*
 	// Update timestamp before the call so failures don't cause retry floods.
 	e.lastCallTime = time.Now()
 
-	response, err := e.llm.Complete(ctx, systemPrompt, userMessage)
+	response, err := e.llm.Complete(WithCallType(ctx, CallReactive), systemPrompt, userMessage)
+	if errors.Is(err, ErrBudgetExhausted) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("reactive thought: %w", err)
 	}
@@ -152,7 +156,10 @@ func (e *Engine) Spontaneous(ctx context.Context, ps *psychology.State) (*Though
 	e.lastCallTime = time.Now()
 	e.lastSpontaneous = time.Now()
 
-	response, err := e.llm.Complete(ctx, systemPrompt, userMessage)
+	response, err := e.llm.Complete(WithCallType(ctx, CallSpontaneous), systemPrompt, userMessage)
+	if errors.Is(err, ErrBudgetExhausted) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("spontaneous thought: %w", err)
 	}
@@ -173,7 +180,8 @@ func (e *Engine) Spontaneous(ctx context.Context, ps *psychology.State) (*Though
 
 // Respond generates a conscious thought in response to external communicative
 // input (speech or action). Unlike React, this is not salience-gated — the
-// person always processes direct communication. Returns nil if rate-limited.
+// person always processes direct communication. Returns nil if rate-limited
+// or over the LLM budget.
 func (e *Engine) Respond(ctx context.Context, ps *psychology.State, input ExternalInput) (*Thought, error) {
 	if !e.canCall() {
 		return nil, nil
@@ -187,7 +195,10 @@ func (e *Engine) Respond(ctx context.Context, ps *psychology.State, input Extern
 
 	e.lastCallTime = time.Now()
 
-	response, err := e.llm.Complete(ctx, systemPrompt, userMessage)
+	response, err := e.llm.Complete(WithCallType(ctx, CallConversational), systemPrompt, userMessage)
+	if errors.Is(err, ErrBudgetExhausted) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("respond to %s: %w", input.Type, err)
 	}
@@ -0,0 +1,226 @@
+package consciousness
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marczahn/person/internal/biology"
+	"github.com/marczahn/person/internal/i18n"
+	"github.com/marczahn/person/internal/psychology"
+)
+
+// FeedbackMode selects how a thought's bodily impact is derived (ADR-002).
+type FeedbackMode string
+
+const (
+	// FeedbackKeyword uses the [STATE] tag pulses plus keyword coping/distortion
+	// changes. This is the default and needs no extra LLM call.
+	FeedbackKeyword FeedbackMode = "keyword"
+	// FeedbackEvaluator replaces the keyword path with the blind evaluator.
+	FeedbackEvaluator FeedbackMode = "evaluator"
+	// FeedbackBlend applies both, weighted by the evaluator weight.
+	FeedbackBlend FeedbackMode = "blend"
+)
+
+// Affect is the only context the blind evaluator sees besides the thought text.
+// Identity and memory are deliberately withheld.
+type Affect struct {
+	Arousal float64
+	Valence float64
+	Energy  float64
+}
+
+// AffectOf summarises a psychological state for the evaluator.
+func AffectOf(ps *psychology.State) Affect {
+	return Affect{Arousal: ps.Arousal, Valence: ps.Valence, Energy: ps.Energy}
+}
+
+// Evaluation is the evaluator's structured verdict on one thought.
+type Evaluation struct {
+	Changes     []biology.StateChange
+	Coping      []string
+	Distortions []string
+}
+
+// Evaluator judges a thought's bodily impact without knowing who had it.
+type Evaluator interface {
+	Evaluate(ctx context.Context, thought string, affect Affect) (Evaluation, error)
+}
+
+// ErrEvaluatorBusy is returned when the evaluator is rate-limited.
+// Callers fall back to keyword feedback.
+var ErrEvaluatorBusy = errors.New("evaluator rate-limited")
+
+// evaluatorLimits bounds each evaluated delta to a single-thought range, in line
+// with EmotionalPulses, so one hallucinated verdict cannot start a spiral.
+// Variables not listed here are not somatic responses to a thought and are dropped.
+var evaluatorLimits = map[biology.Variable][2]float64{
+	biology.VarCortisol:        {-0.03, 0.05},
+	biology.VarAdrenaline:      {0, 0.05},
+	biology.VarMuscleTension:   {-0.1, 0.15},
+	biology.VarHeartRate:       {-5, 12},
+	biology.VarRespiratoryRate: {-2, 4},
+	biology.VarSerotonin:       {-0.04, 0.05},
+	biology.VarDopamine:        {0, 0.08},
+	biology.VarEndorphins:      {0, 0.04},
+}
+
+// EvaluatorConfig holds configuration for an LLMEvaluator.
+type EvaluatorConfig struct {
+	LLM         LLM
+	MinInterval time.Duration // 0 means no rate limit
+}
+
+// LLMEvaluator is an Evaluator backed by a separate, context-free LLM call.
+type LLMEvaluator struct {
+	llm         LLM
+	minInterval time.Duration
+	lastCall    time.Time
+}
+
+// NewLLMEvaluator creates a blind thought evaluator.
+func NewLLMEvaluator(cfg EvaluatorConfig) *LLMEvaluator {
+	return &LLMEvaluator{llm: cfg.LLM, minInterval: cfg.MinInterval}
+}
+
+// Evaluate asks the LLM how the thought lands in the body, given only the
+// thought and the current affect.
+func (e *LLMEvaluator) Evaluate(ctx context.Context, thought string, affect Affect) (Evaluation, error) {
+	if time.Since(e.lastCall) < e.minInterval {
+		return Evaluation{}, ErrEvaluatorBusy
+	}
+	e.lastCall = time.Now()
+
+	tr := i18n.T().Feedback.Evaluator
+	system := strings.TrimSpace(tr.SystemPrompt)
+	user := fmt.Sprintf(tr.Affect, affect.Arousal, affect.Valence, affect.Energy) +
+		"\n\n" + fmt.Sprintf(tr.Thought, thought)
+
+	response, err := e.llm.Complete(WithCallType(ctx, CallEvaluator), system, user)
+	if err != nil {
+		return Evaluation{}, fmt.Errorf("evaluate thought: %w", err)
+	}
+	return ParseEvaluation(response)
+}
+
+type evaluationJSON struct {
+	Effects     map[string]float64 `json:"effects"`
+	Coping      []string           `json:"coping"`
+	Distortions []string           `json:"distortions"`
+}
+
+// ParseEvaluation decodes the evaluator's JSON verdict. Surrounding prose and
+// code fences are tolerated. Deltas are clamped to single-thought ranges and
+// unknown or non-somatic variables are dropped. Changes are ordered by variable.
+func ParseEvaluation(raw string) (Evaluation, error) {
+	start := strings.Index(raw, "{")
+	end := strings.LastIndex(raw, "}")
+	if start < 0 || end < start {
+		return Evaluation{}, fmt.Errorf("evaluation: no JSON object in response")
+	}
+	var decoded evaluationJSON
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &decoded); err != nil {
+		return Evaluation{}, fmt.Errorf("evaluation: %w", err)
+	}
+
+	var ev Evaluation
+	for name, delta := range decoded.Effects {
+		v, ok := biology.ParseVariable(strings.ToLower(strings.TrimSpace(name)))
+		if !ok {
+			continue
+		}
+		limits, ok := evaluatorLimits[v]
+		if !ok || delta == 0 {
+			continue
+		}
+		ev.Changes = append(ev.Changes, biology.StateChange{
+			Variable: v,
+			Delta:    min(max(delta, limits[0]), limits[1]),
+			Source:   "consciousness_evaluator",
+		})
+	}
+	sort.Slice(ev.Changes, func(i, j int) bool { return ev.Changes[i].Variable < ev.Changes[j].Variable })
+	ev.Coping = normalizeNames(decoded.Coping)
+	ev.Distortions = normalizeNames(decoded.Distortions)
+	return ev, nil
+}
+
+// SomaticChanges combines keyword and evaluator feedback according to mode.
+// With no evaluation (keyword mode, or the evaluator was unavailable) the
+// keyword path applies in full. In blend mode keyword changes are scaled by
+// 1-weight and evaluated changes by weight.
+func SomaticChanges(mode FeedbackMode, fb ThoughtFeedback, ev *Evaluation, weight float64) []biology.StateChange {
+	keyword := EmotionalPulses(fb.EmotionalState)
+	keyword = append(keyword, FeedbackToChanges(fb)...)
+	if ev == nil {
+		return keyword
+	}
+	switch mode {
+	case FeedbackEvaluator:
+		return append([]biology.StateChange(nil), ev.Changes...)
+	case FeedbackBlend:
+		weight = min(max(weight, 0), 1)
+		changes := scaleChanges(keyword, 1-weight)
+		return append(changes, scaleChanges(ev.Changes, weight)...)
+	default:
+		return keyword
+	}
+}
+
+// MergeEvaluation updates a thought's coping and distortion labels from an
+// evaluation: replaced in evaluator mode, unioned in blend mode.
+func MergeEvaluation(mode FeedbackMode, fb ThoughtFeedback, ev Evaluation) ThoughtFeedback {
+	switch mode {
+	case FeedbackEvaluator:
+		fb.ActiveCoping = ev.Coping
+		fb.ActiveDistortions = ev.Distortions
+	case FeedbackBlend:
+		fb.ActiveCoping = unionNames(fb.ActiveCoping, ev.Coping)
+		fb.ActiveDistortions = unionNames(fb.ActiveDistortions, ev.Distortions)
+	}
+	return fb
+}
+
+func scaleChanges(changes []biology.StateChange, factor float64) []biology.StateChange {
+	out := make([]biology.StateChange, 0, len(changes))
+	for _, c := range changes {
+		if factor == 0 {
+			continue
+		}
+		c.Delta *= factor
+		out = append(out, c)
+	}
+	return out
+}
+
+func normalizeNames(names []string) []string {
+	var out []string
+	for _, n := range names {
+		if n = strings.ToLower(strings.TrimSpace(n)); n != "" {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func unionNames(a, b []string) []string {
+	out := append([]string(nil), a...)
+	for _, n := range b {
+		found := false
+		for _, m := range out {
+			if m == n {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+package consciousness
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marczahn/person/internal/biology"
+)
+
+func TestParseEvaluation_ClampsAndDropsUnknownVariables(t *testing.T) {
+	raw := "Here you go:\n```json\n" +
+		`{"effects": {"cortisol": 0.5, "heart_rate": 6, "hunger": 0.2, "mood": 1, "Serotonin": -0.01},` +
+		` "coping": ["Rumination", " "], "distortions": ["catastrophizing"]}` +
+		"\n```"
+
+	ev, err := ParseEvaluation(raw)
+	if err != nil {
+		t.Fatalf("ParseEvaluation: %v", err)
+	}
+
+	want := map[biology.Variable]float64{
+		biology.VarHeartRate: 6,
+		biology.VarCortisol:  0.05, // clamped to the single-thought ceiling
+		biology.VarSerotonin: -0.01,
+	}
+	if len(ev.Changes) != len(want) {
+		t.Fatalf("changes = %+v, want %d entries", ev.Changes, len(want))
+	}
+	for i, c := range ev.Changes {
+		if c.Delta != want[c.Variable] {
+			t.Errorf("%s delta = %v, want %v", c.Variable, c.Delta, want[c.Variable])
+		}
+		if c.Source != "consciousness_evaluator" {
+			t.Errorf("source = %q", c.Source)
+		}
+		if i > 0 && ev.Changes[i-1].Variable > c.Variable {
+			t.Errorf("changes not ordered by variable: %+v", ev.Changes)
+		}
+	}
+	if len(ev.Coping) != 1 || ev.Coping[0] != "rumination" {
+		t.Errorf("coping = %v, want [rumination]", ev.Coping)
+	}
+	if len(ev.Distortions) != 1 || ev.Distortions[0] != "catastrophizing" {
+		t.Errorf("distortions = %v", ev.Distortions)
+	}
+}
+
+func TestParseEvaluation_InvalidInput(t *testing.T) {
+	for _, raw := range []string{"", "no json here", `{"effects": {"cortisol": "high"}}`} {
+		if _, err := ParseEvaluation(raw); err == nil {
+			t.Errorf("ParseEvaluation(%q) expected error", raw)
+		}
+	}
+}
+
+func TestLLMEvaluator_IsBlindAndTagged(t *testing.T) {
+	inner := &mockLLM{response: `{"effects": {"adrenaline": 0.02}}`}
+	meter := NewMeteredLLM(inner, MeterConfig{})
+	ev := NewLLMEvaluator(EvaluatorConfig{LLM: meter})
+
+	got, err := ev.Evaluate(context.Background(), "What if they never come back?", Affect{Arousal: 0.7, Valence: -0.4, Energy: 0.5})
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if len(got.Changes) != 1 || got.Changes[0].Variable != biology.VarAdrenaline {
+		t.Errorf("changes = %+v", got.Changes)
+	}
+	if !strings.Contains(inner.lastUser, "What if they never come back?") || !strings.Contains(inner.lastUser, "0.70") {
+		t.Errorf("user prompt missing thought or affect: %q", inner.lastUser)
+	}
+	if !strings.Contains(inner.lastSystem, "know nothing about who they are") {
+		t.Errorf("system prompt should be the blind evaluator prompt: %q", inner.lastSystem)
+	}
+	if meter.Report().ByType[CallEvaluator].Calls != 1 {
+		t.Errorf("evaluator call not attributed: %+v", meter.Report().ByType)
+	}
+}
+
+func TestLLMEvaluator_RateLimited(t *testing.T) {
+	inner := &mockLLM{response: `{}`}
+	ev := NewLLMEvaluator(EvaluatorConfig{LLM: inner, MinInterval: time.Hour})
+
+	ev.Evaluate(context.Background(), "one", Affect{})
+	if _, err := ev.Evaluate(context.Background(), "two", Affect{}); !errors.Is(err, ErrEvaluatorBusy) {
+		t.Errorf("err = %v, want ErrEvaluatorBusy", err)
+	}
+	if inner.calls != 1 {
+		t.Errorf("calls = %d, want 1", inner.calls)
+	}
+}
+
+func TestSomaticChanges_Modes(t *testing.T) {
+	fb := ThoughtFeedback{EmotionalState: EmotionalTag{Arousal: 0.8, Valence: -0.7}}
+	ev := &Evaluation{Changes: []biology.StateChange{
+		{Variable: biology.VarCortisol, Delta: 0.04, Source: "consciousness_evaluator"},
+	}}
+	sum := func(changes []biology.StateChange, v biology.Variable) float64 {
+		total := 0.0
+		for _, c := range changes {
+			if c.Variable == v {
+				total += c.Delta
+			}
+		}
+		return total
+	}
+	keywordCortisol := sum(EmotionalPulses(fb.EmotionalState), biology.VarCortisol)
+
+	if got := sum(SomaticChanges(FeedbackKeyword, fb, ev, 0.5), biology.VarCortisol); got != keywordCortisol {
+		t.Errorf("keyword mode cortisol = %v, want %v", got, keywordCortisol)
+	}
+	evaluated := SomaticChanges(FeedbackEvaluator, fb, ev, 0.5)
+	if len(evaluated) != 1 || evaluated[0].Delta != 0.04 {
+		t.Errorf("evaluator mode should use only evaluated changes, got %+v", evaluated)
+	}
+	blended := sum(SomaticChanges(FeedbackBlend, fb, ev, 0.25), biology.VarCortisol)
+	if want := 0.75*keywordCortisol + 0.25*0.04; blended-want > 1e-12 || want-blended > 1e-12 {
+		t.Errorf("blend cortisol = %v, want %v", blended, want)
+	}
+	if got := sum(SomaticChanges(FeedbackEvaluator, fb, nil, 0.5), biology.VarCortisol); got != keywordCortisol {
+		t.Errorf("missing evaluation should fall back to keyword, got %v", got)
+	}
+}
+
+func TestMergeEvaluation(t *testing.T) {
+	fb := ThoughtFeedback{ActiveCoping: []string{"rumination"}}
+	ev := Evaluation{Coping: []string{"rumination", "suppression"}, Distortions: []string{"personalization"}}
+
+	replaced := MergeEvaluation(FeedbackEvaluator, fb, ev)
+	if len(replaced.ActiveCoping) != 2 || len(replaced.ActiveDistortions) != 1 {
+		t.Errorf("evaluator mode = %+v", replaced)
+	}
+	blended := MergeEvaluation(FeedbackBlend, fb, Evaluation{Coping: []string{"suppression"}})
+	if len(blended.ActiveCoping) != 2 || blended.ActiveCoping[0] != "rumination" {
+		t.Errorf("blend mode = %+v", blended)
+	}
+}
@@ -0,0 +1,321 @@
+package consciousness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/marczahn/person/internal/memory"
+)
+
+// CallType classifies an LLM call for metering.
+type CallType string
+
+const (
+	CallReactive       CallType = "reactive"
+	CallSpontaneous    CallType = "spontaneous"
+	CallConversational CallType = "conversational"
+	CallReviewer       CallType = "reviewer"
+	CallEvaluator      CallType = "evaluator"
+	CallMind           CallType = "mind" // untagged calls
+)
+
+// discretionary reports whether a call type is shed first when the budget runs low.
+// Reactive and conversational calls answer something that happened; spontaneous
+// thoughts, reviews and evaluations (which fall back to keyword feedback) can be
+// skipped without the person ignoring the world.
+func (t CallType) discretionary() bool {
+	return t == CallSpontaneous || t == CallReviewer || t == CallEvaluator
+}
+
+type callTypeKey struct{}
+
+// WithCallType tags ctx so a MeteredLLM can attribute the call.
+func WithCallType(ctx context.Context, t CallType) context.Context {
+	return context.WithValue(ctx, callTypeKey{}, t)
+}
+
+// CallTypeFrom returns the call type tagged on ctx, or CallMind if untagged.
+func CallTypeFrom(ctx context.Context) CallType {
+	if t, ok := ctx.Value(callTypeKey{}).(CallType); ok {
+		return t
+	}
+	return CallMind
+}
+
+// ErrBudgetExhausted is returned by MeteredLLM when a call would exceed a budget.
+// Callers treat it like rate limiting: no thought is produced.
+var ErrBudgetExhausted = errors.New("llm budget exhausted")
+
+// TokenUsage is the token count of a single call.
+type TokenUsage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// UsageLLM is implemented by adapters that report exact token usage.
+// MeteredLLM falls back to EstimateTokens for plain LLMs.
+type UsageLLM interface {
+	CompleteWithUsage(ctx context.Context, systemPrompt, userMessage string) (string, TokenUsage, error)
+}
+
+// EstimateTokens approximates the token count of text (about four characters per token).
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// Budget limits token spend. Zero limits are unlimited.
+type Budget struct {
+	TokensPerMinute int     // rolling 60s window, input + output
+	SessionTokens   int     // whole session, input + output
+	Reserve         float64 // share of each limit kept for reactive/conversational calls (default 0.2)
+}
+
+// Pricing converts tokens into cost, in USD per million tokens.
+type Pricing struct {
+	InputPerMTok  float64
+	OutputPerMTok float64
+}
+
+// Usage accumulates calls and tokens for one call type.
+type Usage struct {
+	Calls        int
+	InputTokens  int
+	OutputTokens int
+	Denied       int // calls refused by the budget
+}
+
+// Tokens returns input plus output tokens.
+func (u Usage) Tokens() int {
+	return u.InputTokens + u.OutputTokens
+}
+
+// Cost returns the cost of u under p.
+func (u Usage) Cost(p Pricing) float64 {
+	return float64(u.InputTokens)/1e6*p.InputPerMTok + float64(u.OutputTokens)/1e6*p.OutputPerMTok
+}
+
+// UsageReport is a snapshot of a MeteredLLM's accounting.
+type UsageReport struct {
+	Started  time.Time
+	ByType   map[CallType]Usage
+	Total    Usage
+	CostUSD  float64
+	Degraded bool // discretionary calls are currently being shed
+}
+
+// String renders a one-line summary for the console.
+func (r UsageReport) String() string {
+	s := fmt.Sprintf("LLM usage: %d calls, %d in / %d out tokens, $%.4f",
+		r.Total.Calls, r.Total.InputTokens, r.Total.OutputTokens, r.CostUSD)
+	if r.Total.Denied > 0 {
+		s += fmt.Sprintf(", %d calls skipped by budget", r.Total.Denied)
+	}
+	return s
+}
+
+// SessionRecord converts the report into a persisted session record.
+func (r UsageReport) SessionRecord(model string, ended time.Time) memory.SessionRecord {
+	rec := memory.SessionRecord{
+		ID:        r.Started.UTC().Format("20060102T150405.000Z"),
+		StartedAt: r.Started,
+		EndedAt:   ended,
+		Model:     model,
+		CostUSD:   r.CostUSD,
+	}
+	types := make([]string, 0, len(r.ByType))
+	for t := range r.ByType {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		u := r.ByType[CallType(t)]
+		rec.Calls = append(rec.Calls, memory.CallUsage{
+			Type:         t,
+			Calls:        u.Calls,
+			InputTokens:  u.InputTokens,
+			OutputTokens: u.OutputTokens,
+			Denied:       u.Denied,
+		})
+	}
+	return rec
+}
+
+// MeterConfig configures a MeteredLLM.
+type MeterConfig struct {
+	Budget  Budget
+	Pricing Pricing
+	Now     func() time.Time // defaults to time.Now
+}
+
+type spend struct {
+	at     time.Time
+	tokens int
+}
+
+// MeteredLLM wraps an LLM, counting tokens per call type and enforcing budgets.
+//
+// Degradation is graceful: once usage passes (1 - Reserve) of either limit,
+// spontaneous, reviewer and evaluator calls are refused while reactive and conversational
+// calls continue up to the hard limit. Refused calls return ErrBudgetExhausted
+// without reaching the wrapped LLM. Safe for concurrent use.
+type MeteredLLM struct {
+	llm     LLM
+	budget  Budget
+	pricing Pricing
+	now     func() time.Time
+
+	mu      sync.Mutex
+	started time.Time
+	byType  map[CallType]Usage
+	window  []spend
+}
+
+var _ LLM = (*MeteredLLM)(nil)
+
+// NewMeteredLLM wraps llm with metering.
+func NewMeteredLLM(llm LLM, cfg MeterConfig) *MeteredLLM {
+	if llm == nil {
+		panic("NewMeteredLLM requires an LLM")
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if cfg.Budget.Reserve <= 0 || cfg.Budget.Reserve >= 1 {
+		cfg.Budget.Reserve = 0.2
+	}
+	return &MeteredLLM{
+		llm:     llm,
+		budget:  cfg.Budget,
+		pricing: cfg.Pricing,
+		now:     cfg.Now,
+		started: cfg.Now(),
+		byType:  make(map[CallType]Usage),
+	}
+}
+
+// Complete meters and forwards the call. The call type is read from ctx.
+func (m *MeteredLLM) Complete(ctx context.Context, systemPrompt, userMessage string) (string, error) {
+	t := CallTypeFrom(ctx)
+	estimate := EstimateTokens(systemPrompt) + EstimateTokens(userMessage)
+	if err := m.admit(t, estimate); err != nil {
+		return "", err
+	}
+
+	var (
+		response string
+		usage    TokenUsage
+		err      error
+	)
+	if u, ok := m.llm.(UsageLLM); ok {
+		response, usage, err = u.CompleteWithUsage(ctx, systemPrompt, userMessage)
+	} else {
+		response, err = m.llm.Complete(ctx, systemPrompt, userMessage)
+		usage = TokenUsage{InputTokens: estimate, OutputTokens: EstimateTokens(response)}
+	}
+	if err != nil && usage.InputTokens == 0 && usage.OutputTokens == 0 {
+		// Failed before any tokens were billed.
+		m.record(t, TokenUsage{})
+		return "", err
+	}
+	m.record(t, usage)
+	return response, err
+}
+
+// Report returns a snapshot of usage so far.
+func (m *MeteredLLM) Report() UsageReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r := UsageReport{
+		Started: m.started,
+		ByType:  make(map[CallType]Usage, len(m.byType)),
+	}
+	for t, u := range m.byType {
+		r.ByType[t] = u
+		r.Total.Calls += u.Calls
+		r.Total.InputTokens += u.InputTokens
+		r.Total.OutputTokens += u.OutputTokens
+		r.Total.Denied += u.Denied
+	}
+	r.CostUSD = r.Total.Cost(m.pricing)
+	r.Degraded = m.degradedLocked(m.now(), 0)
+	return r
+}
+
+// Degraded reports whether discretionary calls are currently being shed.
+func (m *MeteredLLM) Degraded() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.degradedLocked(m.now(), 0)
+}
+
+func (m *MeteredLLM) admit(t CallType, estimate int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	minute, session := m.spentLocked(now)
+	scope := ""
+	switch {
+	case over(session+estimate, m.budget.SessionTokens, 1):
+		scope = "session"
+	case over(minute+estimate, m.budget.TokensPerMinute, 1):
+		scope = "per-minute"
+	case t.discretionary() && m.degradedLocked(now, estimate):
+		scope = "reserved"
+	}
+	if scope == "" {
+		return nil
+	}
+	u := m.byType[t]
+	u.Denied++
+	m.byType[t] = u
+	return fmt.Errorf("%s call refused by %s budget: %w", t, scope, ErrBudgetExhausted)
+}
+
+func (m *MeteredLLM) record(t CallType, usage TokenUsage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u := m.byType[t]
+	u.Calls++
+	u.InputTokens += usage.InputTokens
+	u.OutputTokens += usage.OutputTokens
+	m.byType[t] = u
+	if n := usage.InputTokens + usage.OutputTokens; n > 0 {
+		m.window = append(m.window, spend{at: m.now(), tokens: n})
+	}
+}
+
+// spentLocked prunes the rolling window and returns tokens spent in the last
+// minute and in the whole session.
+func (m *MeteredLLM) spentLocked(now time.Time) (minute, session int) {
+	cutoff := now.Add(-time.Minute)
+	keep := m.window[:0]
+	for _, s := range m.window {
+		if s.at.After(cutoff) {
+			keep = append(keep, s)
+			minute += s.tokens
+		}
+	}
+	m.window = keep
+	for _, u := range m.byType {
+		session += u.Tokens()
+	}
+	return minute, session
+}
+
+func (m *MeteredLLM) degradedLocked(now time.Time, extra int) bool {
+	minute, session := m.spentLocked(now)
+	soft := 1 - m.budget.Reserve
+	return over(session+extra, m.budget.SessionTokens, soft) || over(minute+extra, m.budget.TokensPerMinute, soft)
+}
+
+// over reports whether spent exceeds fraction of limit. A zero limit never trips.
+func over(spent, limit int, fraction float64) bool {
+	return limit > 0 && float64(spent) > float64(limit)*fraction
+}
@@ -0,0 +1,151 @@
+package consciousness
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marczahn/person/internal/psychology"
+)
+
+// usageLLM reports exact token usage like the Claude adapter.
+type usageLLM struct {
+	usage TokenUsage
+	calls int
+}
+
+func (u *usageLLM) Complete(ctx context.Context, system, user string) (string, error) {
+	text, _, err := u.CompleteWithUsage(ctx, system, user)
+	return text, err
+}
+
+func (u *usageLLM) CompleteWithUsage(ctx context.Context, system, user string) (string, TokenUsage, error) {
+	u.calls++
+	return "ok", u.usage, nil
+}
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func TestMeteredLLM_CountsPerCallType(t *testing.T) {
+	inner := &usageLLM{usage: TokenUsage{InputTokens: 100, OutputTokens: 20}}
+	m := NewMeteredLLM(inner, MeterConfig{Pricing: Pricing{InputPerMTok: 1, OutputPerMTok: 5}})
+
+	ctx := context.Background()
+	m.Complete(WithCallType(ctx, CallReactive), "s", "u")
+	m.Complete(WithCallType(ctx, CallReactive), "s", "u")
+	m.Complete(WithCallType(ctx, CallReviewer), "s", "u")
+	m.Complete(ctx, "s", "u")
+
+	r := m.Report()
+	if got := r.ByType[CallReactive]; got.Calls != 2 || got.InputTokens != 200 || got.OutputTokens != 40 {
+		t.Errorf("reactive usage = %+v", got)
+	}
+	if r.ByType[CallReviewer].Calls != 1 {
+		t.Errorf("reviewer calls = %d, want 1", r.ByType[CallReviewer].Calls)
+	}
+	if r.ByType[CallMind].Calls != 1 {
+		t.Errorf("untagged calls should count as mind, got %+v", r.ByType)
+	}
+	if r.Total.Tokens() != 480 {
+		t.Errorf("total tokens = %d, want 480", r.Total.Tokens())
+	}
+	want := 400.0/1e6*1 + 80.0/1e6*5
+	if diff := r.CostUSD - want; diff > 1e-12 || diff < -1e-12 {
+		t.Errorf("cost = %v, want %v", r.CostUSD, want)
+	}
+}
+
+func TestMeteredLLM_EstimatesWithoutUsage(t *testing.T) {
+	m := NewMeteredLLM(&mockLLM{response: strings.Repeat("x", 40)}, MeterConfig{})
+
+	m.Complete(context.Background(), strings.Repeat("s", 80), strings.Repeat("u", 20))
+
+	u := m.Report().Total
+	if u.InputTokens != 25 || u.OutputTokens != 10 {
+		t.Errorf("estimated usage = %+v, want 25 in / 10 out", u)
+	}
+}
+
+func TestMeteredLLM_PerMinuteBudgetRecovers(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 6, 15, 8, 0, 0, 0, time.UTC)}
+	inner := &usageLLM{usage: TokenUsage{InputTokens: 400, OutputTokens: 100}}
+	m := NewMeteredLLM(inner, MeterConfig{
+		Budget: Budget{TokensPerMinute: 1000},
+		Now:    clock.now,
+	})
+	ctx := WithCallType(context.Background(), CallConversational)
+
+	m.Complete(ctx, "s", "u")
+	m.Complete(ctx, "s", "u")
+	if _, err := m.Complete(ctx, "s", "u"); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("third call err = %v, want ErrBudgetExhausted", err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("refused call reached the LLM: %d calls", inner.calls)
+	}
+
+	clock.t = clock.t.Add(61 * time.Second)
+	if _, err := m.Complete(ctx, "s", "u"); err != nil {
+		t.Errorf("call after window rolled over: %v", err)
+	}
+	if d := m.Report().ByType[CallConversational].Denied; d != 1 {
+		t.Errorf("denied = %d, want 1", d)
+	}
+}
+
+func TestMeteredLLM_DegradesDiscretionaryCallsFirst(t *testing.T) {
+	inner := &usageLLM{usage: TokenUsage{InputTokens: 700, OutputTokens: 150}}
+	m := NewMeteredLLM(inner, MeterConfig{Budget: Budget{SessionTokens: 1000}})
+	ctx := context.Background()
+
+	m.Complete(WithCallType(ctx, CallReactive), "s", "u")
+	if !m.Degraded() {
+		t.Fatal("expected degradation past 80% of the session budget")
+	}
+	if _, err := m.Complete(WithCallType(ctx, CallSpontaneous), "s", "u"); !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("spontaneous err = %v, want ErrBudgetExhausted", err)
+	}
+	if _, err := m.Complete(WithCallType(ctx, CallConversational), "s", "u"); err != nil {
+		t.Errorf("conversational call inside the reserve: %v", err)
+	}
+	if _, err := m.Complete(WithCallType(ctx, CallConversational), "s", "u"); !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("call past the session budget err = %v, want ErrBudgetExhausted", err)
+	}
+}
+
+func TestEngine_Respond_BudgetExhausted_NoThought(t *testing.T) {
+	inner := &mockLLM{response: "Hello."}
+	m := NewMeteredLLM(inner, MeterConfig{Budget: Budget{SessionTokens: 1}})
+	engine := NewEngine(EngineConfig{LLM: m})
+
+	ps := &psychology.State{Arousal: 0.2}
+	thought, err := engine.Respond(context.Background(), ps, ExternalInput{Type: InputSpeech, Content: "hi"})
+	if err != nil {
+		t.Fatalf("budget refusal should not be an error: %v", err)
+	}
+	if thought != nil {
+		t.Errorf("expected no thought, got %q", thought.Content)
+	}
+	if inner.calls != 0 {
+		t.Errorf("LLM called %d times over budget", inner.calls)
+	}
+}
+
+func TestUsageReport_SessionRecord(t *testing.T) {
+	inner := &usageLLM{usage: TokenUsage{InputTokens: 10, OutputTokens: 5}}
+	m := NewMeteredLLM(inner, MeterConfig{})
+	m.Complete(WithCallType(context.Background(), CallSpontaneous), "s", "u")
+	m.Complete(WithCallType(context.Background(), CallReactive), "s", "u")
+
+	rec := m.Report().SessionRecord("haiku", time.Now())
+	if rec.Model != "haiku" || rec.ID == "" {
+		t.Errorf("record = %+v", rec)
+	}
+	if len(rec.Calls) != 2 || rec.Calls[0].Type != "reactive" || rec.Calls[1].InputTokens != 10 {
+		t.Errorf("calls = %+v, want sorted reactive, spontaneous", rec.Calls)
+	}
+}
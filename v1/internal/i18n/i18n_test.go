@@ -205,4 +205,13 @@ func TestGerman_HasMatchingStructure(t *testing.T) {
 	if tr.Output.SourceLabels.Sense == "" {
 		t.Error("German: missing sense source label")
 	}
+	if tr.CLI.BudgetLow == "" || tr.CLI.BudgetRecovered == "" || tr.CLI.UsageSummary == "" || tr.CLI.UsageDenied == "" {
+		t.Error("German: missing LLM budget messages")
+	}
+	for _, msg := range []string{tr.CLI.Paused, tr.CLI.Resumed, tr.CLI.SpeedSet, tr.CLI.ScenarioCleared,
+		tr.CLI.SnapshotSaved, tr.CLI.SnapshotRestored, tr.CLI.ValueClamped} {
+		if msg == "" {
+			t.Error("German: missing command acknowledgement")
+		}
+	}
 }
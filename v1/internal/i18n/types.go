@@ -85,8 +85,16 @@ type ConsciousnessPrompts struct {
 // FeedbackTranslations holds keyword lists for detecting distortions and
 // coping strategies in LLM output.
 type FeedbackTranslations struct {
-	Distortions map[string][]string `yaml:"distortions"`
-	Coping      map[string][]string `yaml:"coping"`
+	Distortions map[string][]string   `yaml:"distortions"`
+	Coping      map[string][]string   `yaml:"coping"`
+	Evaluator   EvaluatorTranslations `yaml:"evaluator"`
+}
+
+// EvaluatorTranslations holds the blind thought evaluator prompt.
+type EvaluatorTranslations struct {
+	SystemPrompt string `yaml:"system_prompt"`
+	Affect       string `yaml:"affect"`  // format: arousal, valence, energy
+	Thought      string `yaml:"thought"` // format: thought content
 }
 
 // SenseTranslations holds sensory parser keyword rules and descriptions.
@@ -205,6 +213,17 @@ type CLITranslations struct {
 	Speech             string `yaml:"speech"`
 	ScenarioLoaded     string `yaml:"scenario_loaded"`
 	ScenarioUpdated    string `yaml:"scenario_updated"`
+	BudgetLow          string `yaml:"budget_low"`
+	BudgetRecovered    string `yaml:"budget_recovered"`
+	UsageSummary       string `yaml:"usage_summary"`
+	UsageDenied        string `yaml:"usage_denied"`
+	Paused             string `yaml:"paused"`
+	Resumed            string `yaml:"resumed"`
+	SpeedSet           string `yaml:"speed_set"`
+	ScenarioCleared    string `yaml:"scenario_cleared"`
+	SnapshotSaved      string `yaml:"snapshot_saved"`
+	SnapshotRestored   string `yaml:"snapshot_restored"`
+	ValueClamped       string `yaml:"value_clamped"`
 }
 
 // ClientTranslations holds TUI text.
@@ -225,11 +244,12 @@ type OutputTranslations struct {
 
 // SourceLabels maps source types to their display labels.
 type SourceLabels struct {
-	Sense  string `yaml:"sense"`
-	Bio    string `yaml:"bio"`
-	Psych  string `yaml:"psych"`
-	Mind   string `yaml:"mind"`
-	Review string `yaml:"review"`
+	Sense   string `yaml:"sense"`
+	Bio     string `yaml:"bio"`
+	Psych   string `yaml:"psych"`
+	Mind    string `yaml:"mind"`
+	Review  string `yaml:"review"`
+	Control string `yaml:"control"`
 }
 
 // BiologyTranslations holds display names for biology layer output.
@@ -90,6 +90,15 @@ func createSchema(db *sql.DB) error {
 		created_at TEXT,
 		traumatic INTEGER
 	);
+
+	CREATE TABLE IF NOT EXISTS sessions (
+		id TEXT PRIMARY KEY,
+		started_at TEXT,
+		ended_at TEXT,
+		model TEXT,
+		calls TEXT,
+		cost_usd REAL
+	);
 	`
 	_, err := db.Exec(schema)
 	return err
@@ -314,6 +323,42 @@ func (s *SQLiteStore) LoadEmotionalMemories() ([]psychology.EmotionalMemory, err
 	return memories, rows.Err()
 }
 
+func (s *SQLiteStore) SaveSession(r *SessionRecord) error {
+	calls, _ := json.Marshal(r.Calls)
+	_, err := s.db.Exec(`
+		INSERT OR REPLACE INTO sessions (id, started_at, ended_at, model, calls, cost_usd)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		r.ID, r.StartedAt.Format(time.RFC3339Nano), r.EndedAt.Format(time.RFC3339Nano),
+		r.Model, string(calls), r.CostUSD,
+	)
+	return err
+}
+
+func (s *SQLiteStore) LoadSessions() ([]SessionRecord, error) {
+	rows, err := s.db.Query(`
+		SELECT id, started_at, ended_at, model, calls, cost_usd
+		FROM sessions ORDER BY started_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []SessionRecord
+	for rows.Next() {
+		var r SessionRecord
+		var started, ended, calls string
+		if err := rows.Scan(&r.ID, &started, &ended, &r.Model, &calls, &r.CostUSD); err != nil {
+			return nil, err
+		}
+		r.StartedAt, _ = time.Parse(time.RFC3339Nano, started)
+		r.EndedAt, _ = time.Parse(time.RFC3339Nano, ended)
+		json.Unmarshal([]byte(calls), &r.Calls)
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
 func (s *SQLiteStore) Close() error {
 	return s.db.Close()
 }
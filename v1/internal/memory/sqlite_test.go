@@ -359,6 +359,44 @@ func TestSQLiteStore_PersistenceAcrossReopen(t *testing.T) {
 	}
 }
 
+func TestSQLiteStore_Sessions_RoundTrip(t *testing.T) {
+	store := tempDB(t)
+
+	start := time.Date(2024, 6, 15, 8, 0, 0, 0, time.UTC)
+	rec := SessionRecord{
+		ID:        "s1",
+		StartedAt: start,
+		EndedAt:   start.Add(time.Hour),
+		Model:     "claude-haiku-4-5",
+		Calls: []CallUsage{
+			{Type: "reactive", Calls: 4, InputTokens: 2000, OutputTokens: 300},
+			{Type: "spontaneous", Calls: 1, InputTokens: 500, OutputTokens: 80, Denied: 3},
+		},
+		CostUSD: 0.0123,
+	}
+	if err := store.SaveSession(&rec); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+
+	loaded, err := store.LoadSessions()
+	if err != nil {
+		t.Fatalf("LoadSessions: %v", err)
+	}
+	if len(loaded) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(loaded))
+	}
+	got := loaded[0]
+	if got.ID != "s1" || got.Model != rec.Model || got.CostUSD != rec.CostUSD {
+		t.Errorf("session = %+v, want %+v", got, rec)
+	}
+	if !got.EndedAt.Equal(rec.EndedAt) {
+		t.Errorf("EndedAt = %v, want %v", got.EndedAt, rec.EndedAt)
+	}
+	if len(got.Calls) != 2 || got.Calls[1] != rec.Calls[1] {
+		t.Errorf("Calls = %+v, want %+v", got.Calls, rec.Calls)
+	}
+}
+
 func TestSQLiteStore_InvalidPath_ReturnsError(t *testing.T) {
 	_, err := NewSQLiteStore("/nonexistent/dir/test.db")
 	if err == nil {
@@ -41,6 +41,12 @@ type Store interface {
 	// LoadEmotionalMemories returns all stored emotional memories.
 	LoadEmotionalMemories() ([]psychology.EmotionalMemory, error)
 
+	// SaveSession stores the usage record of a finished simulation session.
+	SaveSession(r *SessionRecord) error
+
+	// LoadSessions returns all stored session records, oldest first.
+	LoadSessions() ([]SessionRecord, error)
+
 	// Close releases any resources held by the store.
 	Close() error
 }
@@ -78,3 +84,22 @@ type BioSnapshot struct {
 	Fatigue    float64
 	Hunger     float64
 }
+
+// SessionRecord summarises one simulation run's LLM spend.
+type SessionRecord struct {
+	ID        string
+	StartedAt time.Time
+	EndedAt   time.Time
+	Model     string
+	Calls     []CallUsage // per call type
+	CostUSD   float64
+}
+
+// CallUsage is the token accounting for one call type within a session.
+type CallUsage struct {
+	Type         string `json:"type"`
+	Calls        int    `json:"calls"`
+	InputTokens  int    `json:"input_tokens"`
+	OutputTokens int    `json:"output_tokens"`
+	Denied       int    `json:"denied"`
+}
@@ -14,6 +14,7 @@ const (
 	colorBlue    = "\033[34m" // PSYCH
 	colorGreen   = "\033[32m" // MIND
 	colorMagenta = "\033[35m" // REVIEW
+	colorWhite   = "\033[97m" // CTRL
 	colorGray    = "\033[90m" // timestamps
 )
 
@@ -23,6 +24,7 @@ var sourceColors = [...]string{
 	colorBlue,    // Psych
 	colorGreen,   // Mind
 	colorMagenta, // Review
+	colorWhite,   // Control
 }
 
 // Display formats and writes simulation output to a writer.
@@ -10,11 +10,12 @@ import (
 type Source int
 
 const (
-	Sense  Source = iota // sensory event parsing
-	Bio                  // biological state changes
-	Psych                // psychological state changes
-	Mind                 // consciousness thoughts/emotions
-	Review               // psychologist reviewer notes
+	Sense   Source = iota // sensory event parsing
+	Bio                   // biological state changes
+	Psych                 // psychological state changes
+	Mind                  // consciousness thoughts/emotions
+	Review                // psychologist reviewer notes
+	Control               // operator command acknowledgements and errors
 )
 
 func (s Source) String() string {
@@ -30,6 +31,8 @@ func (s Source) String() string {
 		return tr.Output.SourceLabels.Mind
 	case Review:
 		return tr.Output.SourceLabels.Review
+	case Control:
+		return tr.Output.SourceLabels.Control
 	default:
 		return tr.Output.Unknown
 	}
@@ -24,6 +24,7 @@ func TestSource_String_AllValues(t *testing.T) {
 		{Psych, "PSYCH"},
 		{Mind, "MIND"},
 		{Review, "REVIEW"},
+		{Control, "CTRL"},
 	}
 
 	for _, tt := range tests {
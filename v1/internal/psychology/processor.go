@@ -25,6 +25,17 @@ func NewProcessor(p Personality) *Processor {
 	}
 }
 
+// Personality returns the personality the processor modulates with.
+func (proc *Processor) Personality() Personality {
+	return proc.personality
+}
+
+// SetPersonality replaces the personality from the next Process call on.
+// Accumulated regulation, memories and isolation are kept.
+func (proc *Processor) SetPersonality(p Personality) {
+	proc.personality = p
+}
+
 // Process transforms a biological state into a psychological state.
 // dt is the elapsed time in seconds since the last processing cycle.
 // stressorControllability is in [0,1] — how much the current stressor
@@ -2,6 +2,7 @@ package reviewer
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/marczahn/person/internal/consciousness"
@@ -62,7 +63,8 @@ func (r *Reviewer) AddThought(t consciousness.Thought) {
 // Review builds a prompt from buffered thoughts and the current state,
 // calls the LLM, and returns a clinical observation.
 //
-// Returns nil (no error) when rate-limited or when the buffer is empty.
+// Returns nil (no error) when rate-limited, over the LLM budget, or when the
+// buffer is empty.
 func (r *Reviewer) Review(
 	ctx context.Context,
 	ps *psychology.State,
@@ -78,7 +80,11 @@ func (r *Reviewer) Review(
 	system := r.promptBuilder.SystemPrompt()
 	user := r.promptBuilder.UserPrompt(ps, personality, r.thoughts)
 
-	response, err := r.llm.Complete(ctx, system, user)
+	response, err := r.llm.Complete(consciousness.WithCallType(ctx, consciousness.CallReviewer), system, user)
+	if errors.Is(err, consciousness.ErrBudgetExhausted) {
+		r.lastReview = time.Now()
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
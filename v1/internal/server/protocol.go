@@ -2,6 +2,7 @@ package server
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/marczahn/person/internal/biology"
@@ -10,14 +11,14 @@ import (
 
 // ClientMessage is sent from the TUI client to the server.
 type ClientMessage struct {
-	Type    string `json:"type"`    // "speech", "action", "environment"
+	Type    string `json:"type"`    // "speech", "action", "environment", "command"
 	Content string `json:"content"`
 }
 
 // Validate checks that the message has a known type and non-empty content.
 func (m ClientMessage) Validate() error {
 	switch m.Type {
-	case "speech", "action", "environment":
+	case "speech", "action", "environment", "command":
 	default:
 		return fmt.Errorf("unknown message type: %q", m.Type)
 	}
@@ -28,13 +29,16 @@ func (m ClientMessage) Validate() error {
 }
 
 // ToInputLine converts a client message to the simulation's input format:
-// speech → plain text, action → *text*, environment → ~text.
+// speech → plain text, action → *text*, environment → ~text,
+// command → /text (a leading slash in the content is optional).
 func (m ClientMessage) ToInputLine() string {
 	switch m.Type {
 	case "action":
 		return "*" + m.Content + "*"
 	case "environment":
 		return "~" + m.Content
+	case "command":
+		return "/" + strings.TrimPrefix(m.Content, "/")
 	default:
 		return m.Content
 	}
@@ -45,11 +49,14 @@ func (m ClientMessage) ToInputLine() string {
 // "thought" messages use Content, ThoughtType, and Trigger.
 // "bio_state" messages use BioState.
 // "psych_state" messages use PsychState.
+// "ack" and "error" messages answer an operator command: Command is the
+// command line and Content the acknowledgement or error.
 type ServerMessage struct {
 	Type        string    `json:"type"`
 	Content     string    `json:"content,omitempty"`
 	ThoughtType string    `json:"thought_type,omitempty"`
 	Trigger     string    `json:"trigger,omitempty"`
+	Command     string    `json:"command,omitempty"`
 	Timestamp   time.Time `json:"timestamp"`
 
 	BioState   *BioStatePayload   `json:"bio_state,omitempty"`
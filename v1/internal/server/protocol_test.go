@@ -10,7 +10,7 @@ import (
 )
 
 func TestClientMessage_Validate_ValidTypes(t *testing.T) {
-	for _, typ := range []string{"speech", "action", "environment"} {
+	for _, typ := range []string{"speech", "action", "environment", "command"} {
 		msg := ClientMessage{Type: typ, Content: "hello"}
 		if err := msg.Validate(); err != nil {
 			t.Errorf("Validate(%q) returned error: %v", typ, err)
@@ -53,6 +53,33 @@ func TestClientMessage_ToInputLine_Environment(t *testing.T) {
 	}
 }
 
+func TestClientMessage_ToInputLine_Command(t *testing.T) {
+	for _, content := range []string{"speed 2", "/speed 2"} {
+		msg := ClientMessage{Type: "command", Content: content}
+		if got := msg.ToInputLine(); got != "/speed 2" {
+			t.Errorf("content %q: got %q, want %q", content, got, "/speed 2")
+		}
+	}
+}
+
+func TestServerMessage_Ack_JSONCarriesCommand(t *testing.T) {
+	msg := ServerMessage{Type: "ack", Command: "/pause", Content: "paused", Timestamp: time.Now()}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if raw["command"] != "/pause" || raw["content"] != "paused" {
+		t.Errorf("ack JSON = %s", data)
+	}
+	if _, ok := raw["bio_state"]; ok {
+		t.Errorf("ack should omit state payloads: %s", data)
+	}
+}
+
 func TestBioStatePayloadFromState_DefaultState(t *testing.T) {
 	s := biology.NewDefaultState()
 	payload := BioStatePayloadFromState(&s)
@@ -1,20 +1,31 @@
 package simulation
 
 import (
+	"math"
 	"sync"
 	"time"
 )
 
-// Clock tracks simulation time. Currently 1:1 with real time,
-// but the abstraction allows future time-scaling.
+// MaxSpeed bounds the clock speed. At the default 100ms tick it keeps each
+// biology step well inside biology.MaxAdvanceSeconds.
+const MaxSpeed = 1000.0
+
+// ValidSpeed reports whether speed is a usable clock speed: finite, positive
+// and at most MaxSpeed.
+func ValidSpeed(speed float64) bool {
+	return speed > 0 && speed <= MaxSpeed && !math.IsInf(speed, 0)
+}
+
+// Clock tracks simulation time. It runs at a settable multiple of real time
+// and can be paused.
 type Clock struct {
-	mu        sync.Mutex
-	startReal time.Time // real time when simulation started
-	startSim  time.Time // simulation time when started
-	lastTick  time.Time // real time of the last tick
-	paused    bool
-	pausedAt  time.Time // real time when paused
-	pauseAccum time.Duration // total time spent paused
+	mu         sync.Mutex
+	startSim   time.Time // simulation time when started
+	anchorReal time.Time // real time of the last start, resume or speed change
+	anchorSim  time.Time // simulation time at anchorReal
+	lastTick   time.Time // real time of the last tick
+	speed      float64   // simulated seconds per real second
+	paused     bool
 }
 
 // NewClock creates a clock starting at the given simulation time.
@@ -22,9 +33,11 @@ type Clock struct {
 func NewClock(simStart time.Time) *Clock {
 	now := time.Now()
 	return &Clock{
-		startReal: now,
-		startSim:  simStart,
-		lastTick:  now,
+		startSim:   simStart,
+		anchorReal: now,
+		anchorSim:  simStart,
+		lastTick:   now,
+		speed:      1,
 	}
 }
 
@@ -42,21 +55,22 @@ func (c *Clock) Tick() float64 {
 	dt := now.Sub(c.lastTick)
 	c.lastTick = now
 
-	return dt.Seconds()
+	return dt.Seconds() * c.speed
 }
 
 // Now returns the current simulation time.
 func (c *Clock) Now() time.Time {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	return c.now(time.Now())
+}
 
+func (c *Clock) now(real time.Time) time.Time {
 	if c.paused {
-		elapsed := c.pausedAt.Sub(c.startReal) - c.pauseAccum
-		return c.startSim.Add(elapsed)
+		return c.anchorSim
 	}
-
-	elapsed := time.Since(c.startReal) - c.pauseAccum
-	return c.startSim.Add(elapsed)
+	elapsed := time.Duration(float64(real.Sub(c.anchorReal)) * c.speed)
+	return c.anchorSim.Add(elapsed)
 }
 
 // Pause stops the clock. Subsequent Tick() calls return 0.
@@ -67,8 +81,8 @@ func (c *Clock) Pause() {
 	if c.paused {
 		return
 	}
+	c.anchorSim = c.now(time.Now())
 	c.paused = true
-	c.pausedAt = time.Now()
 }
 
 // Resume restarts the clock after a pause.
@@ -79,8 +93,9 @@ func (c *Clock) Resume() {
 	if !c.paused {
 		return
 	}
-	c.pauseAccum += time.Since(c.pausedAt)
-	c.lastTick = time.Now()
+	now := time.Now()
+	c.anchorReal = now
+	c.lastTick = now
 	c.paused = false
 }
 
@@ -91,6 +106,30 @@ func (c *Clock) Paused() bool {
 	return c.paused
 }
 
+// SetSpeed sets how many simulated seconds pass per real second.
+// Speeds that are not finite, non-positive or above MaxSpeed are ignored.
+func (c *Clock) SetSpeed(speed float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !ValidSpeed(speed) {
+		return
+	}
+	now := time.Now()
+	if !c.paused {
+		c.anchorSim = c.now(now)
+		c.anchorReal = now
+	}
+	c.speed = speed
+}
+
+// Speed returns how many simulated seconds pass per real second.
+func (c *Clock) Speed() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.speed
+}
+
 // ElapsedSim returns the total elapsed simulation time.
 func (c *Clock) ElapsedSim() time.Duration {
 	return c.Now().Sub(c.startSim)
@@ -1,6 +1,7 @@
 package simulation
 
 import (
+	"math"
 	"testing"
 	"time"
 )
@@ -155,3 +156,38 @@ func TestClock_ElapsedSim_ExcludesPausedTime(t *testing.T) {
 		t.Errorf("ElapsedSim() should exclude paused time, got %v", elapsed)
 	}
 }
+
+func TestClock_SetSpeed_ScalesTickAndNow(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := NewClock(simStart)
+	c.SetSpeed(100)
+	c.Tick()
+
+	time.Sleep(20 * time.Millisecond)
+	dt := c.Tick()
+	if dt < 1.5 || dt > 10 {
+		t.Errorf("Tick() at 100x after ~20ms should be ~2s, got %f", dt)
+	}
+	if elapsed := c.ElapsedSim(); elapsed < 1500*time.Millisecond {
+		t.Errorf("ElapsedSim() at 100x should be ~2s, got %v", elapsed)
+	}
+
+	for _, bad := range []float64{0, -1, math.Inf(1), math.NaN(), MaxSpeed * 2, 1e308} {
+		c.SetSpeed(bad)
+		if c.Speed() != 100 {
+			t.Errorf("speed %v should be ignored, got %v", bad, c.Speed())
+		}
+	}
+}
+
+func TestClock_SetSpeed_KeepsTimeContinuous(t *testing.T) {
+	c := NewClock(time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC))
+	time.Sleep(10 * time.Millisecond)
+	before := c.Now()
+	c.SetSpeed(1000)
+	after := c.Now()
+
+	if diff := after.Sub(before); diff < 0 || diff > 50*time.Millisecond {
+		t.Errorf("changing speed must not jump the clock: diff=%v", diff)
+	}
+}
@@ -0,0 +1,251 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/marczahn/person/internal/biology"
+	"github.com/marczahn/person/internal/i18n"
+	"github.com/marczahn/person/internal/output"
+	"github.com/marczahn/person/internal/psychology"
+)
+
+// CommandResult is the answer to one operator command.
+type CommandResult struct {
+	Line    string // the command as entered, e.g. "/speed 2"
+	Message string // acknowledgement; empty when Err is set
+	Err     error  // why the command was rejected; nil on success
+}
+
+// commandUsage lists every operator command with its arguments.
+var commandUsage = map[string]string{
+	"pause":    "/pause",
+	"resume":   "/resume",
+	"speed":    "/speed <factor>",
+	"set":      "/set <bio_variable> <value>",
+	"trait":    "/trait <openness|conscientiousness|extraversion|agreeableness|neuroticism> <0..1>",
+	"scenario": "/scenario <description> | /scenario off",
+	"snapshot": "/snapshot [name]",
+	"restore":  "/restore [name]",
+	"dump":     "/dump",
+}
+
+const defaultSnapshot = "default"
+
+// snapshot is the restorable part of the simulation: biology, personality
+// and scenario. The psychology processor's accumulated regulation, memories
+// and isolation are not rewound.
+type snapshot struct {
+	bio         biology.State
+	personality psychology.Personality
+	scenario    string
+}
+
+// runCommand executes one /command line, shows the acknowledgement or error
+// and hands it to OnCommand. It runs on the loop goroutine, also while paused.
+func (l *Loop) runCommand(line string) {
+	_, content := classifyInput(line)
+	res := CommandResult{Line: strings.TrimSpace(line)}
+	res.Message, res.Err = l.execute(content)
+
+	msg := fmt.Sprintf("%s: %s", res.Line, res.Message)
+	if res.Err != nil {
+		msg = fmt.Sprintf("%s: error: %v", res.Line, res.Err)
+	}
+	l.cfg.Display.Show(output.Entry{
+		Source:    output.Control,
+		Message:   msg,
+		Timestamp: l.clock.Now(),
+	})
+	if l.cfg.OnCommand != nil {
+		l.cfg.OnCommand(res)
+	}
+}
+
+// execute applies a command and returns its acknowledgement.
+func (l *Loop) execute(content string) (string, error) {
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return "", errors.New("empty command")
+	}
+	name, args := strings.ToLower(fields[0]), fields[1:]
+	usage, ok := commandUsage[name]
+	if !ok {
+		return "", fmt.Errorf("unknown command %q (commands: %s)", name, strings.Join(commandNames(), ", "))
+	}
+	wrongArgs := fmt.Errorf("usage: %s", usage)
+
+	switch name {
+	case "pause", "resume", "dump":
+		if len(args) != 0 {
+			return "", wrongArgs
+		}
+	case "speed":
+		if len(args) != 1 {
+			return "", wrongArgs
+		}
+	case "set", "trait":
+		if len(args) != 2 {
+			return "", wrongArgs
+		}
+	case "scenario":
+		if len(args) == 0 {
+			return "", wrongArgs
+		}
+	case "snapshot", "restore":
+		if len(args) > 1 {
+			return "", wrongArgs
+		}
+	}
+
+	switch name {
+	case "pause":
+		l.Pause()
+		return i18n.T().CLI.Paused, nil
+	case "resume":
+		l.Resume()
+		return i18n.T().CLI.Resumed, nil
+	case "speed":
+		speed, err := strconv.ParseFloat(args[0], 64)
+		if err != nil || !ValidSpeed(speed) {
+			return "", fmt.Errorf("speed must be a number between 0 and %g, got %q", MaxSpeed, args[0])
+		}
+		l.clock.SetSpeed(speed)
+		return fmt.Sprintf(i18n.T().CLI.SpeedSet, speed), nil
+	case "set":
+		return l.setBio(args[0], args[1])
+	case "trait":
+		return l.setTrait(args[0], args[1])
+	case "scenario":
+		text := strings.Join(args, " ")
+		if strings.EqualFold(text, "off") {
+			l.setScenario("")
+			return i18n.T().CLI.ScenarioCleared, nil
+		}
+		l.setScenario(text)
+		return i18n.T().CLI.ScenarioUpdated, nil
+	case "snapshot":
+		key := snapshotName(args)
+		l.snapshots[key] = snapshot{
+			bio:         *l.cfg.BioState,
+			personality: l.cfg.PsychProcessor.Personality(),
+			scenario:    l.scenario,
+		}
+		return fmt.Sprintf(i18n.T().CLI.SnapshotSaved, key), nil
+	case "restore":
+		key := snapshotName(args)
+		snap, ok := l.snapshots[key]
+		if !ok {
+			return "", fmt.Errorf("no snapshot %q", key)
+		}
+		*l.cfg.BioState = snap.bio
+		l.applyPersonality(snap.personality)
+		l.setScenario(snap.scenario)
+		return fmt.Sprintf(i18n.T().CLI.SnapshotRestored, key), nil
+	default: // dump
+		return l.dump(), nil
+	}
+}
+
+func (l *Loop) setBio(name, raw string) (string, error) {
+	v, ok := biology.ParseVariable(strings.ToLower(name))
+	if !ok {
+		return "", fmt.Errorf("unknown bio variable %q", name)
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return "", fmt.Errorf("value must be a number, got %q", raw)
+	}
+	clamped := biology.ClampVariable(v, value)
+	l.cfg.BioState.Set(v, clamped)
+	if clamped != value {
+		return fmt.Sprintf(i18n.T().CLI.ValueClamped, v, clamped, value), nil
+	}
+	return fmt.Sprintf("%s = %.2f", v, clamped), nil
+}
+
+func (l *Loop) setTrait(name, raw string) (string, error) {
+	p := l.cfg.PsychProcessor.Personality()
+	field, ok := traitField(&p, strings.ToLower(name))
+	if !ok {
+		return "", fmt.Errorf("unknown trait %q", name)
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value < 0 || value > 1 {
+		return "", fmt.Errorf("trait value must be between 0 and 1, got %q", raw)
+	}
+	*field = value
+	l.applyPersonality(p)
+	return fmt.Sprintf("%s = %.2f", strings.ToLower(name), value), nil
+}
+
+// applyPersonality updates the processor and the shared personality the
+// reviewer and state snapshots read.
+func (l *Loop) applyPersonality(p psychology.Personality) {
+	l.cfg.PsychProcessor.SetPersonality(p)
+	if l.cfg.Personality != nil {
+		*l.cfg.Personality = p
+	}
+}
+
+func (l *Loop) setScenario(text string) {
+	l.scenario = text
+	l.cfg.Consciousness.UpdateScenario(text)
+}
+
+// dump renders the current state on one line.
+func (l *Loop) dump() string {
+	var bio []string
+	for v := biology.VarBodyTemp; v <= biology.VarEndorphins; v++ {
+		bio = append(bio, fmt.Sprintf("%s=%.2f", v, l.cfg.BioState.Get(v)))
+	}
+	p := l.cfg.PsychProcessor.Personality()
+	state := "running"
+	if l.clock.Paused() {
+		state = "paused"
+	}
+	scenario := l.scenario
+	if scenario == "" {
+		scenario = "none"
+	}
+	return fmt.Sprintf("clock %s (%s, speed %gx); bio %s; personality openness=%.2f conscientiousness=%.2f extraversion=%.2f agreeableness=%.2f neuroticism=%.2f; scenario %s",
+		l.clock.Now().Format("2006-01-02 15:04:05"), state, l.clock.Speed(),
+		strings.Join(bio, " "),
+		p.Openness, p.Conscientiousness, p.Extraversion, p.Agreeableness, p.Neuroticism,
+		scenario)
+}
+
+func traitField(p *psychology.Personality, name string) (*float64, bool) {
+	switch name {
+	case "openness":
+		return &p.Openness, true
+	case "conscientiousness":
+		return &p.Conscientiousness, true
+	case "extraversion":
+		return &p.Extraversion, true
+	case "agreeableness":
+		return &p.Agreeableness, true
+	case "neuroticism":
+		return &p.Neuroticism, true
+	}
+	return nil, false
+}
+
+func snapshotName(args []string) string {
+	if len(args) == 0 {
+		return defaultSnapshot
+	}
+	return args[0]
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commandUsage))
+	for name := range commandUsage {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,158 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marczahn/person/internal/biology"
+	"github.com/marczahn/person/internal/i18n"
+)
+
+func TestClassifyInput_Command(t *testing.T) {
+	typ, content := classifyInput("  /speed 2 ")
+	if typ != TypeCommand || content != "speed 2" {
+		t.Errorf("got (%v, %q), want (TypeCommand, %q)", typ, content, "speed 2")
+	}
+	if typ, _ := classifyInput("/"); typ != TypeSpeech {
+		t.Errorf("a bare slash should fall back to speech, got %v", typ)
+	}
+}
+
+func TestCommand_AcknowledgesAndReportsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	loop := newTestLoop(strings.NewReader(""), &buf)
+	var results []CommandResult
+	loop.cfg.OnCommand = func(res CommandResult) { results = append(results, res) }
+
+	for _, line := range []string{"/speed 4", "/speed fast", "/jump", "/pause now", "/speed Inf", "/speed 1e308", "/speed NaN"} {
+		loop.runCommand(line)
+	}
+
+	if len(results) != 7 {
+		t.Fatalf("expected one answer per command, got %d", len(results))
+	}
+	if results[0].Err != nil || results[0].Message != "speed 4x" || loop.clock.Speed() != 4 {
+		t.Errorf("speed: %+v, clock speed %v", results[0], loop.clock.Speed())
+	}
+	for _, res := range results[1:] {
+		if res.Err == nil {
+			t.Errorf("%s should fail, got %q", res.Line, res.Message)
+		}
+	}
+	if !strings.Contains(results[2].Err.Error(), "unknown command") || !strings.Contains(results[3].Err.Error(), "usage: /pause") {
+		t.Errorf("errors should say what went wrong: %v / %v", results[2].Err, results[3].Err)
+	}
+	if loop.clock.Speed() != 4 {
+		t.Errorf("rejected speeds must not apply, got %v", loop.clock.Speed())
+	}
+	out := buf.String()
+	if !strings.Contains(out, "[CTRL") || !strings.Contains(out, "/speed 4: speed 4x") || !strings.Contains(out, "/jump: error:") {
+		t.Errorf("answers should be shown on the display, got:\n%s", out)
+	}
+}
+
+func TestCommand_SetsBioAndTraits(t *testing.T) {
+	var buf bytes.Buffer
+	loop := newTestLoop(strings.NewReader(""), &buf)
+
+	loop.runCommand("/set cortisol 0.7")
+	if got := loop.cfg.BioState.Cortisol; got != 0.7 {
+		t.Errorf("cortisol = %v, want 0.7", got)
+	}
+	loop.runCommand("/set hunger 5")
+	if got := loop.cfg.BioState.Hunger; got != biology.ClampVariable(biology.VarHunger, 5) {
+		t.Errorf("out-of-range values should be clamped, got %v", got)
+	}
+	loop.runCommand("/set mojo 1")
+
+	loop.runCommand("/trait neuroticism 0.9")
+	if got := loop.cfg.PsychProcessor.Personality().Neuroticism; got != 0.9 {
+		t.Errorf("neuroticism = %v, want 0.9", got)
+	}
+	loop.runCommand("/trait neuroticism 2")
+	if got := loop.cfg.PsychProcessor.Personality().Neuroticism; got != 0.9 {
+		t.Errorf("rejected trait value must not apply, got %v", got)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"cortisol = 0.70", "clamped from 5", `unknown bio variable "mojo"`, "neuroticism = 0.90", "between 0 and 1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestCommand_SnapshotRestoreAndDump(t *testing.T) {
+	var buf bytes.Buffer
+	loop := newTestLoop(strings.NewReader(""), &buf)
+
+	loop.runCommand("/scenario a cold cellar")
+	loop.runCommand("/set adrenaline 0.2")
+	loop.runCommand("/snapshot calm")
+	loop.runCommand("/set adrenaline 0.9")
+	loop.runCommand("/trait openness 0.1")
+	loop.runCommand("/scenario off")
+
+	loop.runCommand("/restore calm")
+	if got := loop.cfg.BioState.Adrenaline; got != 0.2 {
+		t.Errorf("adrenaline after restore = %v, want 0.2", got)
+	}
+	if got := loop.cfg.PsychProcessor.Personality().Openness; got != 0.5 {
+		t.Errorf("openness after restore = %v, want 0.5", got)
+	}
+	if loop.scenario != "a cold cellar" {
+		t.Errorf("scenario after restore = %q", loop.scenario)
+	}
+	loop.runCommand("/restore nothing")
+
+	var answer CommandResult
+	loop.cfg.OnCommand = func(res CommandResult) { answer = res }
+	loop.runCommand("/dump")
+	for _, want := range []string{"adrenaline=0.20", "openness=0.50", "scenario a cold cellar", "speed 1x"} {
+		if !strings.Contains(answer.Message, want) {
+			t.Errorf("dump should contain %q, got %q", want, answer.Message)
+		}
+	}
+	if !strings.Contains(buf.String(), `no snapshot "nothing"`) {
+		t.Errorf("restoring an unknown snapshot should fail:\n%s", buf.String())
+	}
+	if want := "/scenario a cold cellar: " + i18n.T().CLI.ScenarioUpdated; !strings.Contains(buf.String(), want) {
+		t.Errorf("/scenario should acknowledge like a scenario line, want %q in:\n%s", want, buf.String())
+	}
+}
+
+func TestLoop_CommandsRunWhilePaused(t *testing.T) {
+	var buf bytes.Buffer
+	pr, pw := io.Pipe()
+	loop := newTestLoop(pr, &buf)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- loop.Run(ctx)
+	}()
+
+	pw.Write([]byte("/pause\n"))
+	time.Sleep(100 * time.Millisecond)
+	if !loop.clock.Paused() {
+		t.Fatal("/pause should pause the clock")
+	}
+	pw.Write([]byte("/resume\n"))
+	time.Sleep(100 * time.Millisecond)
+	paused := loop.clock.Paused()
+
+	cancel()
+	pw.Close()
+	<-done
+
+	if paused {
+		t.Error("/resume must be handled while paused")
+	}
+	if !strings.Contains(buf.String(), "/resume: resumed") {
+		t.Errorf("expected resume acknowledgement, got:\n%s", buf.String())
+	}
+}
@@ -3,6 +3,7 @@ package simulation
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -26,10 +27,12 @@ const (
 	TypeAction                       // *text* — someone does something to/near the person
 	TypeEnvironment                  // ~text — transient environmental event
 	TypeScenario                     // @text — set persistent physical scenario (non-empty body required)
+	TypeCommand                      // /text — operator command (non-empty body required)
 )
 
 /// classifyInput determines the input type from text conventions:
-// *text* → action, ~text → environment, @text → scenario (non-empty body), plain text → speech.
+// *text* → action, ~text → environment, @text → scenario (non-empty body),
+// /text → command (non-empty body), plain text → speech.
 func classifyInput(raw string) (InputType, string) {
 	trimmed := strings.TrimSpace(raw)
 	if strings.HasPrefix(trimmed, "*") && strings.HasSuffix(trimmed, "*") && len(trimmed) > 2 {
@@ -44,6 +47,12 @@ func classifyInput(raw string) (InputType, string) {
 			return TypeScenario, content
 		}
 	}
+	if strings.HasPrefix(trimmed, "/") {
+		content := strings.TrimSpace(trimmed[1:])
+		if content != "" {
+			return TypeCommand, content
+		}
+	}
 	return TypeSpeech, trimmed
 }
 
@@ -61,6 +70,18 @@ type Config struct {
 	Reviewer    *reviewer.Reviewer
 	Personality *psychology.Personality
 
+	// Somatic feedback regime (ADR-002). Empty FeedbackMode means keyword.
+	// Evaluator is required for the evaluator and blend modes; EvaluatorWeight
+	// is the evaluator's share in blend mode (default 0.5).
+	FeedbackMode    consciousness.FeedbackMode
+	Evaluator       consciousness.Evaluator
+	EvaluatorWeight float64
+
+	// Optional LLM metering. When set, budget degradation is announced and on
+	// shutdown the usage summary is shown and persisted as a session record.
+	Meter *consciousness.MeteredLLM
+	Model string // model name recorded in the session record
+
 	// Initial state.
 	BioState *biology.State
 	Identity *memory.IdentityCore
@@ -78,6 +99,10 @@ type Config struct {
 	// the current bio and psych states. Nil in non-server mode. Must not block.
 	OnStateSnapshot func(bio *biology.State, psych psychology.State)
 
+	// OnCommand is called with the answer to every /command, after it has
+	// been shown on the display. Nil in non-server mode. Must not block.
+	OnCommand func(CommandResult)
+
 	// IO.
 	Input io.Reader // stdin or test reader
 }
@@ -97,11 +122,23 @@ type Loop struct {
 	// Channel for input events from the reader goroutine.
 	inputCh chan string
 
+	// Channel for operator commands, which run even while paused.
+	commandCh chan string
+
+	// scenario is the current physical environment description.
+	scenario string
+
+	// snapshots holds named states saved by /snapshot.
+	snapshots map[string]snapshot
+
 	// Tracks active thresholds to avoid displaying the same one every tick.
 	activeThresholds map[thresholdKey]bool
 
 	// lastSnapshot tracks when OnStateSnapshot was last called.
 	lastSnapshot time.Time
+
+	// budgetDegraded tracks the last announced Meter degradation state.
+	budgetDegraded bool
 }
 
 // NewLoop creates a simulation loop from the given configuration.
@@ -112,6 +149,12 @@ func NewLoop(cfg Config) *Loop {
 	if cfg.StateSnapshotInterval == 0 {
 		cfg.StateSnapshotInterval = 2 * time.Second
 	}
+	if cfg.FeedbackMode == "" {
+		cfg.FeedbackMode = consciousness.FeedbackKeyword
+	}
+	if cfg.EvaluatorWeight == 0 {
+		cfg.EvaluatorWeight = 0.5
+	}
 	if cfg.Scenario != "" && cfg.Consciousness != nil {
 		cfg.Consciousness.UpdateScenario(cfg.Scenario)
 	}
@@ -119,6 +162,9 @@ func NewLoop(cfg Config) *Loop {
 		cfg:              cfg,
 		clock:            NewClock(cfg.SimStart),
 		inputCh:          make(chan string, 16),
+		commandCh:        make(chan string, 16),
+		scenario:         cfg.Scenario,
+		snapshots:        make(map[string]snapshot),
 		activeThresholds: make(map[thresholdKey]bool),
 	}
 }
@@ -136,6 +182,8 @@ func (l *Loop) Run(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return l.shutdown()
+		case line := <-l.commandCh:
+			l.runCommand(line)
 		case <-ticker.C:
 			if l.clock.Paused() {
 				continue
@@ -172,8 +220,9 @@ func (l *Loop) tick(ctx context.Context) error {
 	// 1. Drain pending input events.
 	l.processInput(ctx, now)
 
-	// 2. Biology tick (decay, circadian, interactions, thresholds).
-	bioResult := l.cfg.BioProcessor.Tick(l.cfg.BioState)
+	// 2. Biology tick (decay, circadian, interactions, thresholds), driven by
+	// the simulation clock so speed and pauses apply.
+	bioResult := l.cfg.BioProcessor.Advance(l.cfg.BioState, dt)
 	l.displayBioChanges(bioResult, now)
 
 	// 3. Psychology: transform bio → psych state.
@@ -192,7 +241,7 @@ func (l *Loop) tick(ctx context.Context) error {
 	}
 	if reactive != nil {
 		l.displayThought(reactive, now)
-		l.applyFeedback(reactive)
+		l.applyFeedback(ctx, reactive, &psychState)
 	}
 
 	// 5. Consciousness: spontaneous thought.
@@ -202,15 +251,41 @@ func (l *Loop) tick(ctx context.Context) error {
 	}
 	if spontaneous != nil {
 		l.displayThought(spontaneous, now)
-		l.applyFeedback(spontaneous)
+		l.applyFeedback(ctx, spontaneous, &psychState)
 	}
 
 	// 6. Psychologist reviewer (optional).
 	l.runReviewer(ctx, reactive, spontaneous, &psychState, now)
 
+	// 7. Announce LLM budget degradation changes (optional).
+	l.reportBudget(now)
+
 	return nil
 }
 
+// reportBudget shows a line whenever the meter starts or stops shedding
+// discretionary calls.
+func (l *Loop) reportBudget(now time.Time) {
+	if l.cfg.Meter == nil {
+		return
+	}
+	degraded := l.cfg.Meter.Degraded()
+	if degraded == l.budgetDegraded {
+		return
+	}
+	l.budgetDegraded = degraded
+	cli := &i18n.T().CLI
+	msg := cli.BudgetRecovered
+	if degraded {
+		msg = cli.BudgetLow
+	}
+	l.cfg.Display.Show(output.Entry{
+		Source:    output.Mind,
+		Message:   msg,
+		Timestamp: now,
+	})
+}
+
 // processInput drains the input channel and routes each input based on type:
 // speech/action → consciousness.Respond, environment → sensory parser only.
 func (l *Loop) processInput(ctx context.Context, now time.Time) {
@@ -259,7 +334,7 @@ func (l *Loop) processSpeech(ctx context.Context, content string, now time.Time)
 	}
 	if thought != nil {
 		l.displayThought(thought, now)
-		l.applyFeedback(thought)
+		l.applyFeedback(ctx, thought, &psychState)
 	}
 }
 
@@ -302,7 +377,7 @@ func (l *Loop) processAction(ctx context.Context, content string, now time.Time)
 	}
 	if thought != nil {
 		l.displayThought(thought, now)
-		l.applyFeedback(thought)
+		l.applyFeedback(ctx, thought, &psychState)
 	}
 }
 
@@ -331,7 +406,7 @@ func (l *Loop) processEnvironment(content string, now time.Time) {
 // processScenario updates the persistent physical environment description in the
 // consciousness engine and acknowledges it to the display.
 func (l *Loop) processScenario(content string, now time.Time) {
-	l.cfg.Consciousness.UpdateScenario(content)
+	l.setScenario(content)
 	l.cfg.Display.Show(output.Entry{
 		Source:    output.Sense,
 		Message:   i18n.T().CLI.ScenarioUpdated,
@@ -428,12 +503,27 @@ func (l *Loop) displayThought(thought *consciousness.Thought, now time.Time) {
 }
 
 // applyFeedback converts consciousness feedback into absolute biological state
-// changes. Pulses are one-time events — not dt-scaled — so they apply equally
-// to reactive thoughts (fired during ticks) and conversational responses
-// (fired outside ticks where dt=0 was previously causing zero effect).
-func (l *Loop) applyFeedback(thought *consciousness.Thought) {
-	changes := consciousness.EmotionalPulses(thought.Feedback.EmotionalState)
-	changes = append(changes, consciousness.FeedbackToChanges(thought.Feedback)...)
+// changes. In the evaluator and blend modes the thought is first judged by the
+// blind evaluator; if it is unavailable the keyword path applies in full.
+func (l *Loop) applyFeedback(ctx context.Context, thought *consciousness.Thought, ps *psychology.State) {
+	var eval *consciousness.Evaluation
+	if l.cfg.FeedbackMode != consciousness.FeedbackKeyword && l.cfg.Evaluator != nil {
+		ev, err := l.cfg.Evaluator.Evaluate(ctx, thought.Content, consciousness.AffectOf(ps))
+		switch {
+		case err == nil:
+			eval = &ev
+			thought.Feedback = consciousness.MergeEvaluation(l.cfg.FeedbackMode, thought.Feedback, ev)
+		case errors.Is(err, consciousness.ErrEvaluatorBusy), errors.Is(err, consciousness.ErrBudgetExhausted):
+		default:
+			l.cfg.Display.Show(output.Entry{
+				Source:    output.Mind,
+				Message:   fmt.Sprintf("evaluator error: %v", err),
+				Timestamp: l.clock.Now(),
+			})
+		}
+	}
+
+	changes := consciousness.SomaticChanges(l.cfg.FeedbackMode, thought.Feedback, eval, l.cfg.EvaluatorWeight)
 	for _, c := range changes {
 		current := l.cfg.BioState.Get(c.Variable)
 		l.cfg.BioState.Set(c.Variable, biology.ClampVariable(c.Variable, current+c.Delta))
@@ -441,7 +531,8 @@ func (l *Loop) applyFeedback(thought *consciousness.Thought) {
 }
 
 // readInput reads lines from the configured input reader and sends them
-// to the input channel. Exits when the context is cancelled or input ends.
+// to the input channel, or commands to the command channel. Exits when the
+// context is cancelled or input ends.
 func (l *Loop) readInput(ctx context.Context) {
 	scanner := bufio.NewScanner(l.cfg.Input)
 	for scanner.Scan() {
@@ -449,26 +540,55 @@ func (l *Loop) readInput(ctx context.Context) {
 		if line == "" {
 			continue
 		}
+		ch := l.inputCh
+		if inputType, _ := classifyInput(line); inputType == TypeCommand {
+			ch = l.commandCh
+		}
 		select {
-		case l.inputCh <- line:
+		case ch <- line:
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
-// shutdown persists the current state before exiting.
+// shutdown persists the current state before exiting. State is saved first,
+// so a failing session record cannot lose it; every failure is reported.
 func (l *Loop) shutdown() error {
-	if l.cfg.Store == nil {
-		return nil
-	}
-	if err := l.cfg.Store.SaveBioState(l.cfg.BioState); err != nil {
-		return fmt.Errorf("save bio state: %w", err)
+	var errs []error
+	if l.cfg.Store != nil {
+		if err := l.cfg.Store.SaveBioState(l.cfg.BioState); err != nil {
+			errs = append(errs, fmt.Errorf("save bio state: %w", err))
+		}
+		if l.cfg.Identity != nil {
+			if err := l.cfg.Store.SaveIdentityCore(l.cfg.Identity); err != nil {
+				errs = append(errs, fmt.Errorf("save identity: %w", err))
+			}
+		}
 	}
-	if l.cfg.Identity != nil {
-		if err := l.cfg.Store.SaveIdentityCore(l.cfg.Identity); err != nil {
-			return fmt.Errorf("save identity: %w", err)
+	if l.cfg.Meter != nil {
+		report := l.cfg.Meter.Report()
+		l.cfg.Display.Show(output.Entry{
+			Source:    output.Mind,
+			Message:   usageLine(report),
+			Timestamp: l.clock.Now(),
+		})
+		if l.cfg.Store != nil {
+			rec := report.SessionRecord(l.cfg.Model, time.Now())
+			if err := l.cfg.Store.SaveSession(&rec); err != nil {
+				errs = append(errs, fmt.Errorf("save session: %w", err))
+			}
 		}
 	}
-	return nil
+	return errors.Join(errs...)
+}
+
+// usageLine renders the LLM usage report in the active language.
+func usageLine(r consciousness.UsageReport) string {
+	cli := &i18n.T().CLI
+	s := fmt.Sprintf(cli.UsageSummary, r.Total.Calls, r.Total.InputTokens, r.Total.OutputTokens, r.CostUSD)
+	if r.Total.Denied > 0 {
+		s += fmt.Sprintf(cli.UsageDenied, r.Total.Denied)
+	}
+	return s
 }
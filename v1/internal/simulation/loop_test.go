@@ -3,7 +3,9 @@ package simulation
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"math"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -526,7 +528,7 @@ func TestApplyFeedback_SpeechResponseLandsBiologically(t *testing.T) {
 		},
 	}
 
-	loop.applyFeedback(thought)
+	loop.applyFeedback(context.Background(), thought, &psychology.State{})
 
 	if bioState.Cortisol <= initialCortisol {
 		t.Errorf("cortisol should be elevated after angry thought, before=%v after=%v",
@@ -534,6 +536,70 @@ func TestApplyFeedback_SpeechResponseLandsBiologically(t *testing.T) {
 	}
 }
 
+// stubEvaluator returns a fixed evaluation or error.
+type stubEvaluator struct {
+	eval  consciousness.Evaluation
+	err   error
+	calls int
+}
+
+func (s *stubEvaluator) Evaluate(ctx context.Context, thought string, affect consciousness.Affect) (consciousness.Evaluation, error) {
+	s.calls++
+	return s.eval, s.err
+}
+
+func TestApplyFeedback_EvaluatorModeReplacesKeywordPath(t *testing.T) {
+	bioState := ptrBioState(biology.NewDefaultState())
+	initialCortisol := bioState.Cortisol
+	initialTension := bioState.MuscleTension
+
+	eval := &stubEvaluator{eval: consciousness.Evaluation{
+		Changes: []biology.StateChange{{Variable: biology.VarCortisol, Delta: 0.03, Source: "consciousness_evaluator"}},
+		Coping:  []string{"suppression"},
+	}}
+	loop := NewLoop(Config{BioState: bioState, FeedbackMode: consciousness.FeedbackEvaluator, Evaluator: eval})
+
+	thought := &consciousness.Thought{
+		Content: "I'm fine. Really.",
+		Feedback: consciousness.ThoughtFeedback{
+			EmotionalState: consciousness.EmotionalTag{Arousal: 0.8, Valence: -0.7},
+		},
+	}
+	loop.applyFeedback(context.Background(), thought, &psychology.State{})
+
+	if got := bioState.Cortisol - initialCortisol; math.Abs(got-0.03) > 1e-9 {
+		t.Errorf("cortisol delta = %v, want the evaluated 0.03", got)
+	}
+	if bioState.MuscleTension != initialTension {
+		t.Errorf("keyword pulses should not apply in evaluator mode, tension %v -> %v", initialTension, bioState.MuscleTension)
+	}
+	if len(thought.Feedback.ActiveCoping) != 1 || thought.Feedback.ActiveCoping[0] != "suppression" {
+		t.Errorf("coping = %v, want evaluator labels", thought.Feedback.ActiveCoping)
+	}
+}
+
+func TestApplyFeedback_EvaluatorBusyFallsBackToKeyword(t *testing.T) {
+	bioState := ptrBioState(biology.NewDefaultState())
+	initialTension := bioState.MuscleTension
+
+	eval := &stubEvaluator{err: consciousness.ErrEvaluatorBusy}
+	loop := NewLoop(Config{BioState: bioState, FeedbackMode: consciousness.FeedbackBlend, Evaluator: eval})
+
+	thought := &consciousness.Thought{
+		Feedback: consciousness.ThoughtFeedback{
+			EmotionalState: consciousness.EmotionalTag{Arousal: 0.8, Valence: -0.7},
+		},
+	}
+	loop.applyFeedback(context.Background(), thought, &psychology.State{})
+
+	if eval.calls != 1 {
+		t.Errorf("evaluator calls = %d, want 1", eval.calls)
+	}
+	if got := bioState.MuscleTension - initialTension; math.Abs(got-0.8*0.15) > 1e-9 {
+		t.Errorf("tension delta = %v, want the full keyword pulse", got)
+	}
+}
+
 func TestLoop_OnStateSnapshot_NilIsNoop(t *testing.T) {
 	// A loop with no OnStateSnapshot callback must run without error.
 	var buf bytes.Buffer
@@ -707,3 +773,43 @@ func TestLoop_EnvironmentInput_NoBioOnly(t *testing.T) {
 		t.Errorf("expected thermal/cold reference in output, got: %s", out)
 	}
 }
+
+// failingSessionStore saves state but fails to record the session.
+type failingSessionStore struct {
+	memory.Store
+	savedBio      bool
+	savedIdentity bool
+}
+
+func (s *failingSessionStore) SaveBioState(*biology.State) error {
+	s.savedBio = true
+	return nil
+}
+
+func (s *failingSessionStore) SaveIdentityCore(*memory.IdentityCore) error {
+	s.savedIdentity = true
+	return nil
+}
+
+func (s *failingSessionStore) SaveSession(*memory.SessionRecord) error {
+	return errors.New("disk full")
+}
+
+func TestShutdown_SavesStateEvenWhenSessionRecordFails(t *testing.T) {
+	var buf bytes.Buffer
+	loop := newTestLoop(strings.NewReader(""), &buf)
+	store := &failingSessionStore{}
+	loop.cfg.Store = store
+	loop.cfg.Meter = consciousness.NewMeteredLLM(&mockLLM{response: "ok"}, consciousness.MeterConfig{})
+
+	err := loop.shutdown()
+	if err == nil || !strings.Contains(err.Error(), "save session") {
+		t.Fatalf("expected the session error to be reported, got %v", err)
+	}
+	if !store.savedBio || !store.savedIdentity {
+		t.Errorf("bio and identity state must be saved first: bio=%v identity=%v", store.savedBio, store.savedIdentity)
+	}
+	if !strings.Contains(buf.String(), "LLM usage: 0 calls") {
+		t.Errorf("expected the usage line on the display, got:\n%s", buf.String())
+	}
+}
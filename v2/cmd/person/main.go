@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/marczahn/person/v2/internal/scenario"
+)
+
+const usage = `usage: person scenario run [-v] [-replay FILE] SCENARIO.yaml`
+
+func main() {
+	code, err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	}
+	os.Exit(code)
+}
+
+// run executes a command and returns the exit code: 0 when every assertion
+// passed, 1 when one failed, 2 on usage or load errors.
+func run(args []string, out io.Writer) (int, error) {
+	if len(args) < 2 || args[0] != "scenario" || args[1] != "run" {
+		return 2, fmt.Errorf("%s", usage)
+	}
+	fs := flag.NewFlagSet("scenario run", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	verbose := fs.Bool("v", false, "print script events and a per-minute bio trace")
+	replay := fs.String("replay", "", "YAML list of recorded mind replies; overrides the file's mind")
+	if err := fs.Parse(args[2:]); err != nil {
+		return 2, fmt.Errorf("%v\n%s", err, usage)
+	}
+	if fs.NArg() != 1 {
+		return 2, fmt.Errorf("%s", usage)
+	}
+
+	f, err := scenario.Load(fs.Arg(0))
+	if err != nil {
+		return 2, err
+	}
+	if *replay != "" {
+		replies, err := scenario.LoadReplay(*replay)
+		if err != nil {
+			return 2, err
+		}
+		f.Mind = scenario.Mind{Mode: scenario.MindReplay, Replay: replies}
+	}
+	report, err := scenario.Run(f)
+	if err != nil {
+		return 2, err
+	}
+
+	if *verbose {
+		for _, e := range report.Events {
+			fmt.Fprintf(out, "t=%-6g %-10s %s\n", e.At, e.Action, e.Detail)
+		}
+		for _, s := range report.Samples {
+			if int(s.T)%60 == 0 {
+				fmt.Fprintf(out, "t=%-6g hunger=%.2f energy=%.2f stress=%.2f mood=%.2f body_temp=%.1f\n",
+					s.T, s.Bio.Hunger, s.Bio.Energy, s.Bio.Stress, s.Bio.Mood, s.Bio.BodyTemp)
+			}
+		}
+	}
+	fmt.Fprintf(out, "scenario %s: %d ticks, t=%g, ended: %s\n", report.Name, report.Ticks, report.Clock, report.EndReason)
+	for _, r := range report.Results {
+		verdict := "PASS"
+		if !r.Passed {
+			verdict = "FAIL"
+		}
+		fmt.Fprintf(out, "%s  %s  (%s)\n", verdict, r.Assertion, r.Detail)
+	}
+	if !report.Passed() {
+		fmt.Fprintf(out, "FAIL  %d of %d assertions failed\n", len(report.Failed()), len(report.Results))
+		return 1, nil
+	}
+	fmt.Fprintf(out, "PASS  %d assertions\n", len(report.Results))
+	return 0, nil
+}
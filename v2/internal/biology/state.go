@@ -84,3 +84,55 @@ func ClampAll(s *State) {
 	s.SocialDeficit = Clamp(s.SocialDeficit, Ranges.SocialDeficit.Min, Ranges.SocialDeficit.Max)
 	s.BodyTemp = Clamp(s.BodyTemp, Ranges.BodyTemp.Min, Ranges.BodyTemp.Max)
 }
+
+// Field returns a variable by its feedback field name ("stress", "body_temp", ...).
+func (s State) Field(name string) (float64, bool) {
+	switch name {
+	case "energy":
+		return s.Energy, true
+	case "stress":
+		return s.Stress, true
+	case "cognitive_capacity":
+		return s.CognitiveCapacity, true
+	case "mood":
+		return s.Mood, true
+	case "physical_tension":
+		return s.PhysicalTension, true
+	case "hunger":
+		return s.Hunger, true
+	case "social_deficit":
+		return s.SocialDeficit, true
+	case "body_temp":
+		return s.BodyTemp, true
+	}
+	return 0, false
+}
+
+// SetField sets a variable by its feedback field name and clamps it to its range.
+// It reports false for an unknown name.
+func (s *State) SetField(name string, v float64) bool {
+	var p *float64
+	switch name {
+	case "energy":
+		p = &s.Energy
+	case "stress":
+		p = &s.Stress
+	case "cognitive_capacity":
+		p = &s.CognitiveCapacity
+	case "mood":
+		p = &s.Mood
+	case "physical_tension":
+		p = &s.PhysicalTension
+	case "hunger":
+		p = &s.Hunger
+	case "social_deficit":
+		p = &s.SocialDeficit
+	case "body_temp":
+		p = &s.BodyTemp
+	default:
+		return false
+	}
+	*p = v
+	ClampAll(s)
+	return true
+}
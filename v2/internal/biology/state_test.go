@@ -202,3 +202,34 @@ func TestClamp_AllVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestState_FieldMatchesFeedbackNames(t *testing.T) {
+	for _, name := range []string{"energy", "stress", "cognitive_capacity", "mood", "physical_tension", "hunger", "social_deficit", "body_temp"} {
+		s := biology.NewDefaultState()
+		s.Energy, s.CognitiveCapacity = 0.5, 0.5
+		before, ok := s.Field(name)
+		if !ok {
+			t.Fatalf("Field(%q) not found", name)
+		}
+		biology.ApplyFeedbackAtTickEnd(s, 1, biology.FeedbackEnvelope{Pulses: []biology.BioPulse{{Field: name, Amount: 0.1}}})
+		if after, _ := s.Field(name); math.Abs(after-before-0.1) > 1e-9 {
+			t.Errorf("Field(%q) = %v after +0.1 pulse from %v", name, after, before)
+		}
+	}
+	if _, ok := biology.NewDefaultState().Field("cortisol"); ok {
+		t.Error("unknown field should not be found")
+	}
+}
+
+func TestState_SetFieldClampsAndRejectsUnknown(t *testing.T) {
+	s := biology.NewDefaultState()
+	if !s.SetField("hunger", 0.7) || s.Hunger != 0.7 {
+		t.Fatalf("SetField(hunger) = %v", s.Hunger)
+	}
+	if !s.SetField("body_temp", 50) || s.BodyTemp != biology.Ranges.BodyTemp.Max {
+		t.Errorf("body_temp should clamp to %v, got %v", biology.Ranges.BodyTemp.Max, s.BodyTemp)
+	}
+	if s.SetField("cortisol", 1) {
+		t.Error("unknown field should be rejected")
+	}
+}
@@ -81,6 +81,10 @@ func ActionPulse(outcome ActionOutcome) []biology.BioPulse {
 			{Field: "mood", Amount: 0.02},
 			{Field: "energy", Amount: -0.02},
 		}
+	case string(motivation.ActionMove):
+		return []biology.BioPulse{
+			{Field: "energy", Amount: -0.01},
+		}
 	default:
 		return nil
 	}
@@ -88,11 +92,15 @@ func ActionPulse(outcome ActionOutcome) []biology.BioPulse {
 
 func ResolveActionOutcome(action string, allowed bool) ActionOutcome {
 	normalized := strings.ToLower(strings.TrimSpace(action))
-	return ActionOutcome{
+	outcome := ActionOutcome{
 		Action:    normalized,
 		Executed:  allowed,
 		Satisfied: allowed,
 	}
+	if !allowed {
+		outcome.Reason = OutcomeBlocked
+	}
+	return outcome
 }
 
 // ResolveActionOutcomeWithCooldown applies environment gating plus per-action cooldowns.
@@ -107,11 +115,11 @@ func ResolveActionOutcomeWithCooldown(
 	normalized := strings.ToLower(strings.TrimSpace(action))
 	nextState := cloneCooldownState(state)
 	if !allowedByEnvironment {
-		return ActionOutcome{Action: normalized, Executed: false, Satisfied: false}, nextState
+		return ActionOutcome{Action: normalized, Executed: false, Satisfied: false, Reason: OutcomeBlocked}, nextState
 	}
 
 	if until, ok := nextState[normalized]; ok && nowSeconds < until {
-		return ActionOutcome{Action: normalized, Executed: false, Satisfied: false}, nextState
+		return ActionOutcome{Action: normalized, Executed: false, Satisfied: false, Reason: OutcomeCooldown}, nextState
 	}
 
 	duration := cooldowns[normalized]
@@ -0,0 +1,183 @@
+package consciousness
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// ActivitySpec describes an action that takes time.
+// Rates are applied per second while the activity runs, so the total effect
+// scales with how long the person actually keeps doing it.
+type ActivitySpec struct {
+	Duration float64 // seconds; zero means the action resolves instantly via ActionPulse
+	Rates    []biology.BioRate
+}
+
+// ActivityCatalog maps normalized action names to their duration specs.
+// Actions missing from the catalog stay instant.
+type ActivityCatalog map[string]ActivitySpec
+
+// Activity is the ongoing action occupying the person. The zero value is idle.
+type Activity struct {
+	Action   string
+	Duration float64
+	Elapsed  float64
+}
+
+// OccupancyPolicy decides what happens to new actions while an activity runs.
+type OccupancyPolicy string
+
+const (
+	OccupancyReject OccupancyPolicy = "reject"
+	OccupancyQueue  OccupancyPolicy = "queue"
+)
+
+// DefaultActivityCatalog returns durations for actions that are not momentary.
+// Total effects over the full duration match the instant ActionPulse values.
+func DefaultActivityCatalog() ActivityCatalog {
+	return ActivityCatalog{
+		string(motivation.ActionRest): {
+			Duration: 90,
+			Rates: []biology.BioRate{
+				{Field: "energy", PerSecond: 0.18 / 90},
+				{Field: "stress", PerSecond: -0.06 / 90},
+				{Field: "physical_tension", PerSecond: -0.08 / 90},
+			},
+		},
+		string(motivation.ActionJournal): {
+			Duration: 60,
+			Rates: []biology.BioRate{
+				{Field: "stress", PerSecond: -0.02 / 60},
+				{Field: "mood", PerSecond: 0.03 / 60},
+			},
+		},
+		string(motivation.ActionMicroTask): {
+			Duration: 60,
+			Rates: []biology.BioRate{
+				{Field: "cognitive_capacity", PerSecond: 0.04 / 60},
+				{Field: "mood", PerSecond: 0.02 / 60},
+				{Field: "energy", PerSecond: -0.02 / 60},
+			},
+		},
+	}
+}
+
+// Active reports whether the activity still occupies the person.
+func (a Activity) Active() bool {
+	return a.Action != "" && a.Elapsed < a.Duration
+}
+
+// Remaining returns the seconds left before the activity completes.
+func (a Activity) Remaining() float64 {
+	if !a.Active() {
+		return 0
+	}
+	return a.Duration - a.Elapsed
+}
+
+// StartActivity begins an activity for an executed outcome when the catalog gives it a duration.
+func StartActivity(outcome ActionOutcome, catalog ActivityCatalog) (Activity, bool) {
+	if !outcome.Executed {
+		return Activity{}, false
+	}
+	spec, ok := catalog[outcome.Action]
+	if !ok || spec.Duration <= 0 {
+		return Activity{}, false
+	}
+	return Activity{Action: outcome.Action, Duration: spec.Duration}, true
+}
+
+// ActivityRates returns the activity's rates for the next dt, scaled down when the
+// activity completes part-way through the tick so the total effect never overshoots.
+func ActivityRates(a Activity, catalog ActivityCatalog, dt float64) []biology.BioRate {
+	if !a.Active() || dt <= 0 {
+		return nil
+	}
+	spec, ok := catalog[a.Action]
+	if !ok {
+		return nil
+	}
+	fraction := 1.0
+	if remaining := a.Remaining(); remaining < dt {
+		fraction = remaining / dt
+	}
+	out := make([]biology.BioRate, 0, len(spec.Rates))
+	for _, r := range spec.Rates {
+		out = append(out, biology.BioRate{Field: r.Field, PerSecond: r.PerSecond * fraction})
+	}
+	return out
+}
+
+// AdvanceActivity moves the activity forward by dt and reports whether it completed.
+func AdvanceActivity(a Activity, dt float64) (Activity, bool) {
+	if !a.Active() {
+		return Activity{}, false
+	}
+	a.Elapsed += dt
+	if a.Elapsed >= a.Duration {
+		return Activity{}, true
+	}
+	return a, false
+}
+
+// ResolveOccupiedAction handles a newly chosen action while current is running.
+// Repeating the current action continues it; anything else is queued or rejected by policy.
+// Contract: occupied outcomes never execute and never touch cooldown state.
+func ResolveOccupiedAction(action string, current Activity, policy OccupancyPolicy) ActionOutcome {
+	normalized := strings.ToLower(strings.TrimSpace(action))
+	switch {
+	case normalized == current.Action:
+		return ActionOutcome{Action: normalized, Reason: OutcomeOngoing}
+	case policy == OccupancyQueue:
+		return ActionOutcome{Action: normalized, Reason: OutcomeQueued}
+	default:
+		return ActionOutcome{Action: normalized, Reason: OutcomeBusy}
+	}
+}
+
+// ActivityLine renders the ongoing activity as felt language without numbers.
+func ActivityLine(a Activity) string {
+	if !a.Active() {
+		return ""
+	}
+	progress := "just started"
+	switch done := a.Elapsed / a.Duration; {
+	case done >= 2.0/3.0:
+		progress = "nearly done"
+	case done >= 1.0/3.0:
+		progress = "well underway"
+	}
+	return fmt.Sprintf("Currently %s, %s.", activityGerund(a.Action), progress)
+}
+
+func activityGerund(action string) string {
+	switch motivation.Action(action) {
+	case motivation.ActionRest:
+		return "resting"
+	case motivation.ActionEat:
+		return "eating"
+	case motivation.ActionHydrate:
+		return "drinking"
+	case motivation.ActionReachOut:
+		return "reaching out to someone"
+	case motivation.ActionJournal:
+		return "writing things down"
+	case motivation.ActionBreathe:
+		return "breathing slowly"
+	case motivation.ActionScanArea:
+		return "checking the surroundings"
+	case motivation.ActionSeekWarm:
+		return "looking for warmth"
+	case motivation.ActionSeekCool:
+		return "looking for somewhere cooler"
+	case motivation.ActionMicroTask:
+		return "working on a small task"
+	case motivation.ActionMove:
+		return "on the way somewhere else"
+	default:
+		return "busy with " + strings.ReplaceAll(action, "_", " ")
+	}
+}
@@ -0,0 +1,56 @@
+package consciousness
+
+import (
+	"fmt"
+
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// DescribeConflicts renders detected drive conflicts as felt ambivalence lines.
+// Like drive felt-language, the lines carry no raw numbers or drive labels.
+func DescribeConflicts(conflicts []motivation.DriveConflict) []string {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		first := actionPhrase(c.FirstPreferred)
+		second := actionPhrase(c.SecondPreferred)
+		switch c.Kind {
+		case motivation.ConflictApproachAvoidance:
+			lines = append(lines, fmt.Sprintf("Part of attention pulls to %s while another part pulls to %s.", first, second))
+		default:
+			lines = append(lines, fmt.Sprintf("Torn between wanting to %s and wanting to %s.", first, second))
+		}
+	}
+	return lines
+}
+
+func actionPhrase(a motivation.Action) string {
+	switch a {
+	case motivation.ActionRest:
+		return "stay put and rest"
+	case motivation.ActionEat:
+		return "go and eat something"
+	case motivation.ActionHydrate:
+		return "get something to drink"
+	case motivation.ActionReachOut:
+		return "reach out to someone"
+	case motivation.ActionJournal:
+		return "withdraw and sort thoughts out"
+	case motivation.ActionBreathe:
+		return "hold still and steady the breath"
+	case motivation.ActionScanArea:
+		return "stay watchful and check the surroundings"
+	case motivation.ActionSeekWarm:
+		return "find warmth"
+	case motivation.ActionSeekCool:
+		return "find somewhere cooler"
+	case motivation.ActionMicroTask:
+		return "get busy with something"
+	case motivation.ActionMove:
+		return "go somewhere else"
+	default:
+		return "do something else"
+	}
+}
@@ -1,9 +1,13 @@
 package consciousness_test
 
 import (
+	"context"
+	"errors"
 	"math"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/marczahn/person/v2/internal/biology"
 	"github.com/marczahn/person/v2/internal/consciousness"
@@ -70,6 +74,141 @@ func TestThoughtSelection_NotDueDoesNotFire(t *testing.T) {
 	}
 }
 
+func seedSources() consciousness.SeedSources {
+	return consciousness.SeedSources{
+		Motivation: motivation.MotivationState{EnergyUrgency: 0.4, SocialUrgency: 0.2},
+		Continuity: []consciousness.Thought{{Category: consciousness.ThoughtCategoryDrive, Drive: motivation.DriveEnergy, Text: "I am so tired. Maybe later."}},
+		Memories:   []string{"Yesterday the kitchen was empty."},
+		Inputs:     []string{"hello?"},
+	}
+}
+
+func TestThoughtSeeder_SameSeedSameThoughts(t *testing.T) {
+	run := func() []consciousness.Thought {
+		seeder := consciousness.NewThoughtSeeder(consciousness.SeederConfig{Schedule: consciousness.TickSchedule{EveryTicks: 1}, Seed: 42})
+		var out []consciousness.Thought
+		for tick := 1; tick <= 20; tick++ {
+			thought, ok := seeder.Next(seedSources(), tick)
+			if !ok {
+				t.Fatalf("tick %d: expected a thought", tick)
+			}
+			out = append(out, thought)
+		}
+		return out
+	}
+	if a, b := run(), run(); !reflect.DeepEqual(a, b) {
+		t.Fatalf("same seed produced different thoughts:\n%v\n%v", a, b)
+	}
+}
+
+func TestThoughtSeeder_CandidatesCoverEverySource(t *testing.T) {
+	seeder := consciousness.NewThoughtSeeder(consciousness.SeederConfig{Schedule: consciousness.TickSchedule{EveryTicks: 1}})
+	seeder.NoteOutcome(consciousness.ActionOutcome{Action: "eat_food", Reason: consciousness.OutcomeBlocked})
+	seeder.Next(consciousness.SeedSources{Inputs: []string{"hello?"}}, 1)
+
+	seen := map[consciousness.ThoughtCategory]string{}
+	for _, seed := range seeder.Candidates(seedSources(), 1) {
+		seen[seed.Thought.Category] = seed.Thought.Text
+	}
+	for _, category := range []consciousness.ThoughtCategory{
+		consciousness.ThoughtCategoryDrive,
+		consciousness.ThoughtCategoryContinuity,
+		consciousness.ThoughtCategoryMemory,
+		consciousness.ThoughtCategoryInput,
+		consciousness.ThoughtCategoryBlocked,
+		consciousness.ThoughtCategoryAssociativeDrift,
+	} {
+		if _, ok := seen[category]; !ok {
+			t.Errorf("missing %q seed in %v", category, seen)
+		}
+	}
+	if got := seen[consciousness.ThoughtCategoryContinuity]; !strings.Contains(got, "I am so tired.") || strings.Contains(got, "Maybe later") {
+		t.Errorf("continuity seed should carry the first sentence, got %q", got)
+	}
+	if got := seen[consciousness.ThoughtCategoryBlocked]; !strings.Contains(got, "eat food") {
+		t.Errorf("blocked seed = %q", got)
+	}
+}
+
+func TestThoughtSeeder_BlockedActionResolvesOnExecution(t *testing.T) {
+	seeder := consciousness.NewThoughtSeeder(consciousness.SeederConfig{})
+	blocked := func() float64 {
+		for _, seed := range seeder.Candidates(consciousness.SeedSources{}, 1) {
+			if seed.Thought.Category == consciousness.ThoughtCategoryBlocked {
+				return seed.Weight
+			}
+		}
+		return 0
+	}
+
+	seeder.NoteOutcome(consciousness.ActionOutcome{Action: "rest", Reason: consciousness.OutcomeBusy})
+	if w := blocked(); w != 0 {
+		t.Fatalf("busy outcome should not seed a blocked thought, weight %v", w)
+	}
+	seeder.NoteOutcome(consciousness.ActionOutcome{Action: "rest", Reason: consciousness.OutcomeBlocked})
+	once := blocked()
+	seeder.NoteOutcome(consciousness.ActionOutcome{Action: "rest", Reason: consciousness.OutcomeCooldown})
+	if twice := blocked(); twice <= once || once == 0 {
+		t.Fatalf("repeated blocking should raise the weight: once %v, twice %v", once, twice)
+	}
+	seeder.NoteOutcome(consciousness.ActionOutcome{Action: "rest", Executed: true})
+	if w := blocked(); w != 0 {
+		t.Fatalf("executed action should resolve the blocked seed, weight %v", w)
+	}
+}
+
+func TestThoughtSeeder_InputsFade(t *testing.T) {
+	seeder := consciousness.NewThoughtSeeder(consciousness.SeederConfig{})
+	seeder.Next(consciousness.SeedSources{Inputs: []string{"watch out"}}, 1)
+	weight := func(tick int) float64 {
+		for _, seed := range seeder.Candidates(consciousness.SeedSources{}, tick) {
+			if seed.Thought.Category == consciousness.ThoughtCategoryInput {
+				return seed.Weight
+			}
+		}
+		return 0
+	}
+	if fresh, later := weight(1), weight(3); later >= fresh || math.Abs(later-fresh/4) > 1e-12 {
+		t.Fatalf("input weight should halve per tick: fresh %v, two ticks later %v", fresh, later)
+	}
+}
+
+func TestThoughtSeeder_AbsorptionDeepensAndResistsWeakInterrupts(t *testing.T) {
+	seeder := consciousness.NewThoughtSeeder(consciousness.SeederConfig{Schedule: consciousness.TickSchedule{EveryTicks: 1}, Seed: 7})
+	// Only a drive and drift are available; the dominant drive keeps winning and deepens the chain.
+	src := consciousness.SeedSources{Motivation: motivation.MotivationState{SafetyUrgency: 1}}
+	for tick := 1; tick <= 6; tick++ {
+		seeder.Next(src, tick)
+	}
+	absorption := seeder.Absorption()
+	if !absorption.Absorbed() || absorption.Depth < 3 {
+		t.Fatalf("expected an absorbed chain, got %+v", absorption)
+	}
+
+	var chainWeight float64
+	for _, seed := range seeder.Candidates(src, 7) {
+		if seed.Thought.Category == absorption.Category && seed.Thought.Drive == absorption.Drive {
+			chainWeight = seed.Weight
+		}
+	}
+	if chainWeight <= 1 {
+		t.Fatalf("absorption should amplify the chain seed beyond its urgency, got %v", chainWeight)
+	}
+
+	if seeder.Interrupt(0.2) {
+		t.Fatal("weak interruption should not break a deep chain")
+	}
+	if got := seeder.Absorption(); got.Level >= absorption.Level || !got.Absorbed() {
+		t.Fatalf("resisted interruption should cost absorption: before %+v, after %+v", absorption, got)
+	}
+	if !seeder.Interrupt(1.1) {
+		t.Fatal("strong interruption should break the chain")
+	}
+	if seeder.Absorption().Absorbed() {
+		t.Fatal("chain should be gone after a break")
+	}
+}
+
 func TestBuildPromptContext_IncludesContinuityBuffer(t *testing.T) {
 	state := motivation.MotivationState{
 		EnergyUrgency:      0.2,
@@ -516,3 +655,481 @@ func assertDeltaAmount(t *testing.T, deltas []biology.BioPulse, field string, wa
 	}
 	t.Fatalf("expected delta for field %s not found in %+v", field, deltas)
 }
+
+func TestDescribeConflicts_FeltAmbivalenceWithoutNumbersOrLabels(t *testing.T) {
+	lines := consciousness.DescribeConflicts([]motivation.DriveConflict{{
+		Kind:            motivation.ConflictApproachAvoidance,
+		First:           motivation.DriveSafety,
+		Second:          motivation.DriveSocialConnection,
+		FirstUrgency:    0.8,
+		SecondUrgency:   0.7,
+		FirstPreferred:  motivation.ActionBreathe,
+		SecondPreferred: motivation.ActionReachOut,
+		Intensity:       0.7,
+	}})
+
+	if len(lines) != 1 {
+		t.Fatalf("expected one conflict line, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], "reach out") {
+		t.Fatalf("expected approach side in conflict line, got %q", lines[0])
+	}
+	if strings.ContainsAny(lines[0], "0123456789") || strings.Contains(lines[0], "drive") {
+		t.Fatalf("conflict line must stay felt-language, got %q", lines[0])
+	}
+}
+
+func TestActivity_RatesScaleWithTimeAndStopAtDuration(t *testing.T) {
+	catalog := consciousness.ActivityCatalog{
+		"rest": {Duration: 10, Rates: []biology.BioRate{{Field: "energy", PerSecond: 0.01}}},
+	}
+
+	activity, ok := consciousness.StartActivity(consciousness.ActionOutcome{Action: "rest", Executed: true, Satisfied: true}, catalog)
+	if !ok {
+		t.Fatal("expected rest to start a timed activity")
+	}
+
+	total := 0.0
+	for i := 0; i < 4; i++ {
+		for _, r := range consciousness.ActivityRates(activity, catalog, 3) {
+			total += r.PerSecond * 3
+		}
+		activity, _ = consciousness.AdvanceActivity(activity, 3)
+	}
+	if math.Abs(total-0.10) > 1e-9 {
+		t.Fatalf("expected total effect capped at duration (0.10), got %f", total)
+	}
+	if activity.Active() {
+		t.Fatalf("expected activity to complete, got %+v", activity)
+	}
+}
+
+func TestActivity_InstantActionsDoNotStart(t *testing.T) {
+	outcome := consciousness.ActionOutcome{Action: "eat", Executed: true, Satisfied: true}
+	if _, ok := consciousness.StartActivity(outcome, consciousness.DefaultActivityCatalog()); ok {
+		t.Fatal("eat is instant and must not start an activity")
+	}
+	blocked := consciousness.ActionOutcome{Action: "rest", Reason: consciousness.OutcomeBlocked}
+	if _, ok := consciousness.StartActivity(blocked, consciousness.DefaultActivityCatalog()); ok {
+		t.Fatal("blocked action must not start an activity")
+	}
+}
+
+func TestResolveOccupiedAction_ContinuesQueuesOrRejects(t *testing.T) {
+	current := consciousness.Activity{Action: "rest", Duration: 90, Elapsed: 10}
+
+	if got := consciousness.ResolveOccupiedAction("rest", current, consciousness.OccupancyReject); got.Reason != consciousness.OutcomeOngoing {
+		t.Fatalf("repeating current action should continue it, got %+v", got)
+	}
+	if got := consciousness.ResolveOccupiedAction("eat", current, consciousness.OccupancyReject); got.Reason != consciousness.OutcomeBusy || got.Executed {
+		t.Fatalf("reject policy must refuse new action, got %+v", got)
+	}
+	if got := consciousness.ResolveOccupiedAction("eat", current, consciousness.OccupancyQueue); got.Reason != consciousness.OutcomeQueued || got.Executed {
+		t.Fatalf("queue policy must defer new action, got %+v", got)
+	}
+}
+
+func TestDecayPerceived_ClosesHalfTheGapPerHalfLife(t *testing.T) {
+	raw := motivation.MotivationState{EnergyUrgency: 0.8}
+	perceived := motivation.MotivationState{EnergyUrgency: 0.4}
+
+	got := consciousness.DecayPerceived(perceived, raw, 30, 30)
+	if math.Abs(got.EnergyUrgency-0.6) > 1e-9 {
+		t.Fatalf("expected half the gap closed after one half-life (0.6), got %f", got.EnergyUrgency)
+	}
+	if got.ActiveGoalDrive != motivation.DriveEnergy {
+		t.Fatalf("expected active goal recomputed from decayed perception, got %s", got.ActiveGoalDrive)
+	}
+}
+
+func TestMeasurePerceptionGap_DenialCountsOnlyUnderReporting(t *testing.T) {
+	raw := motivation.MotivationState{EnergyUrgency: 0.8, SocialUrgency: 0.2}
+	perceived := motivation.MotivationState{EnergyUrgency: 0.3, SocialUrgency: 0.7}
+
+	gap := consciousness.MeasurePerceptionGap(raw, perceived)
+	if math.Abs(gap.Mean-0.2) > 1e-9 {
+		t.Fatalf("expected mean gap 1.0/5 drives = 0.2, got %f", gap.Mean)
+	}
+	if math.Abs(gap.Denial-0.1) > 1e-9 {
+		t.Fatalf("expected denial 0.5/5 drives = 0.1, got %f", gap.Denial)
+	}
+}
+
+func TestParseJSONResponse_ValidObjectParsed(t *testing.T) {
+	raw := `{"narrative": "I need air.", "state": {"arousal": 0.6, "valence": -0.2}, "action": "breathe", "drives": {"safety": 0.7}, "speech": "Give me a second."}`
+
+	got := consciousness.ParseJSONResponse(raw, consciousness.ParsedResponse{})
+
+	if got.Action != "breathe" || got.State.Arousal != 0.6 || got.State.Valence != -0.2 {
+		t.Fatalf("unexpected parsed state/action: %+v", got)
+	}
+	if got.Narrative != "I need air." || got.Speech != "Give me a second." {
+		t.Fatalf("unexpected narrative/speech: %+v", got)
+	}
+	if got.DriveOverrides[motivation.DriveSafety] != 0.7 {
+		t.Fatalf("expected safety override 0.7, got %+v", got.DriveOverrides)
+	}
+}
+
+func TestParseJSONResponse_RepairsNearValidOutput(t *testing.T) {
+	raw := "Sure, here it is:\n```json\n{\"narrative\": \"Tired.\", \"state\": {\"arousal\": -0.3, \"valence\": 0.1,}, \"action\": \"rest\",}\n```"
+
+	got := consciousness.ParseJSONResponse(raw, consciousness.ParsedResponse{Action: "journal"})
+	if got.Action != "rest" {
+		t.Fatalf("expected repaired output to parse action rest, got %+v", got)
+	}
+	if got.Narrative != "Tired." {
+		t.Fatalf("expected narrative from repaired object, got %q", got.Narrative)
+	}
+}
+
+func TestParseJSONResponse_InvalidFallsBackToPrior(t *testing.T) {
+	prior := consciousness.ParsedResponse{
+		State:  consciousness.ParsedState{Arousal: 0.1, Valence: 0.2},
+		Action: "journal",
+	}
+
+	cases := map[string]string{
+		"missing action":     `{"narrative": "Hm.", "state": {"arousal": 0.5, "valence": 0.5}}`,
+		"state out of range": `{"narrative": "Hm.", "state": {"arousal": 3, "valence": 0}, "action": "rest"}`,
+		"unknown drive":      `{"narrative": "Hm.", "state": {"arousal": 0, "valence": 0}, "action": "rest", "drives": {"boredom": 0.4}}`,
+		"not json":           `I just want to rest.`,
+	}
+	for name, raw := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := consciousness.ParseJSONResponse(raw, prior)
+			if got.Action != prior.Action || got.State != prior.State {
+				t.Fatalf("expected fallback to prior, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestParseResponseWith_SelectsProtocol(t *testing.T) {
+	tagged := "ok [STATE: arousal=0.1, valence=0.1] [ACTION: rest]"
+	if got := consciousness.ParseResponseWith(consciousness.ProtocolTags, tagged, consciousness.ParsedResponse{}); got.Action != "rest" {
+		t.Fatalf("tag protocol must keep parsing bracket tags, got %+v", got)
+	}
+	if got := consciousness.ParseResponseWith(consciousness.ProtocolJSON, tagged, consciousness.ParsedResponse{}); got.Action != "" {
+		t.Fatalf("json protocol must not accept bracket tags, got %+v", got)
+	}
+}
+
+func TestParseResponse_ReportsStatusAndFailureReason(t *testing.T) {
+	cases := []struct {
+		name   string
+		raw    string
+		status consciousness.ParseStatus
+		reason consciousness.ParseFailure
+	}{
+		{"ok", "Fine. [STATE: arousal=0.1, valence=0.1] [ACTION: rest]", consciousness.ParseStatusOK, ""},
+		{"missing state", "Fine. [ACTION: rest]", consciousness.ParseStatusFailed, consciousness.ParseMissingState},
+		{"malformed state", "Fine. [STATE: arousal=x, valence=0.1] [ACTION: rest]", consciousness.ParseStatusFailed, consciousness.ParseMalformedState},
+		{"missing action", "Fine. [STATE: arousal=0.1, valence=0.1]", consciousness.ParseStatusFailed, consciousness.ParseMissingAction},
+		{"malformed drive", "Fine. [STATE: arousal=0.1, valence=0.1] [ACTION: rest] [DRIVE: boredom=0.3]", consciousness.ParseStatusFailed, consciousness.ParseMalformedDrive},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := consciousness.ParseResponse(tc.raw, consciousness.ParsedResponse{})
+			if got.Status != tc.status || got.Reason != tc.reason {
+				t.Fatalf("got status=%q reason=%q, want status=%q reason=%q", got.Status, got.Reason, tc.status, tc.reason)
+			}
+		})
+	}
+
+	if got := consciousness.ParseJSONResponse("no json here", consciousness.ParsedResponse{}); got.Reason != consciousness.ParseInvalidJSON {
+		t.Fatalf("expected invalid_json reason, got %q", got.Reason)
+	}
+}
+
+func TestCorrectivePrompt_NamesProblemAndFormat(t *testing.T) {
+	tags := consciousness.CorrectivePrompt(consciousness.ProtocolTags, consciousness.ParseMissingAction)
+	if !strings.Contains(tags, "action was missing") || !strings.Contains(tags, "[ACTION:") {
+		t.Fatalf("tag correction must name the problem and the tag format, got %q", tags)
+	}
+	js := consciousness.CorrectivePrompt(consciousness.ProtocolJSON, consciousness.ParseInvalidJSON)
+	if !strings.Contains(js, consciousness.ResponseJSONSchema) {
+		t.Fatalf("json correction must embed the schema, got %q", js)
+	}
+}
+
+func TestParseResponse_SpeechTagSeparatesWordsFromThought(t *testing.T) {
+	raw := "They look worried.\n[SPEECH: \"Are you okay?\"]\n[STATE: arousal=0.1, valence=0.2]\n[ACTION: reach_out]"
+
+	got := consciousness.ParseResponse(raw, consciousness.ParsedResponse{Speech: "old words"})
+
+	if got.Speech != "Are you okay?" {
+		t.Fatalf("expected speech from tag, got %q", got.Speech)
+	}
+	if got.Narrative != "They look worried." {
+		t.Fatalf("speech must be stripped from narrative, got %q", got.Narrative)
+	}
+	if silent := consciousness.ParseResponse("Quiet. [STATE: arousal=0, valence=0] [ACTION: rest]", got); silent.Speech != "" {
+		t.Fatalf("speech must not carry over from prior, got %q", silent.Speech)
+	}
+}
+
+func TestSpeechPulse_CountsAsSocialContactOnlyWithListener(t *testing.T) {
+	if pulses := consciousness.SpeechPulse("hello", false); pulses != nil {
+		t.Fatalf("speaking to nobody must not emit pulses, got %+v", pulses)
+	}
+	if pulses := consciousness.SpeechPulse("  ", true); pulses != nil {
+		t.Fatalf("empty speech must not emit pulses, got %+v", pulses)
+	}
+	pulses := consciousness.SpeechPulse("hello", true)
+	found := false
+	for _, p := range pulses {
+		if p.Field == "social_deficit" && p.Amount < 0 {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("speech with a listener must reduce social deficit, got %+v", pulses)
+	}
+}
+
+func TestContinuityBuffer_CompressesEvictedThoughtsIntoGistWithResidue(t *testing.T) {
+	buffer := consciousness.NewContinuityBufferWith(consciousness.ContinuityConfig{Recent: 2, Span: 2})
+	buffer.Add(consciousness.Thought{Text: "The room is cold. I hate it.", Affect: consciousness.ParsedState{Arousal: 0.6, Valence: -0.7}})
+	buffer.Add(consciousness.Thought{Text: "Nothing happens.", Affect: consciousness.ParsedState{Arousal: 0.1, Valence: -0.1}})
+	buffer.Add(consciousness.Thought{Text: "third"})
+	buffer.Add(consciousness.Thought{Text: "fourth"})
+
+	gists := buffer.Gists()
+	if len(gists) != 1 || gists[0].Thoughts != 2 {
+		t.Fatalf("expected one gist over two evicted thoughts, got %+v", gists)
+	}
+	if gists[0].Text != "The room is cold." {
+		t.Fatalf("expected extractive gist from the most intense thought, got %q", gists[0].Text)
+	}
+	if gists[0].Residue.Valence >= 0 {
+		t.Fatalf("expected negative residue, got %+v", gists[0].Residue)
+	}
+
+	lines := buffer.Lines()
+	want := []string{
+		"Earlier (2 thoughts): The room is cold. It left a lingering agitation.",
+		"third",
+		"fourth",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Fatalf("unexpected rendered continuity:\ngot  %q\nwant %q", lines, want)
+	}
+}
+
+func TestContinuityBuffer_MergesOldestGistsInsteadOfDropping(t *testing.T) {
+	buffer := consciousness.NewContinuityBufferWith(consciousness.ContinuityConfig{Recent: 1, Span: 1, MaxGists: 2, TokenBudget: -1})
+	for _, text := range []string{"one.", "two.", "three.", "four."} {
+		buffer.Add(consciousness.Thought{Text: text})
+	}
+
+	gists := buffer.Gists()
+	if len(gists) != 2 {
+		t.Fatalf("expected gist tier bounded at 2, got %+v", gists)
+	}
+	total := 0
+	for _, g := range gists {
+		total += g.Thoughts
+	}
+	if total != 3 {
+		t.Fatalf("expected every evicted thought to be represented, got %d", total)
+	}
+}
+
+func TestContinuityBuffer_LinesFitTokenBudgetPreferringRecent(t *testing.T) {
+	buffer := consciousness.NewContinuityBufferWith(consciousness.ContinuityConfig{Recent: 2, Span: 1, TokenBudget: 10})
+	buffer.Add(consciousness.Thought{Text: "An old thought that should be compressed away."})
+	buffer.Add(consciousness.Thought{Text: "recent one"})
+	buffer.Add(consciousness.Thought{Text: "recent two"})
+
+	lines := buffer.Lines()
+	want := []string{"recent one", "recent two"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Fatalf("expected only recent thoughts within budget, got %q", lines)
+	}
+	cost := 0
+	for _, l := range lines {
+		cost += consciousness.EstimateTokens(l)
+	}
+	if cost > 10 {
+		t.Fatalf("rendered lines exceed budget: %d", cost)
+	}
+}
+
+type stubCompleter struct {
+	reply string
+	err   error
+}
+
+func (s stubCompleter) Complete(context.Context, string) (string, error) { return s.reply, s.err }
+
+func TestLLMSummarizer_UsesFirstLineAndReportsErrors(t *testing.T) {
+	span := []consciousness.Thought{{Text: "I waited. Nobody came."}}
+
+	model := consciousness.LLMSummarizer{Model: stubCompleter{reply: "I waited alone for a long time.\nextra"}}
+	if got, err := model.SummarizeContext(context.Background(), span); err != nil || got != "I waited alone for a long time." {
+		t.Fatalf("expected first line of model summary, got %q, %v", got, err)
+	}
+
+	for _, failing := range []consciousness.LLMSummarizer{
+		{Model: stubCompleter{err: errors.New("offline")}},
+		{Model: stubCompleter{reply: "  "}},
+		{},
+	} {
+		if _, err := failing.SummarizeContext(context.Background(), span); err == nil {
+			t.Errorf("expected an error from %+v", failing)
+		}
+	}
+}
+
+// blockingModel answers only when released, or fails when ctx ends first.
+type blockingModel struct {
+	release chan struct{}
+	reply   string
+}
+
+func (m blockingModel) SummarizeContext(ctx context.Context, _ []consciousness.Thought) (string, error) {
+	select {
+	case <-m.release:
+		return m.reply, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestContinuityBuffer_ModelRewritesGistsOffTheTick(t *testing.T) {
+	model := blockingModel{release: make(chan struct{}), reply: "I kept thinking about the cold."}
+	buffer := consciousness.NewContinuityBufferWith(consciousness.ContinuityConfig{Recent: 1, Span: 1, Model: model})
+	buffer.Add(consciousness.Thought{Text: "The room is cold."})
+
+	done := make(chan []consciousness.Gist, 1)
+	go func() {
+		buffer.Add(consciousness.Thought{Text: "Still cold."})
+		buffer.Lines()
+		done <- buffer.Gists()
+	}()
+	select {
+	case gists := <-done:
+		if len(gists) != 1 || gists[0].Text != "The room is cold." {
+			t.Fatalf("before the model answers the gist should be extractive, got %+v", gists)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Add and Lines must not wait for the model")
+	}
+
+	close(model.release)
+	buffer.WaitSummaries()
+	if gists := buffer.Gists(); gists[0].Text != "I kept thinking about the cold." {
+		t.Fatalf("the model summary should replace the gist once it arrives, got %+v", gists)
+	}
+}
+
+func TestContinuityBuffer_ModelTimeoutKeepsExtractiveGist(t *testing.T) {
+	model := blockingModel{release: make(chan struct{})}
+	buffer := consciousness.NewContinuityBufferWith(consciousness.ContinuityConfig{
+		Recent: 1, Span: 1, Model: model, ModelTimeout: 10 * time.Millisecond,
+	})
+	buffer.Add(consciousness.Thought{Text: "The room is cold."})
+	buffer.Add(consciousness.Thought{Text: "Still cold."})
+
+	buffer.WaitSummaries()
+	if gists := buffer.Gists(); len(gists) != 1 || gists[0].Text != "The room is cold." {
+		t.Fatalf("a timed-out model should leave the extractive gist, got %+v", gists)
+	}
+}
+
+type countingSummarizer struct{ calls *int }
+
+func (c countingSummarizer) Summarize(span []consciousness.Thought) string {
+	*c.calls++
+	return consciousness.ExtractiveSummarizer{}.Summarize(span)
+}
+
+func TestContinuityBuffer_ProvisionalGistIsCachedUntilEvictionChanges(t *testing.T) {
+	calls := 0
+	buffer := consciousness.NewContinuityBufferWith(consciousness.ContinuityConfig{
+		Recent: 1, Span: 4, Summarizer: countingSummarizer{calls: &calls},
+	})
+	buffer.Add(consciousness.Thought{Text: "one."})
+	buffer.Add(consciousness.Thought{Text: "two."})
+	for range 5 {
+		buffer.Lines()
+	}
+	if calls != 1 {
+		t.Fatalf("repeated Lines should summarize the pending thoughts once, got %d calls", calls)
+	}
+	buffer.Add(consciousness.Thought{Text: "three."})
+	if gists := buffer.Gists(); calls != 2 || gists[0].Thoughts != 2 {
+		t.Fatalf("a new eviction should refresh the provisional gist: %d calls, %+v", calls, gists)
+	}
+}
+
+func TestParseEvaluatorPulses_RepairsClampsAndDropsUnknownFields(t *testing.T) {
+	raw := "```json\n{\"effects\": {\"Stress\": 0.9, \"mood\": -0.05, \"hunger\": 0.2, \"energy\": 0,}}\n```"
+
+	pulses, err := consciousness.ParseEvaluatorPulses(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pulses) != 2 || pulses[0].Field != "mood" || pulses[1].Field != "stress" {
+		t.Fatalf("expected ordered mood and stress pulses only, got %+v", pulses)
+	}
+	assertDeltaAmount(t, pulses, "stress", 0.20)
+	assertDeltaAmount(t, pulses, "mood", -0.05)
+
+	if _, err := consciousness.ParseEvaluatorPulses("it feels tense"); err == nil {
+		t.Fatal("expected error without a JSON object")
+	}
+}
+
+func TestLLMEvaluator_PromptIsBlind(t *testing.T) {
+	var prompt string
+	model := recordingCompleter{reply: `{"effects": {"stress": 0.05}}`, prompt: &prompt}
+
+	pulses, err := consciousness.LLMEvaluator{Model: model}.Evaluate(context.Background(), "Nobody is coming.", consciousness.ParsedState{Arousal: 0.6, Valence: -0.4})
+	if err != nil || len(pulses) != 1 {
+		t.Fatalf("unexpected evaluation %+v err=%v", pulses, err)
+	}
+	if !strings.Contains(prompt, "Nobody is coming.") || !strings.Contains(prompt, "arousal 0.60, valence -0.40") {
+		t.Fatalf("prompt missing narrative or affect: %q", prompt)
+	}
+	if _, err := (consciousness.LLMEvaluator{Model: stubCompleter{err: errors.New("offline")}}).Evaluate(context.Background(), "x", consciousness.ParsedState{}); err == nil {
+		t.Fatal("expected model error to surface")
+	}
+}
+
+type recordingCompleter struct {
+	reply  string
+	prompt *string
+}
+
+func (r recordingCompleter) Complete(_ context.Context, prompt string) (string, error) {
+	*r.prompt = prompt
+	return r.reply, nil
+}
+
+func TestSomaticPulses_Modes(t *testing.T) {
+	state := consciousness.ParsedState{Arousal: 0.8, Valence: -0.5}
+	evaluated := []biology.BioPulse{{Field: "stress", Amount: 0.04}}
+
+	assertDeltaAmount(t, consciousness.SomaticPulses(consciousness.SomaticStatePulse, state, evaluated, 0.5), "stress", 0.136)
+	replaced := consciousness.SomaticPulses(consciousness.SomaticEvaluator, state, evaluated, 0.5)
+	if len(replaced) != 1 {
+		t.Fatalf("evaluator mode should apply only the evaluation, got %+v", replaced)
+	}
+	assertDeltaAmount(t, replaced, "stress", 0.04)
+
+	blended := consciousness.SomaticPulses(consciousness.SomaticBlend, state, evaluated, 0.25)
+	total := 0.0
+	for _, p := range blended {
+		if p.Field == "stress" {
+			total += p.Amount
+		}
+	}
+	if want := 0.75*0.136 + 0.25*0.04; math.Abs(total-want) > 1e-9 {
+		t.Fatalf("blended stress = %f, want %f", total, want)
+	}
+
+	fallback := consciousness.SomaticPulses(consciousness.SomaticEvaluator, state, nil, 0.5)
+	assertDeltaAmount(t, fallback, "stress", 0.136)
+}
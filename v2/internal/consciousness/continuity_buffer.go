@@ -1,25 +1,103 @@
 package consciousness
 
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultContinuitySpan         = 4
+	DefaultContinuityMaxGists     = 6
+	DefaultContinuityTokenBudget  = 400
+	DefaultContinuityModelTimeout = 30 * time.Second
+)
+
+// ContinuityConfig shapes a ContinuityBuffer. Zero fields use the defaults above;
+// a nil Summarizer uses ExtractiveSummarizer.
+type ContinuityConfig struct {
+	// Recent is how many thoughts stay verbatim.
+	Recent int
+	// Span is how many evicted thoughts are compressed into one gist.
+	Span int
+	// MaxGists bounds the older tier; beyond it the two oldest gists merge.
+	MaxGists int
+	// TokenBudget bounds the rendered Lines. Negative disables the budget.
+	TokenBudget int
+	Summarizer  Summarizer
+	// Model rewrites each new gist in the background; until it answers, the
+	// gist keeps the Summarizer's text. Nil keeps the Summarizer's text.
+	Model ModelSummarizer
+	// ModelTimeout bounds one model rewrite. Zero uses DefaultContinuityModelTimeout.
+	ModelTimeout time.Duration
+}
+
 func NewContinuityBuffer(capacity int) *ContinuityBuffer {
-	if capacity < 0 {
-		capacity = 0
+	return NewContinuityBufferWith(ContinuityConfig{Recent: capacity})
+}
+
+func NewContinuityBufferWith(cfg ContinuityConfig) *ContinuityBuffer {
+	capacity := max(cfg.Recent, 0)
+	spanSize := cfg.Span
+	if spanSize <= 0 {
+		spanSize = DefaultContinuitySpan
+	}
+	maxGists := cfg.MaxGists
+	if maxGists <= 0 {
+		maxGists = DefaultContinuityMaxGists
+	}
+	budget := cfg.TokenBudget
+	if budget == 0 {
+		budget = DefaultContinuityTokenBudget
+	}
+	summarizer := cfg.Summarizer
+	if summarizer == nil {
+		summarizer = ExtractiveSummarizer{}
+	}
+	timeout := cfg.ModelTimeout
+	if timeout <= 0 {
+		timeout = DefaultContinuityModelTimeout
 	}
 	return &ContinuityBuffer{
-		capacity: capacity,
-		thoughts: make([]Thought, 0, capacity),
+		capacity:     capacity,
+		thoughts:     make([]Thought, 0, capacity),
+		spanSize:     spanSize,
+		maxGists:     maxGists,
+		tokenBudget:  budget,
+		summarizer:   summarizer,
+		model:        cfg.Model,
+		modelTimeout: timeout,
+		rewrites:     make(map[int]string),
 	}
 }
 
+// Add appends a thought. Once the recent tier is full, the oldest thought is not
+// dropped but moved toward compression: every Span evicted thoughts become one gist.
+// Contract: Add never waits for the Model; its rewrites apply once they have arrived.
 func (b *ContinuityBuffer) Add(thought Thought) {
 	if b == nil || b.capacity == 0 {
 		return
 	}
+	b.applyRewrites()
 	b.thoughts = append(b.thoughts, thought)
-	if len(b.thoughts) > b.capacity {
-		b.thoughts = b.thoughts[len(b.thoughts)-b.capacity:]
+	if over := len(b.thoughts) - b.capacity; over > 0 {
+		b.evicted = append(b.evicted, b.thoughts[:over]...)
+		b.thoughts = append([]Thought(nil), b.thoughts[over:]...)
+		b.provisional = nil
+	}
+	if len(b.evicted) >= b.spanSize {
+		b.gists = append(b.gists, b.compress(b.evicted))
+		b.evicted = nil
+		b.provisional = nil
+	}
+	if len(b.gists) > b.maxGists {
+		merged := b.merge(b.gists[0], b.gists[1])
+		b.gists = append([]Gist{merged}, b.gists[2:]...)
 	}
 }
 
+// Items returns the recent verbatim thoughts, oldest first.
 func (b *ContinuityBuffer) Items() []Thought {
 	if b == nil || len(b.thoughts) == 0 {
 		return nil
@@ -28,3 +106,203 @@ func (b *ContinuityBuffer) Items() []Thought {
 	copy(out, b.thoughts)
 	return out
 }
+
+// Gists returns the compressed older tier, oldest first. Evicted thoughts still
+// waiting for a full span are included as a provisional gist, summarized once
+// per change and never by the Model.
+func (b *ContinuityBuffer) Gists() []Gist {
+	if b == nil {
+		return nil
+	}
+	b.applyRewrites()
+	out := make([]Gist, 0, len(b.gists)+1)
+	out = append(out, b.gists...)
+	if len(b.evicted) > 0 {
+		if b.provisional == nil {
+			b.provisional = &Gist{
+				Text:     b.summarizer.Summarize(b.evicted),
+				Thoughts: len(b.evicted),
+				Residue:  meanAffect(b.evicted),
+			}
+		}
+		out = append(out, *b.provisional)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// Lines renders gists followed by recent thoughts, oldest first, within the token budget.
+// When the budget is tight the newest material wins: recent thoughts are kept before gists.
+func (b *ContinuityBuffer) Lines() []string {
+	if b == nil {
+		return nil
+	}
+	recent := continuityLines(b.thoughts)
+	gists := b.Gists()
+	older := make([]string, 0, len(gists))
+	for _, g := range gists {
+		older = append(older, GistLine(g))
+	}
+
+	budget := b.tokenBudget
+	keptRecent := keepNewestWithin(recent, &budget)
+	keptOlder := keepNewestWithin(older, &budget)
+	out := append(keptOlder, keptRecent...)
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// WaitSummaries blocks until every model rewrite started so far has finished
+// or timed out, and applies the results.
+func (b *ContinuityBuffer) WaitSummaries() {
+	if b == nil {
+		return
+	}
+	b.running.Wait()
+	b.applyRewrites()
+}
+
+// GistLine renders a gist with its span size and any emotional residue.
+func GistLine(g Gist) string {
+	line := fmt.Sprintf("Earlier (%d thoughts): %s", g.Thoughts, strings.TrimSpace(g.Text))
+	if residue := residuePhrase(g.Residue); residue != "" {
+		line += " It left " + residue + "."
+	}
+	return line
+}
+
+// EstimateTokens approximates the token count of s (about four characters per token).
+func EstimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+func (b *ContinuityBuffer) compress(span []Thought) Gist {
+	g := Gist{
+		Text:     b.summarizer.Summarize(span),
+		Thoughts: len(span),
+		Residue:  meanAffect(span),
+	}
+	g.id = b.rewrite(span)
+	return g
+}
+
+func (b *ContinuityBuffer) merge(older, newer Gist) Gist {
+	span := []Thought{
+		{Text: older.Text, Affect: older.Residue},
+		{Text: newer.Text, Affect: newer.Residue},
+	}
+	text := b.summarizer.Summarize(span)
+	total := older.Thoughts + newer.Thoughts
+	wo := float64(older.Thoughts) / float64(max(total, 1))
+	wn := 1 - wo
+	return Gist{
+		Text:     text,
+		Thoughts: total,
+		Residue: ParsedState{
+			Arousal: older.Residue.Arousal*wo + newer.Residue.Arousal*wn,
+			Valence: older.Residue.Valence*wo + newer.Residue.Valence*wn,
+		},
+		id: b.rewrite(span),
+	}
+}
+
+// rewrite starts a model summary of span in the background and returns the id
+// its result is filed under, or 0 without a model.
+func (b *ContinuityBuffer) rewrite(span []Thought) int {
+	if b.model == nil {
+		return 0
+	}
+	b.nextID++
+	id := b.nextID
+	span = append([]Thought(nil), span...)
+	b.running.Add(1)
+	go func() {
+		defer b.running.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), b.modelTimeout)
+		defer cancel()
+		text, err := b.model.SummarizeContext(ctx, span)
+		if err != nil {
+			return
+		}
+		b.mu.Lock()
+		b.rewrites[id] = text
+		b.mu.Unlock()
+	}()
+	return id
+}
+
+// applyRewrites swaps in model summaries that have arrived. Rewrites of gists
+// merged away in the meantime are dropped.
+func (b *ContinuityBuffer) applyRewrites() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.rewrites) == 0 {
+		return
+	}
+	for i := range b.gists {
+		if text, ok := b.rewrites[b.gists[i].id]; ok {
+			b.gists[i].Text = text
+		}
+	}
+	clear(b.rewrites)
+}
+
+// keepNewestWithin keeps the longest newest-first suffix of lines that fits the
+// remaining budget, which it reduces. A negative budget keeps everything.
+func keepNewestWithin(lines []string, budget *int) []string {
+	if *budget < 0 {
+		return lines
+	}
+	start := len(lines)
+	for start > 0 {
+		cost := EstimateTokens(lines[start-1])
+		if cost > *budget {
+			break
+		}
+		*budget -= cost
+		start--
+	}
+	return append([]string(nil), lines[start:]...)
+}
+
+// meanAffect averages the affect of thoughts that carried one.
+func meanAffect(span []Thought) ParsedState {
+	var sum ParsedState
+	n := 0
+	for _, t := range span {
+		if t.Affect == (ParsedState{}) {
+			continue
+		}
+		sum.Arousal += t.Affect.Arousal
+		sum.Valence += t.Affect.Valence
+		n++
+	}
+	if n == 0 {
+		return ParsedState{}
+	}
+	return ParsedState{Arousal: sum.Arousal / float64(n), Valence: sum.Valence / float64(n)}
+}
+
+func residuePhrase(r ParsedState) string {
+	const faint = 0.15
+	switch {
+	case r.Valence <= -faint && r.Arousal > 0.3:
+		return "a lingering agitation"
+	case r.Valence <= -faint:
+		return "a lingering heaviness"
+	case r.Valence >= faint && r.Arousal > 0.3:
+		return "a lingering excitement"
+	case r.Valence >= faint:
+		return "a lingering warmth"
+	case r.Arousal > 0.3:
+		return "a lingering restlessness"
+	case r.Arousal < -0.3:
+		return "a lingering flatness"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,42 @@
+package consciousness
+
+import "fmt"
+
+// CorrectivePrompt builds the follow-up instruction sent to the mind after
+// its output could not be used, naming what was wrong and the expected format.
+func CorrectivePrompt(protocol ResponseProtocol, reason ParseFailure) string {
+	problem := failureDescription(reason)
+	if protocol == ProtocolJSON {
+		return fmt.Sprintf(
+			"Your last reply could not be used: %s. Reply again with only one JSON object matching this schema:\n%s",
+			problem, ResponseJSONSchema,
+		)
+	}
+	return fmt.Sprintf(
+		"Your last reply could not be used: %s. Reply again with your narrative followed by "+
+			"[STATE: arousal=<-1..1>, valence=<-1..1>] and [ACTION: <action_name>], "+
+			"plus optional [DRIVE: <drive>=<0..1>] and [SPEECH: <words said aloud>] tags.",
+		problem,
+	)
+}
+
+func failureDescription(reason ParseFailure) string {
+	switch reason {
+	case ParseMissingState:
+		return "the state was missing"
+	case ParseMalformedState:
+		return "the state was malformed"
+	case ParseMissingAction:
+		return "the action was missing"
+	case ParseMalformedAction:
+		return "the action was malformed"
+	case ParseMalformedDrive:
+		return "a drive value was malformed or named an unknown drive"
+	case ParseInvalidJSON:
+		return "it was not a JSON object"
+	case ParseMissingNarrative:
+		return "the narrative was missing"
+	default:
+		return "it did not follow the response format"
+	}
+}
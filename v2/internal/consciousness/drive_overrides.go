@@ -13,19 +13,30 @@ func ApplyParsedDriveOverridesForNextTick(raw motivation.MotivationState, parsed
 }
 
 func ApplyDriveOverrides(raw motivation.MotivationState, overrides map[motivation.Drive]float64) motivation.MotivationState {
-	result := raw
-	result.EnergyUrgency = effectiveDrive(raw.EnergyUrgency, overrides, motivation.DriveEnergy)
-	result.SocialUrgency = effectiveDrive(raw.SocialUrgency, overrides, motivation.DriveSocialConnection)
-	result.StimulationUrgency = effectiveDrive(raw.StimulationUrgency, overrides, motivation.DriveStimulation)
-	result.SafetyUrgency = effectiveDrive(raw.SafetyUrgency, overrides, motivation.DriveSafety)
-	result.IdentityUrgency = effectiveDrive(raw.IdentityUrgency, overrides, motivation.DriveIdentityCoherence)
+	return ApplyDriveOverridesOnto(raw, raw, overrides)
+}
 
-	ordered := rankedDrives(result)
+// ApplyDriveOverridesOnto applies overrides on top of an existing perception.
+// Overridden drives keep the half-raw floor; all other drives keep their base value.
+func ApplyDriveOverridesOnto(base, raw motivation.MotivationState, overrides map[motivation.Drive]float64) motivation.MotivationState {
+	result := base
+	for _, drive := range motivation.DefaultRegistry().Drives() {
+		if _, ok := overrides[drive]; ok {
+			result.SetUrgency(drive, effectiveDrive(raw.Urgency(drive), overrides, drive))
+			continue
+		}
+		result.SetUrgency(drive, clamp01(base.Urgency(drive)))
+	}
+	return withActiveGoal(result)
+}
+
+func withActiveGoal(state motivation.MotivationState) motivation.MotivationState {
+	ordered := rankedDrives(state)
 	if len(ordered) > 0 {
-		result.ActiveGoalDrive = ordered[0].drive
-		result.ActiveGoalUrgency = ordered[0].urgency
+		state.ActiveGoalDrive = ordered[0].drive
+		state.ActiveGoalUrgency = ordered[0].urgency
 	}
-	return result
+	return state
 }
 
 type rankedDrive struct {
@@ -34,24 +45,18 @@ type rankedDrive struct {
 	priority int
 }
 
-var drivePriority = map[motivation.Drive]int{
-	motivation.DriveSafety:            0,
-	motivation.DriveEnergy:            1,
-	motivation.DriveSocialConnection:  2,
-	motivation.DriveIdentityCoherence: 3,
-	motivation.DriveStimulation:       4,
-}
-
 func rankedDrives(state motivation.MotivationState) []rankedDrive {
-	drives := []rankedDrive{
-		{drive: motivation.DriveEnergy, urgency: clamp01(state.EnergyUrgency), priority: drivePriority[motivation.DriveEnergy]},
-		{drive: motivation.DriveSocialConnection, urgency: clamp01(state.SocialUrgency), priority: drivePriority[motivation.DriveSocialConnection]},
-		{drive: motivation.DriveStimulation, urgency: clamp01(state.StimulationUrgency), priority: drivePriority[motivation.DriveStimulation]},
-		{drive: motivation.DriveSafety, urgency: clamp01(state.SafetyUrgency), priority: drivePriority[motivation.DriveSafety]},
-		{drive: motivation.DriveIdentityCoherence, urgency: clamp01(state.IdentityUrgency), priority: drivePriority[motivation.DriveIdentityCoherence]},
+	defs := motivation.DefaultRegistry().Definitions()
+	drives := make([]rankedDrive, 0, len(defs))
+	for _, def := range defs {
+		drives = append(drives, rankedDrive{
+			drive:    def.Drive,
+			urgency:  clamp01(state.Urgency(def.Drive)),
+			priority: def.Priority,
+		})
 	}
 
-	sort.Slice(drives, func(i, j int) bool {
+	sort.SliceStable(drives, func(i, j int) bool {
 		if drives[i].urgency == drives[j].urgency {
 			return drives[i].priority < drives[j].priority
 		}
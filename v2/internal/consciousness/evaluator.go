@@ -0,0 +1,131 @@
+package consciousness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/biology"
+)
+
+// SomaticMode selects how a thought's bodily impact is derived (ADR-002).
+type SomaticMode string
+
+const (
+	// SomaticStatePulse maps the mind's own [STATE] report via EmotionalPulseFromState.
+	SomaticStatePulse SomaticMode = "state"
+	// SomaticEvaluator replaces the state pulse with the blind evaluator's verdict.
+	SomaticEvaluator SomaticMode = "evaluator"
+	// SomaticBlend applies both, weighted by the evaluator weight.
+	SomaticBlend SomaticMode = "blend"
+)
+
+// ThoughtEvaluator judges a thought's bodily impact from its text and the affect
+// summary alone. It never sees drives, memories or continuity.
+type ThoughtEvaluator interface {
+	Evaluate(ctx context.Context, narrative string, affect ParsedState) ([]biology.BioPulse, error)
+}
+
+// evaluatorLimits bounds each evaluated pulse to the range EmotionalPulseFromState
+// can produce, so a single misjudged thought cannot push biology into a spiral.
+var evaluatorLimits = map[string]float64{
+	"stress":             0.20,
+	"mood":               0.21,
+	"physical_tension":   0.10,
+	"cognitive_capacity": 0.10,
+	"energy":             0.05,
+}
+
+// LLMEvaluator asks a model for a structured verdict.
+type LLMEvaluator struct {
+	Model Completer
+}
+
+func (e LLMEvaluator) Evaluate(ctx context.Context, narrative string, affect ParsedState) ([]biology.BioPulse, error) {
+	if e.Model == nil {
+		return nil, fmt.Errorf("evaluator requires a model")
+	}
+	raw, err := e.Model.Complete(ctx, EvaluatorPrompt(narrative, affect))
+	if err != nil {
+		return nil, fmt.Errorf("evaluate thought: %w", err)
+	}
+	return ParseEvaluatorPulses(raw)
+}
+
+// EvaluatorPrompt renders the blind evaluation request.
+func EvaluatorPrompt(narrative string, affect ParsedState) string {
+	var b strings.Builder
+	b.WriteString("You observe how a single passing thought lands in a person's body. ")
+	b.WriteString("You know nothing about who they are or what happened to them. ")
+	b.WriteString("Judge the bodily impact the way a body reacts, before any reflection.\n")
+	b.WriteString(`Answer with one JSON object and nothing else: {"effects": {"<field>": <delta>}}` + "\n")
+	b.WriteString("Fields: stress, mood, physical_tension, cognitive_capacity, energy. ")
+	b.WriteString("Deltas are small, typically -0.2..0.2. Omit fields the thought does not affect.\n")
+	fmt.Fprintf(&b, "Current felt state: arousal %.2f, valence %.2f (both -1..1).\n", affect.Arousal, affect.Valence)
+	b.WriteString("The thought:\n")
+	b.WriteString(strings.TrimSpace(narrative))
+	return b.String()
+}
+
+// ParseEvaluatorPulses decodes an evaluator verdict. Near-valid JSON is repaired
+// like mind responses; unknown fields are dropped and amounts clamped.
+// Contract: pulses are ordered by field and are absolute, like EmotionalPulseFromState.
+func ParseEvaluatorPulses(raw string) ([]biology.BioPulse, error) {
+	var decoded struct {
+		Effects map[string]float64 `json:"effects"`
+	}
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		repaired, ok := repairJSON(raw)
+		if !ok {
+			return nil, fmt.Errorf("no JSON object in evaluation")
+		}
+		if err := json.Unmarshal([]byte(repaired), &decoded); err != nil {
+			return nil, fmt.Errorf("evaluation is not valid JSON after repair: %w", err)
+		}
+	}
+
+	pulses := make([]biology.BioPulse, 0, len(decoded.Effects))
+	for field, amount := range decoded.Effects {
+		field = strings.ToLower(strings.TrimSpace(field))
+		limit, ok := evaluatorLimits[field]
+		if !ok || amount == 0 {
+			continue
+		}
+		pulses = append(pulses, biology.BioPulse{Field: field, Amount: clamp(amount, -limit, limit)})
+	}
+	sort.Slice(pulses, func(i, j int) bool { return pulses[i].Field < pulses[j].Field })
+	return pulses, nil
+}
+
+// SomaticPulses combines the state pulse with an evaluation according to mode.
+// A nil evaluation (state mode, or the evaluator failed) yields the state pulse in
+// full. In blend mode the state pulse is scaled by 1-weight and the evaluation by weight.
+func SomaticPulses(mode SomaticMode, state ParsedState, evaluated []biology.BioPulse, weight float64) []biology.BioPulse {
+	pulse := EmotionalPulseFromState(state)
+	if evaluated == nil {
+		return pulse
+	}
+	switch mode {
+	case SomaticEvaluator:
+		return append([]biology.BioPulse(nil), evaluated...)
+	case SomaticBlend:
+		weight = clamp01(weight)
+		out := scalePulses(pulse, 1-weight)
+		return append(out, scalePulses(evaluated, weight)...)
+	default:
+		return pulse
+	}
+}
+
+func scalePulses(pulses []biology.BioPulse, factor float64) []biology.BioPulse {
+	out := make([]biology.BioPulse, 0, len(pulses))
+	for _, p := range pulses {
+		if factor == 0 {
+			continue
+		}
+		out = append(out, biology.BioPulse{Field: p.Field, Amount: p.Amount * factor})
+	}
+	return out
+}
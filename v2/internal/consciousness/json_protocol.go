@@ -0,0 +1,210 @@
+package consciousness
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// ResponseProtocol selects how raw mind output is parsed.
+type ResponseProtocol string
+
+const (
+	// ProtocolTags parses bracket tags: [STATE: ..], [ACTION: ..], [DRIVE: ..=..], [SPEECH: ..].
+	ProtocolTags ResponseProtocol = "tags"
+	// ProtocolJSON parses one JSON object matching ResponseJSONSchema.
+	ProtocolJSON ResponseProtocol = "json"
+)
+
+// ResponseJSONSchema is the published JSON Schema for ProtocolJSON responses.
+// Prompts using the JSON protocol should embed it verbatim.
+const ResponseJSONSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "MindResponse",
+  "type": "object",
+  "required": ["narrative", "state", "action"],
+  "properties": {
+    "narrative": {"type": "string"},
+    "state": {
+      "type": "object",
+      "required": ["arousal", "valence"],
+      "properties": {
+        "arousal": {"type": "number", "minimum": -1, "maximum": 1},
+        "valence": {"type": "number", "minimum": -1, "maximum": 1}
+      }
+    },
+    "action": {"type": "string", "pattern": "^[a-z_]+$"},
+    "drives": {
+      "type": "object",
+      "additionalProperties": {"type": "number", "minimum": 0, "maximum": 1}
+    },
+    "speech": {"type": "string"}
+  }
+}`
+
+// ParseResponseWith parses raw using the selected protocol. Unknown protocols use tags.
+func ParseResponseWith(protocol ResponseProtocol, raw string, prior ParsedResponse) ParsedResponse {
+	if protocol == ProtocolJSON {
+		return ParseJSONResponse(raw, prior)
+	}
+	return ParseResponse(raw, prior)
+}
+
+type jsonResponse struct {
+	Narrative *string            `json:"narrative"`
+	State     *jsonState         `json:"state"`
+	Action    *string            `json:"action"`
+	Drives    map[string]float64 `json:"drives"`
+	Speech    *string            `json:"speech"`
+}
+
+type jsonState struct {
+	Arousal *float64 `json:"arousal"`
+	Valence *float64 `json:"valence"`
+}
+
+var actionNameRe = regexp.MustCompile(`^[a-z_]+$`)
+
+// ParseJSONResponse parses a JSON mind response with the same fallback contract as
+// ParseResponse: if state or action is missing or invalid, or any drive override is
+// malformed, State/Action/DriveOverrides fall back to prior. Near-valid output is
+// repaired first (code fences, surrounding prose, trailing commas, smart quotes).
+func ParseJSONResponse(raw string, prior ParsedResponse) ParsedResponse {
+	fallback := ParsedResponse{
+		State:          prior.State,
+		Action:         prior.Action,
+		DriveOverrides: cloneOverrides(prior.DriveOverrides),
+		Status:         ParseStatusFailed,
+	}
+
+	decoded, err := decodeJSONResponse(raw)
+	if err != nil {
+		fallback.Narrative = strings.TrimSpace(raw)
+		fallback.Reason = ParseInvalidJSON
+		return fallback
+	}
+	if decoded.Narrative != nil {
+		fallback.Narrative = strings.TrimSpace(*decoded.Narrative)
+	}
+	if decoded.Speech != nil {
+		fallback.Speech = strings.TrimSpace(*decoded.Speech)
+	}
+
+	if reason := validateJSONResponse(decoded); reason != "" {
+		fallback.Reason = reason
+		return fallback
+	}
+	overrides, err := jsonDriveOverrides(decoded.Drives)
+	if err != nil {
+		fallback.Reason = ParseMalformedDrive
+		return fallback
+	}
+
+	result := fallback
+	result.State = ParsedState{Arousal: *decoded.State.Arousal, Valence: *decoded.State.Valence}
+	result.Action = strings.ToLower(strings.TrimSpace(*decoded.Action))
+	result.DriveOverrides = overrides
+	result.Status = ParseStatusOK
+	return result
+}
+
+func decodeJSONResponse(raw string) (jsonResponse, error) {
+	var out jsonResponse
+	if err := json.Unmarshal([]byte(raw), &out); err == nil {
+		return out, nil
+	}
+	repaired, ok := repairJSON(raw)
+	if !ok {
+		return jsonResponse{}, fmt.Errorf("no JSON object found in response")
+	}
+	if err := json.Unmarshal([]byte(repaired), &out); err != nil {
+		return jsonResponse{}, fmt.Errorf("response is not valid JSON after repair: %w", err)
+	}
+	return out, nil
+}
+
+// validateJSONResponse checks a decoded response against ResponseJSONSchema.
+func validateJSONResponse(r jsonResponse) ParseFailure {
+	if r.Narrative == nil {
+		return ParseMissingNarrative
+	}
+	if r.State == nil || r.State.Arousal == nil || r.State.Valence == nil {
+		return ParseMissingState
+	}
+	if *r.State.Arousal < -1 || *r.State.Arousal > 1 || *r.State.Valence < -1 || *r.State.Valence > 1 {
+		return ParseMalformedState
+	}
+	if r.Action == nil {
+		return ParseMissingAction
+	}
+	if !actionNameRe.MatchString(strings.ToLower(strings.TrimSpace(*r.Action))) {
+		return ParseMalformedAction
+	}
+	return ""
+}
+
+func jsonDriveOverrides(drives map[string]float64) (map[motivation.Drive]float64, error) {
+	overrides := make(map[motivation.Drive]float64, len(drives))
+	for name, value := range drives {
+		drive, ok := parseDriveName(strings.ToLower(strings.TrimSpace(name)))
+		if !ok {
+			return nil, fmt.Errorf("unknown drive %q", name)
+		}
+		overrides[drive] = clamp01(value)
+	}
+	return overrides, nil
+}
+
+var trailingCommaRe = regexp.MustCompile(`,\s*([}\]])`)
+
+// repairJSON extracts the first balanced JSON object from raw and fixes common
+// near-valid mistakes. It reports false when no object can be found.
+func repairJSON(raw string) (string, bool) {
+	s := strings.NewReplacer("“", `"`, "”", `"`, "‘", "'", "’", "'").Replace(raw)
+	s = strings.ReplaceAll(s, "```json", "")
+	s = strings.ReplaceAll(s, "```", "")
+
+	start := strings.Index(s, "{")
+	if start < 0 {
+		return "", false
+	}
+	end, depth := scanBraces(s[start:])
+	if end < 0 {
+		// Truncated output: close any braces still open.
+		s = s[start:] + strings.Repeat("}", max(depth, 0))
+	} else {
+		s = s[start : start+end+1]
+	}
+	return trailingCommaRe.ReplaceAllString(s, "$1"), true
+}
+
+// scanBraces walks s (starting at an opening brace) outside of string literals.
+// It returns the index where the first object closes, or -1 with the depth still open.
+func scanBraces(s string) (int, int) {
+	depth := 0
+	inString := false
+	escaped := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && inString:
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return i, 0
+			}
+		}
+	}
+	return -1, depth
+}
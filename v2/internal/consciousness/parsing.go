@@ -9,11 +9,14 @@ import (
 )
 
 var (
-	stateTagRe      = regexp.MustCompile(`(?i)\[STATE:\s*arousal=([-\d.]+),\s*valence=([-\d.]+)\]`)
-	actionTagRe     = regexp.MustCompile(`(?i)\[ACTION:\s*([a-z_]+)\s*\]`)
-	driveTagRe      = regexp.MustCompile(`(?i)\[DRIVE:\s*([a-z_]+)\s*=\s*([-\d.]+)\s*\]`)
-	driveTagLooseRe = regexp.MustCompile(`(?i)\[DRIVE:[^\]]*\]`)
-	stripTagsRe     = regexp.MustCompile(`(?i)\[(STATE|ACTION|DRIVE):[^\]]*\]`)
+	stateTagRe       = regexp.MustCompile(`(?i)\[STATE:\s*arousal=([-\d.]+),\s*valence=([-\d.]+)\]`)
+	actionTagRe      = regexp.MustCompile(`(?i)\[ACTION:\s*([a-z_]+)\s*\]`)
+	driveTagRe       = regexp.MustCompile(`(?i)\[DRIVE:\s*([a-z_]+)\s*=\s*([-\d.]+)\s*\]`)
+	driveTagLooseRe  = regexp.MustCompile(`(?i)\[DRIVE:[^\]]*\]`)
+	stateTagLooseRe  = regexp.MustCompile(`(?i)\[STATE:[^\]]*\]`)
+	actionTagLooseRe = regexp.MustCompile(`(?i)\[ACTION:[^\]]*\]`)
+	speechTagRe      = regexp.MustCompile(`(?i)\[SPEECH:\s*([^\]]*)\]`)
+	stripTagsRe      = regexp.MustCompile(`(?i)\[(STATE|ACTION|DRIVE|SPEECH):[^\]]*\]`)
 )
 
 func ParseResponse(raw string, prior ParsedResponse) ParsedResponse {
@@ -22,35 +25,45 @@ func ParseResponse(raw string, prior ParsedResponse) ParsedResponse {
 		Action:         prior.Action,
 		DriveOverrides: cloneOverrides(prior.DriveOverrides),
 		Narrative:      stripTags(raw),
+		Speech:         parseSpeech(raw),
+		Status:         ParseStatusFailed,
 	}
 
 	state, okState := parseState(raw)
+	if !okState {
+		result.Reason = missingOrMalformed(stateTagLooseRe, raw, ParseMissingState, ParseMalformedState)
+		return result
+	}
 	action, okAction := parseAction(raw)
-	if !okState || !okAction {
+	if !okAction {
+		result.Reason = missingOrMalformed(actionTagLooseRe, raw, ParseMissingAction, ParseMalformedAction)
 		return result
 	}
 
-	result.State = state
-	result.Action = action
-
 	overrides, okOverrides := parseDriveOverrides(raw)
-	if okOverrides {
-		result.DriveOverrides = overrides
-	} else if len(driveTagLooseRe.FindAllString(raw, -1)) == 0 {
-		result.DriveOverrides = map[motivation.Drive]float64{}
-	} else {
-		// Malformed optional DRIVE tag still invalidates this parse turn.
-		return ParsedResponse{
-			State:          prior.State,
-			Action:         prior.Action,
-			DriveOverrides: cloneOverrides(prior.DriveOverrides),
-			Narrative:      stripTags(raw),
+	if !okOverrides {
+		if len(driveTagLooseRe.FindAllString(raw, -1)) != 0 {
+			// Malformed optional DRIVE tag still invalidates this parse turn.
+			result.Reason = ParseMalformedDrive
+			return result
 		}
+		overrides = map[motivation.Drive]float64{}
 	}
 
+	result.State = state
+	result.Action = action
+	result.DriveOverrides = overrides
+	result.Status = ParseStatusOK
 	return result
 }
 
+func missingOrMalformed(loose *regexp.Regexp, raw string, missing, malformed ParseFailure) ParseFailure {
+	if loose.MatchString(raw) {
+		return malformed
+	}
+	return missing
+}
+
 func parseState(raw string) (ParsedState, bool) {
 	match := stateTagRe.FindStringSubmatch(raw)
 	if len(match) != 3 {
@@ -74,6 +87,18 @@ func parseAction(raw string) (string, bool) {
 	return strings.ToLower(match[1]), true
 }
 
+// parseSpeech joins the optional [SPEECH: ..] tags. Speech is never carried from prior:
+// what was said last turn is not said again.
+func parseSpeech(raw string) string {
+	var parts []string
+	for _, m := range speechTagRe.FindAllStringSubmatch(raw, -1) {
+		if text := strings.Trim(strings.TrimSpace(m[1]), `"`); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
 func parseDriveOverrides(raw string) (map[motivation.Drive]float64, bool) {
 	loose := driveTagLooseRe.FindAllString(raw, -1)
 	if len(loose) == 0 {
@@ -101,20 +126,11 @@ func parseDriveOverrides(raw string) (map[motivation.Drive]float64, bool) {
 }
 
 func parseDriveName(name string) (motivation.Drive, bool) {
-	switch name {
-	case string(motivation.DriveEnergy):
-		return motivation.DriveEnergy, true
-	case string(motivation.DriveSocialConnection):
-		return motivation.DriveSocialConnection, true
-	case string(motivation.DriveStimulation):
-		return motivation.DriveStimulation, true
-	case string(motivation.DriveSafety):
-		return motivation.DriveSafety, true
-	case string(motivation.DriveIdentityCoherence):
-		return motivation.DriveIdentityCoherence, true
-	default:
+	def, ok := motivation.DefaultRegistry().Lookup(motivation.Drive(name))
+	if !ok {
 		return "", false
 	}
+	return def.Drive, true
 }
 
 func stripTags(raw string) string {
@@ -0,0 +1,53 @@
+package consciousness
+
+import (
+	"math"
+
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// DefaultPerceptionHalfLife is how many seconds it takes a reported drive
+// perception to close half of its gap to the raw drive value.
+const DefaultPerceptionHalfLife = 30.0
+
+// DecayPerceived moves a carried perception toward the raw drive state.
+// After halfLife seconds half of the raw/perceived gap remains.
+func DecayPerceived(perceived, raw motivation.MotivationState, dt, halfLife float64) motivation.MotivationState {
+	if halfLife <= 0 {
+		return withActiveGoal(raw)
+	}
+	keep := math.Pow(0.5, math.Max(dt, 0)/halfLife)
+	result := raw
+	for _, drive := range motivation.DefaultRegistry().Drives() {
+		r := clamp01(raw.Urgency(drive))
+		p := clamp01(perceived.Urgency(drive))
+		result.SetUrgency(drive, r+(p-r)*keep)
+	}
+	return withActiveGoal(result)
+}
+
+// PerceptionGap measures how far the mind's view of its drives departs from the raw drives.
+type PerceptionGap struct {
+	// Mean is the mean absolute raw/perceived difference across drives.
+	Mean float64
+	// Denial is the mean amount by which perceived urgency sits below raw urgency.
+	Denial float64
+}
+
+// MeasurePerceptionGap compares raw and perceived drive state across registered drives.
+func MeasurePerceptionGap(raw, perceived motivation.MotivationState) PerceptionGap {
+	drives := motivation.DefaultRegistry().Drives()
+	if len(drives) == 0 {
+		return PerceptionGap{}
+	}
+	var gap PerceptionGap
+	for _, drive := range drives {
+		diff := clamp01(raw.Urgency(drive)) - clamp01(perceived.Urgency(drive))
+		gap.Mean += math.Abs(diff)
+		gap.Denial += max0(diff)
+	}
+	n := float64(len(drives))
+	gap.Mean /= n
+	gap.Denial /= n
+	return gap
+}
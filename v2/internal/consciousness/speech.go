@@ -0,0 +1,20 @@
+package consciousness
+
+import (
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/biology"
+)
+
+// SpeechPulse maps something said aloud to absolute bio deltas.
+// Contract: speech only counts as social contact when someone is present to hear it;
+// talking to an empty room emits no bio effects. It is a smaller dose than reach_out.
+func SpeechPulse(speech string, listenerPresent bool) []biology.BioPulse {
+	if strings.TrimSpace(speech) == "" || !listenerPresent {
+		return nil
+	}
+	return []biology.BioPulse{
+		{Field: "social_deficit", Amount: -0.06},
+		{Field: "mood", Amount: 0.02},
+	}
+}
@@ -0,0 +1,98 @@
+package consciousness
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Summarizer compresses a span of thoughts into one gist sentence.
+// Contract: the continuity buffer calls it on the tick path, so it must be cheap.
+type Summarizer interface {
+	Summarize(span []Thought) string
+}
+
+// ExtractiveSummarizer keeps the first sentence of the span's most emotionally
+// intense thought (the latest one on ties). It is deterministic and needs no model.
+type ExtractiveSummarizer struct{}
+
+func (ExtractiveSummarizer) Summarize(span []Thought) string {
+	best := -1
+	bestIntensity := -1.0
+	for i, t := range span {
+		if strings.TrimSpace(t.Text) == "" {
+			continue
+		}
+		intensity := math.Abs(t.Affect.Arousal) + math.Abs(t.Affect.Valence)
+		if intensity >= bestIntensity {
+			best, bestIntensity = i, intensity
+		}
+	}
+	if best < 0 {
+		return "Nothing much came to mind."
+	}
+	return firstSentence(span[best].Text)
+}
+
+// Completer is a text model used for summarization and evaluation.
+// Contract: it returns promptly once ctx is done.
+type Completer interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// ModelSummarizer summarizes a span with a model. The continuity buffer runs it
+// off the tick and keeps the Summarizer's text until it answers.
+type ModelSummarizer interface {
+	SummarizeContext(ctx context.Context, span []Thought) (string, error)
+}
+
+// LLMSummarizer asks a model for a one-sentence first-person summary.
+type LLMSummarizer struct {
+	Model Completer
+}
+
+func (s LLMSummarizer) SummarizeContext(ctx context.Context, span []Thought) (string, error) {
+	if s.Model == nil {
+		return "", fmt.Errorf("summarizer requires a model")
+	}
+	out, err := s.Model.Complete(ctx, summaryPrompt(span))
+	if err != nil {
+		return "", fmt.Errorf("summarize thoughts: %w", err)
+	}
+	line := firstLine(out)
+	if line == "" {
+		return "", fmt.Errorf("summarize thoughts: empty reply")
+	}
+	return line, nil
+}
+
+func summaryPrompt(span []Thought) string {
+	var b strings.Builder
+	b.WriteString("Summarize these earlier thoughts in one first-person sentence. ")
+	b.WriteString("Keep what mattered and how it felt; drop details.\n")
+	for _, t := range span {
+		if text := strings.TrimSpace(t.Text); text != "" {
+			b.WriteString("- ")
+			b.WriteString(text)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func firstSentence(text string) string {
+	text = strings.TrimSpace(text)
+	if i := strings.IndexAny(text, ".!?"); i >= 0 {
+		return text[:i+1]
+	}
+	return firstLine(text)
+}
+
+func firstLine(text string) string {
+	text = strings.TrimSpace(text)
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		return strings.TrimSpace(text[:i])
+	}
+	return text
+}
@@ -0,0 +1,259 @@
+package consciousness
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// Seed categories beyond drive and associative drift.
+const (
+	ThoughtCategoryContinuity ThoughtCategory = "continuity"
+	ThoughtCategoryMemory     ThoughtCategory = "memory"
+	ThoughtCategoryInput      ThoughtCategory = "input"
+	ThoughtCategoryBlocked    ThoughtCategory = "blocked"
+)
+
+// Seed weights before urgency, recency and absorption scaling.
+const (
+	seedWeightDrift      = 0.10
+	seedWeightContinuity = 0.40
+	seedWeightMemory     = 0.30
+	seedWeightInput      = 0.60
+	seedWeightBlocked    = 0.50
+
+	// maxRecentInputs bounds how many inputs the seeder remembers.
+	maxRecentInputs = 4
+)
+
+// SeedSources is everything a spontaneous thought may be drawn from this tick.
+type SeedSources struct {
+	Motivation motivation.MotivationState
+	// Continuity is recent thoughts, oldest first.
+	Continuity []Thought
+	// Memories are recalled episodes rendered as lines.
+	Memories []string
+	// Inputs are what reached the person this tick (operator speech, events).
+	Inputs []string
+}
+
+// ThoughtSeed is a weighted candidate for the next spontaneous thought.
+type ThoughtSeed struct {
+	Thought Thought
+	Weight  float64
+}
+
+// Absorption describes the ongoing thought chain. Level rises with every thought
+// that continues the chain and makes the chain both more likely to continue and
+// harder to interrupt.
+type Absorption struct {
+	Category ThoughtCategory
+	Drive    motivation.Drive
+	Depth    int
+	Level    float64
+}
+
+// Absorbed reports whether a chain is in progress.
+func (a Absorption) Absorbed() bool {
+	return a.Depth > 0
+}
+
+// SeederConfig tunes a ThoughtSeeder. Zero fields use defaults.
+type SeederConfig struct {
+	Schedule TickSchedule
+	// Seed makes selection reproducible.
+	Seed int64
+	// AbsorptionGain is the Level added per continued thought (default 0.25).
+	AbsorptionGain float64
+	// AbsorptionPull scales a chain seed's weight by 1 + Pull*Level (default 3).
+	AbsorptionPull float64
+}
+
+type recentInput struct {
+	text string
+	tick int
+}
+
+// ThoughtSeeder draws spontaneous thoughts from continuity, memories, recent
+// inputs, unresolved blocked actions and drives with seeded weighted randomness.
+// Contract: the same config and the same sequence of calls yield the same thoughts.
+type ThoughtSeeder struct {
+	cfg        SeederConfig
+	rng        *rand.Rand
+	absorption Absorption
+	inputs     []recentInput
+	// blocked maps unresolved actions to how often they were blocked.
+	blocked      map[string]int
+	blockedOrder []string
+}
+
+// NewThoughtSeeder creates a seeder.
+func NewThoughtSeeder(cfg SeederConfig) *ThoughtSeeder {
+	if cfg.AbsorptionGain <= 0 {
+		cfg.AbsorptionGain = 0.25
+	}
+	if cfg.AbsorptionPull <= 0 {
+		cfg.AbsorptionPull = 3
+	}
+	return &ThoughtSeeder{
+		cfg:     cfg,
+		rng:     rand.New(rand.NewSource(cfg.Seed)),
+		blocked: make(map[string]int),
+	}
+}
+
+// Absorption returns the current thought chain.
+func (s *ThoughtSeeder) Absorption() Absorption {
+	return s.absorption
+}
+
+// NoteOutcome tracks unresolved actions: blocked or cooling-down actions become
+// seeds until the same action executes.
+func (s *ThoughtSeeder) NoteOutcome(outcome ActionOutcome) {
+	action := strings.TrimSpace(outcome.Action)
+	if action == "" {
+		return
+	}
+	if outcome.Executed {
+		if _, ok := s.blocked[action]; ok {
+			delete(s.blocked, action)
+			for i, a := range s.blockedOrder {
+				if a == action {
+					s.blockedOrder = append(s.blockedOrder[:i], s.blockedOrder[i+1:]...)
+					break
+				}
+			}
+		}
+		return
+	}
+	if outcome.Reason != OutcomeBlocked && outcome.Reason != OutcomeCooldown {
+		return
+	}
+	if _, ok := s.blocked[action]; !ok {
+		s.blockedOrder = append(s.blockedOrder, action)
+	}
+	s.blocked[action]++
+}
+
+// Interrupt breaks the current chain unless absorption outweighs strength (0..1).
+// Full strength always breaks it. A resisted interruption still costs the chain
+// some absorption. It reports whether the chain was broken.
+func (s *ThoughtSeeder) Interrupt(strength float64) bool {
+	if !s.absorption.Absorbed() {
+		return false
+	}
+	strength = clamp01(strength)
+	if strength >= 1 || strength > s.absorption.Level {
+		s.absorption = Absorption{}
+		return true
+	}
+	s.absorption.Level = max0(s.absorption.Level - strength*0.5)
+	return false
+}
+
+// Next remembers this tick's inputs and, when the schedule is due, draws one thought.
+func (s *ThoughtSeeder) Next(src SeedSources, tick int) (Thought, bool) {
+	for _, text := range src.Inputs {
+		if text = strings.TrimSpace(text); text != "" {
+			s.inputs = append(s.inputs, recentInput{text: text, tick: tick})
+		}
+	}
+	if len(s.inputs) > maxRecentInputs {
+		s.inputs = s.inputs[len(s.inputs)-maxRecentInputs:]
+	}
+	if !s.cfg.Schedule.due(tick) {
+		return Thought{}, false
+	}
+
+	seeds := s.Candidates(src, tick)
+	seed := s.pick(seeds)
+	s.advance(seed.Thought)
+	return seed.Thought, true
+}
+
+// Candidates lists this tick's weighted seeds in a stable order, with absorption
+// applied. It never returns an empty list: associative drift is always possible.
+func (s *ThoughtSeeder) Candidates(src SeedSources, tick int) []ThoughtSeed {
+	var seeds []ThoughtSeed
+	add := func(t Thought, w float64) {
+		if w <= 0 {
+			return
+		}
+		if s.continues(t) {
+			w *= 1 + s.cfg.AbsorptionPull*s.absorption.Level
+		}
+		seeds = append(seeds, ThoughtSeed{Thought: t, Weight: w})
+	}
+
+	for _, d := range rankedDrives(src.Motivation) {
+		add(Thought{Category: ThoughtCategoryDrive, Drive: d.drive, Text: driveThoughtText(d.drive)}, d.urgency)
+	}
+	if n := len(src.Continuity); n > 0 {
+		last := src.Continuity[n-1]
+		if text := firstSentence(last.Text); text != "" {
+			add(Thought{
+				Category: ThoughtCategoryContinuity,
+				Drive:    last.Drive,
+				Text:     fmt.Sprintf("My mind goes back to it: %s", text),
+			}, seedWeightContinuity)
+		}
+	}
+	for _, line := range src.Memories {
+		if line = strings.TrimSpace(line); line != "" {
+			add(Thought{Category: ThoughtCategoryMemory, Text: line}, seedWeightMemory)
+		}
+	}
+	for _, in := range s.inputs {
+		// Inputs fade: half weight per tick since they arrived.
+		age := max(tick-in.tick, 0)
+		weight := seedWeightInput / float64(int(1)<<min(age, 16))
+		add(Thought{Category: ThoughtCategoryInput, Text: fmt.Sprintf("“%s” keeps echoing.", in.text)}, weight)
+	}
+	for _, action := range s.blockedOrder {
+		weight := seedWeightBlocked * (1 + 0.2*float64(min(s.blocked[action]-1, 5)))
+		add(Thought{
+			Category: ThoughtCategoryBlocked,
+			Text:     fmt.Sprintf("I still haven't managed to %s.", strings.ReplaceAll(action, "_", " ")),
+		}, weight)
+	}
+	add(Thought{Category: ThoughtCategoryAssociativeDrift, Text: "A loose associative thread drifts into awareness."}, seedWeightDrift)
+	return seeds
+}
+
+func (s *ThoughtSeeder) pick(seeds []ThoughtSeed) ThoughtSeed {
+	total := 0.0
+	for _, seed := range seeds {
+		total += seed.Weight
+	}
+	r := s.rng.Float64() * total
+	for _, seed := range seeds {
+		if r < seed.Weight {
+			return seed
+		}
+		r -= seed.Weight
+	}
+	return seeds[len(seeds)-1]
+}
+
+// continues reports whether t carries the current chain forward. Returning to
+// the last thought always does; otherwise category and drive must match.
+func (s *ThoughtSeeder) continues(t Thought) bool {
+	if !s.absorption.Absorbed() {
+		return false
+	}
+	if t.Category == ThoughtCategoryContinuity {
+		return true
+	}
+	return t.Category == s.absorption.Category && t.Drive == s.absorption.Drive
+}
+
+func (s *ThoughtSeeder) advance(t Thought) {
+	if s.continues(t) {
+		s.absorption.Depth++
+		s.absorption.Level = clamp01(s.absorption.Level + s.cfg.AbsorptionGain)
+		return
+	}
+	s.absorption = Absorption{Category: t.Category, Drive: t.Drive, Depth: 1, Level: s.cfg.AbsorptionGain}
+}
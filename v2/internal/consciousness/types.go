@@ -1,6 +1,11 @@
 package consciousness
 
-import "github.com/marczahn/person/v2/internal/motivation"
+import (
+	"sync"
+	"time"
+
+	"github.com/marczahn/person/v2/internal/motivation"
+)
 
 type PromptDrive struct {
 	Drive motivation.Drive
@@ -12,6 +17,15 @@ type PromptContext struct {
 	Background       []PromptDrive
 	GoalPull         string
 	ContinuityBuffer []string
+	Conflicts        []string
+	Activity         string
+	// Memories are recalled episodes rendered as felt recollections.
+	Memories []string
+	// Surroundings describes the ambient environment; empty when unremarkable.
+	Surroundings string
+	// Heard tags how each line of operator speech was taken, e.g.
+	// "[HEARD: greeting; tone=warm; to you]", in the order it was said.
+	Heard []string
 }
 
 type ThoughtCategory string
@@ -25,15 +39,47 @@ type Thought struct {
 	Category ThoughtCategory
 	Drive    motivation.Drive
 	Text     string
+	// Affect is the reported state while the thought was had; zero when unknown.
+	Affect ParsedState
 }
 
 type TickSchedule struct {
 	EveryTicks int
 }
 
+// ContinuityBuffer is a two-tier store of recent mental life: the latest thoughts
+// verbatim, and older spans compressed into gists that keep their emotional residue.
 type ContinuityBuffer struct {
-	capacity int
-	thoughts []Thought
+	capacity    int
+	thoughts    []Thought
+	evicted     []Thought
+	gists       []Gist
+	spanSize    int
+	maxGists    int
+	tokenBudget int
+	summarizer  Summarizer
+	// provisional caches the gist of evicted; nil when evicted changed since.
+	provisional *Gist
+
+	// Model rewrites run off the tick and land in rewrites, keyed by gist id.
+	model        ModelSummarizer
+	modelTimeout time.Duration
+	nextID       int
+	mu           sync.Mutex
+	rewrites     map[int]string
+	running      sync.WaitGroup
+}
+
+// Gist is a compressed span of older thoughts.
+type Gist struct {
+	Text string
+	// Thoughts is how many original thoughts the gist stands for.
+	Thoughts int
+	// Residue is the mean affect left behind by the span.
+	Residue ParsedState
+
+	// id keys a pending model rewrite; 0 for a provisional gist.
+	id int
 }
 
 type ParsedState struct {
@@ -46,14 +92,59 @@ type ParsedResponse struct {
 	Action         string
 	DriveOverrides map[motivation.Drive]float64
 	Narrative      string
+	Speech         string
+	// Status and Reason describe how this turn's output was used.
+	Status ParseStatus
+	Reason ParseFailure
 }
 
+// ParseStatus tells whether a parsed response came from fresh mind output.
+type ParseStatus string
+
+const (
+	ParseStatusOK ParseStatus = "ok"
+	// ParseStatusFailed: output was unusable and prior State/Action were reused.
+	ParseStatusFailed ParseStatus = "failed"
+	// ParseStatusRecovered: a corrective retry produced usable output.
+	ParseStatusRecovered ParseStatus = "recovered"
+	// ParseStatusNoop: output was unusable and the turn fell back to doing nothing.
+	ParseStatusNoop ParseStatus = "noop"
+)
+
+// ParseFailure names why mind output could not be used. Empty when parsing succeeded.
+type ParseFailure string
+
+const (
+	ParseMissingState     ParseFailure = "missing_state"
+	ParseMalformedState   ParseFailure = "malformed_state"
+	ParseMissingAction    ParseFailure = "missing_action"
+	ParseMalformedAction  ParseFailure = "malformed_action"
+	ParseMalformedDrive   ParseFailure = "malformed_drive"
+	ParseInvalidJSON      ParseFailure = "invalid_json"
+	ParseMissingNarrative ParseFailure = "missing_narrative"
+)
+
 type ActionOutcome struct {
 	Action    string
 	Executed  bool
 	Satisfied bool
+	Reason    OutcomeReason
 }
 
+// OutcomeReason explains why an action did not execute. Empty on success.
+type OutcomeReason string
+
+const (
+	OutcomeBlocked  OutcomeReason = "blocked"
+	OutcomeCooldown OutcomeReason = "cooldown"
+	OutcomeBusy     OutcomeReason = "busy"
+	OutcomeQueued   OutcomeReason = "queued"
+	OutcomeOngoing  OutcomeReason = "ongoing"
+	OutcomeIdle     OutcomeReason = "idle"
+	// OutcomeStale: an asynchronous reply arrived too late for its action to still make sense.
+	OutcomeStale OutcomeReason = "stale"
+)
+
 type ActionCooldowns map[string]int64
 
 type ActionCooldownState map[string]int64
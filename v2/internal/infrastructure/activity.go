@@ -0,0 +1,136 @@
+package infrastructure
+
+import (
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/consciousness"
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// ActivityInterruptRules decides which stimuli break off an ongoing activity.
+type ActivityInterruptRules struct {
+	// PulseMagnitude is the summed aversive input pulse amount that interrupts:
+	// rises in stress or physical tension and drops in mood. Comfort never does.
+	PulseMagnitude float64
+	// Severity is the lowest threshold-event severity that interrupts.
+	Severity biology.Severity
+}
+
+// DefaultActivityInterruptRules interrupts on a punch-sized input or any warning-level threshold.
+func DefaultActivityInterruptRules() ActivityInterruptRules {
+	return ActivityInterruptRules{
+		PulseMagnitude: 0.15,
+		Severity:       biology.Warning,
+	}
+}
+
+// Interruption records why an activity was broken off this tick.
+type Interruption struct {
+	Activity consciousness.Activity
+	Reason   string
+}
+
+func (r ActivityInterruptRules) reason(pulses []biology.BioPulse, bioResult biology.TickResult) (string, bool) {
+	if r.PulseMagnitude > 0 && aversiveMagnitude(pulses) >= r.PulseMagnitude {
+		return "strong stimulus", true
+	}
+	for _, event := range bioResult.Thresholds {
+		if event.Severity >= r.Severity {
+			return event.Variable + ": " + event.Description, true
+		}
+	}
+	return "", false
+}
+
+// aversiveMagnitude sums the part of pulses that hurts.
+func aversiveMagnitude(pulses []biology.BioPulse) float64 {
+	total := 0.0
+	for _, p := range pulses {
+		switch p.Field {
+		case "stress", "physical_tension":
+			total += max(p.Amount, 0)
+		case "mood":
+			total += max(-p.Amount, 0)
+		}
+	}
+	return total
+}
+
+// resolveAction gates and cooldown-checks an action for a free (unoccupied) person.
+func (l *SimulationLoop) resolveAction(
+	action string,
+	world WorldState,
+	nowSeconds int64,
+	cooldownState consciousness.ActionCooldownState,
+) (consciousness.ActionOutcome, consciousness.ActionCooldownState) {
+	return consciousness.ResolveActionOutcomeWithCooldown(
+		action,
+		world.AllowedActions()[action],
+		nowSeconds,
+		l.cooldowns,
+		cooldownState,
+	)
+}
+
+// performAction starts a timed activity for an executed outcome or, for instant
+// actions, adds its one-shot pulse to the tick feedback.
+func (l *SimulationLoop) performAction(state *SimulationState, outcome consciousness.ActionOutcome, feedback *biology.TickFeedbackBuffer) {
+	if activity, ok := consciousness.StartActivity(outcome, l.activities); ok {
+		state.Activity = activity
+		return
+	}
+	if l.deferSocialRelief && outcome.Action == string(motivation.ActionReachOut) {
+		return
+	}
+	feedback.AddPulses(consciousness.ActionPulse(outcome))
+}
+
+// chooseAction resolves the mind's chosen action against the current activity,
+// the world and cooldowns, performing it when it executes.
+func (l *SimulationLoop) chooseAction(
+	state *SimulationState,
+	action string,
+	world WorldState,
+	nowSeconds int64,
+	feedback *biology.TickFeedbackBuffer,
+) (consciousness.ActionOutcome, consciousness.ActionCooldownState) {
+	switch {
+	case action == "":
+		return consciousness.ActionOutcome{Reason: consciousness.OutcomeIdle}, state.CooldownState
+	case state.Activity.Active():
+		outcome := consciousness.ResolveOccupiedAction(action, state.Activity, l.occupancy)
+		if outcome.Reason == consciousness.OutcomeQueued {
+			state.PendingAction = outcome.Action
+		}
+		return outcome, state.CooldownState
+	default:
+		outcome, cooldowns := l.resolveAction(action, world, nowSeconds, state.CooldownState)
+		l.performAction(state, outcome, feedback)
+		return outcome, cooldowns
+	}
+}
+
+// changeWorld applies executed outcomes to the world model. A move also takes
+// the person into the new location's ambient conditions, which hold until changed.
+func (l *SimulationLoop) changeWorld(state *SimulationState, goal motivation.Drive, outcomes ...*consciousness.ActionOutcome) []WorldEffect {
+	if state.Places == nil {
+		return nil
+	}
+	var effects []WorldEffect
+	for _, outcome := range outcomes {
+		if outcome == nil || !outcome.Executed {
+			continue
+		}
+		effect, ok := state.Places.Perform(outcome.Action, goal)
+		if !ok {
+			continue
+		}
+		effects = append(effects, effect)
+		if effect.To != "" {
+			*state.World = state.Environment.Apply(EnvironmentChange{Ambient: state.Places.Here().Ambient}, *state.World)
+		}
+	}
+	if len(effects) > 0 {
+		*state.World = state.Places.State()
+	}
+	return effects
+}
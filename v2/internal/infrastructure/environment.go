@@ -0,0 +1,398 @@
+package infrastructure
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/sense"
+)
+
+// Neutral ambient levels. No rate is derived from a level at its neutral value.
+const (
+	NeutralTemperatureC = 20.0
+	NeutralNoise        = 0.2
+	NeutralLight        = 0.6
+	NeutralCrowding     = 0.0
+)
+
+// Body temperature drifts toward an equilibrium set by the ambient
+// temperature: 36.6°C at neutral, 0.125°C further per degree away from it,
+// closing 2% of the gap per second. Freezing settles at 34.1°C, sweltering
+// (35°C) just under fever.
+const (
+	BaselineBodyTempC    = 36.6
+	bodyTempPerAmbientC  = 0.125
+	bodyTempApproachPerS = 0.02
+)
+
+// Environment is the persistent ambient state around the person. Levels hold
+// until changed or until a timed change expires, and every tick derives
+// biology rates from them.
+type Environment struct {
+	TemperatureC float64
+	// Noise is 0 (silent) to 1 (deafening); 0.2 is ordinary background.
+	Noise float64
+	// Light is 0 (pitch dark) to 1 (glaring); 0.6 is daylight.
+	Light float64
+	// Crowding is 0 (nobody) to 1 (packed).
+	Crowding float64
+	// Elapsed is simulated seconds since the environment started.
+	Elapsed float64
+	// Pending are timed changes waiting to revert, in the order they were made.
+	Pending []EnvironmentRevert
+}
+
+// DefaultEnvironment returns a neutral environment.
+func DefaultEnvironment() Environment {
+	return Environment{
+		TemperatureC: NeutralTemperatureC,
+		Noise:        NeutralNoise,
+		Light:        NeutralLight,
+		Crowding:     NeutralCrowding,
+	}
+}
+
+// AmbientPatch changes ambient levels. Nil fields leave the level unchanged.
+type AmbientPatch struct {
+	TemperatureC *float64
+	Noise        *float64
+	Light        *float64
+	Crowding     *float64
+}
+
+// Empty reports whether the patch changes nothing.
+func (p AmbientPatch) Empty() bool {
+	return p.TemperatureC == nil && p.Noise == nil && p.Light == nil && p.Crowding == nil
+}
+
+// EnvironmentChange is one drained environment description.
+type EnvironmentChange struct {
+	Ambient AmbientPatch
+	// World holds resource changes that only last For; untimed resource changes
+	// travel in TickInput.World.
+	World WorldPatch
+	// For is how many simulated seconds the change holds before the prior
+	// levels and resources return. Zero persists until changed.
+	For float64
+}
+
+// Reset returns a change that puts every level and resource c touches back to
+// its default, for good.
+func (c EnvironmentChange) Reset() EnvironmentChange {
+	neutral := DefaultEnvironment()
+	levels := neutral.levels()
+	var reset EnvironmentChange
+	out := reset.Ambient.fields()
+	for i, f := range c.Ambient.fields() {
+		if *f != nil {
+			*out[i] = floatPtr(*levels[i])
+		}
+	}
+	reset.World = resetWorldPatch(c.World)
+	return reset
+}
+
+// resetWorldPatch returns a patch restoring the default of every resource p sets.
+func resetWorldPatch(p WorldPatch) WorldPatch {
+	defaults := DefaultWorldState()
+	values := defaults.fields()
+	var reset WorldPatch
+	out := reset.fields()
+	for i, f := range p.fields() {
+		if *f != nil {
+			*out[i] = boolPtr(*values[i])
+		}
+	}
+	return reset
+}
+
+// EnvironmentRevert restores prior values when a timed change expires.
+type EnvironmentRevert struct {
+	At      float64
+	Ambient AmbientPatch
+	World   WorldPatch
+}
+
+// Advance moves the environment clock by dt and reverts expired timed changes.
+// It returns the world resources to restore.
+func (e *Environment) Advance(dt float64) WorldPatch {
+	e.Elapsed += dt
+	var restore WorldPatch
+	var keep []EnvironmentRevert
+	for _, r := range e.Pending {
+		if r.At > e.Elapsed {
+			keep = append(keep, r)
+			continue
+		}
+		e.applyAmbient(r.Ambient)
+		restore = mergeWorldPatch(restore, r.World)
+	}
+	e.Pending = keep
+	return restore
+}
+
+// Apply applies c and returns the changed world. A newer change to a level or
+// resource supersedes any pending revert of it: an untimed change is never
+// undone by an earlier timed one, and a timed change on top of another reverts
+// to the value before both.
+func (e *Environment) Apply(c EnvironmentChange, world WorldState) WorldState {
+	var revert EnvironmentRevert
+	if c.For > 0 {
+		revert = EnvironmentRevert{
+			At:      e.Elapsed + c.For,
+			Ambient: e.priorAmbient(c.Ambient),
+			World:   e.priorWorld(c.World, world),
+		}
+	}
+	e.Supersede(c.Ambient, c.World)
+	if c.For > 0 {
+		e.Pending = append(e.Pending, revert)
+	}
+	e.applyAmbient(c.Ambient)
+	return c.World.Apply(world)
+}
+
+// Supersede drops pending reverts of the given levels and resources.
+func (e *Environment) Supersede(ambient AmbientPatch, world WorldPatch) {
+	var keep []EnvironmentRevert
+	for _, r := range e.Pending {
+		r.Ambient = dropAmbient(r.Ambient, ambient)
+		r.World = dropWorld(r.World, world)
+		if !r.Ambient.Empty() || !r.World.Empty() {
+			keep = append(keep, r)
+		}
+	}
+	e.Pending = keep
+}
+
+// AmbientStimulus is the biology rates one ambient level drives, by the cue
+// that names it.
+type AmbientStimulus struct {
+	Cue   sense.Cue
+	Rates []biology.BioRate
+}
+
+// BodyTempEquilibrium is the body temperature the ambient temperature
+// settles the person at.
+func (e Environment) BodyTempEquilibrium() float64 {
+	return BaselineBodyTempC + bodyTempPerAmbientC*(e.TemperatureC-NeutralTemperatureC)
+}
+
+// Stimuli derives the biology rates the ambient levels drive over a tick of
+// dt seconds, grouped by stimulus so exposure to each can be tracked.
+// bodyTempC is the person's current body temperature.
+func (e Environment) Stimuli(bodyTempC, dt float64) []AmbientStimulus {
+	var stimuli []AmbientStimulus
+	add := func(cue sense.Cue, rates ...biology.BioRate) {
+		var nonZero []biology.BioRate
+		for _, r := range rates {
+			if r.PerSecond != 0 {
+				nonZero = append(nonZero, r)
+			}
+		}
+		if len(nonZero) > 0 {
+			stimuli = append(stimuli, AmbientStimulus{Cue: cue, Rates: nonZero})
+		}
+	}
+	// Starting from baseline, 12°C away from neutral moves body temperature
+	// 0.03/s; the pull fades as it nears the equilibrium.
+	temperature := sense.CueHeat
+	if e.TemperatureC < NeutralTemperatureC {
+		temperature = sense.CueCold
+	}
+	add(temperature, biology.BioRate{Field: "body_temp", PerSecond: approachRate(e.BodyTempEquilibrium()-bodyTempC, dt)})
+	// Loud (0.5) adds 0.03/s stress; silence takes 0.02/s away.
+	noise := sense.CueNoise
+	if e.Noise < NeutralNoise {
+		noise = sense.CueCalm
+	}
+	add(noise, biology.BioRate{Field: "stress", PerSecond: 0.1 * (e.Noise - NeutralNoise)})
+	if e.Light < 0.3 {
+		add(sense.CueDark, biology.BioRate{Field: "stress", PerSecond: 0.02 * (0.3 - e.Light)})
+	}
+	if e.Crowding > 0.3 {
+		add(sense.CueCrowd,
+			biology.BioRate{Field: "stress", PerSecond: 0.04 * (e.Crowding - 0.3)},
+			biology.BioRate{Field: "physical_tension", PerSecond: 0.02 * (e.Crowding - 0.3)})
+	}
+	return stimuli
+}
+
+// approachRate is the rate that closes the fraction of gap a tick of dt
+// seconds closes, exactly, so long ticks land on the equilibrium instead of
+// overshooting it.
+func approachRate(gap, dt float64) float64 {
+	if dt <= 0 {
+		return bodyTempApproachPerS * gap
+	}
+	return gap * -math.Expm1(-bodyTempApproachPerS*dt) / dt
+}
+
+// Rates derives a tick's biology rates from the ambient levels, unadapted.
+func (e Environment) Rates(bodyTempC, dt float64) []biology.BioRate {
+	var rates []biology.BioRate
+	for _, s := range e.Stimuli(bodyTempC, dt) {
+		rates = append(rates, s.Rates...)
+	}
+	return rates
+}
+
+// Line renders the environment for the mind; empty when nothing stands out.
+func (e Environment) Line() string {
+	var parts []string
+	switch t := e.TemperatureC; {
+	case t <= 5:
+		parts = append(parts, "freezing")
+	case t <= 13:
+		parts = append(parts, "cold")
+	case t < 17:
+		parts = append(parts, "cool")
+	case t >= 35:
+		parts = append(parts, "sweltering")
+	case t >= 27:
+		parts = append(parts, "hot")
+	case t > 23:
+		parts = append(parts, "warm")
+	}
+	switch n := e.Noise; {
+	case n >= 0.65:
+		parts = append(parts, "deafeningly loud")
+	case n >= 0.4:
+		parts = append(parts, "loud")
+	case n <= 0.05:
+		parts = append(parts, "silent")
+	case n < 0.15:
+		parts = append(parts, "quiet")
+	}
+	switch l := e.Light; {
+	case l <= 0.1:
+		parts = append(parts, "pitch dark")
+	case l < 0.3:
+		parts = append(parts, "dim")
+	case l >= 0.9:
+		parts = append(parts, "glaring")
+	}
+	switch c := e.Crowding; {
+	case c >= 0.7:
+		parts = append(parts, "packed with people")
+	case c >= 0.4:
+		parts = append(parts, "crowded")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Around you it is %s.", joinWithAnd(parts))
+}
+
+func joinWithAnd(parts []string) string {
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
+
+// The field helpers below list levels and patch fields in the same order, so
+// patches can be applied, captured and masked field by field.
+
+func (e *Environment) levels() [4]*float64 {
+	return [4]*float64{&e.TemperatureC, &e.Noise, &e.Light, &e.Crowding}
+}
+
+func (p *AmbientPatch) fields() [4]**float64 {
+	return [4]**float64{&p.TemperatureC, &p.Noise, &p.Light, &p.Crowding}
+}
+
+func (w *WorldState) fields() [5]*bool {
+	return [5]*bool{&w.Food, &w.Water, &w.QuietSpace, &w.PeopleNearby, &w.Explorable}
+}
+
+func (p *WorldPatch) fields() [5]**bool {
+	return [5]**bool{&p.Food, &p.Water, &p.QuietSpace, &p.PeopleNearby, &p.Explorable}
+}
+
+func (e *Environment) applyAmbient(p AmbientPatch) {
+	levels := e.levels()
+	for i, f := range p.fields() {
+		if *f != nil {
+			*levels[i] = **f
+		}
+	}
+}
+
+// priorAmbient captures the current value of every level p changes. A level
+// still awaiting a revert keeps that revert's value, so stacked timed changes
+// return to where the first one started.
+func (e *Environment) priorAmbient(p AmbientPatch) AmbientPatch {
+	var prior AmbientPatch
+	levels := e.levels()
+	out := prior.fields()
+	for i, f := range p.fields() {
+		if *f != nil {
+			*out[i] = floatPtr(*levels[i])
+		}
+	}
+	for _, r := range e.Pending {
+		for i, f := range r.Ambient.fields() {
+			if *f != nil && *out[i] != nil {
+				*out[i] = *f
+			}
+		}
+	}
+	return prior
+}
+
+func (e *Environment) priorWorld(p WorldPatch, w WorldState) WorldPatch {
+	var prior WorldPatch
+	values := w.fields()
+	out := prior.fields()
+	for i, f := range p.fields() {
+		if *f != nil {
+			*out[i] = boolPtr(*values[i])
+		}
+	}
+	for _, r := range e.Pending {
+		for i, f := range r.World.fields() {
+			if *f != nil && *out[i] != nil {
+				*out[i] = *f
+			}
+		}
+	}
+	return prior
+}
+
+func dropAmbient(p, changed AmbientPatch) AmbientPatch {
+	out := p.fields()
+	for i, f := range changed.fields() {
+		if *f != nil {
+			*out[i] = nil
+		}
+	}
+	return p
+}
+
+func dropWorld(p, changed WorldPatch) WorldPatch {
+	out := p.fields()
+	for i, f := range changed.fields() {
+		if *f != nil {
+			*out[i] = nil
+		}
+	}
+	return p
+}
+
+// mergeWorldPatch returns a with every field set in b overriding it.
+func mergeWorldPatch(a, b WorldPatch) WorldPatch {
+	out := a.fields()
+	for i, f := range b.fields() {
+		if *f != nil {
+			*out[i] = *f
+		}
+	}
+	return a
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
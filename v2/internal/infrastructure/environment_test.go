@@ -0,0 +1,150 @@
+package infrastructure_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/infrastructure"
+	"github.com/marczahn/person/v2/internal/sense"
+)
+
+func newEnvironmentLoop(adapter *infrastructure.InputAdapter) *infrastructure.SimulationLoop {
+	return infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      adapter,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: none] Hm."},
+	})
+}
+
+func TestEnvironment_ColdPersistsAcrossTicksAndReachesTheMind(t *testing.T) {
+	adapter := infrastructure.NewInputAdapter(sense.NewParser(), nil)
+	loop := newEnvironmentLoop(adapter)
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+
+	adapter.Enqueue("~freezing cold")
+	result := loop.Tick(&state, 1.0)
+	if !strings.Contains(result.Prompt.Surroundings, "freezing") {
+		t.Fatalf("expected the mind to be told it is freezing, got %q", result.Prompt.Surroundings)
+	}
+
+	before := state.Bio.BodyTemp
+	for range 10 {
+		result = loop.Tick(&state, 1.0)
+	}
+	if drop := before - state.Bio.BodyTemp; drop < 0.4 {
+		t.Fatalf("expected cold to keep cooling without re-injection, dropped %.3f", drop)
+	}
+	if result.Environment.TemperatureC > 5 {
+		t.Fatalf("expected ambient temperature to persist, got %+v", result.Environment)
+	}
+
+	adapter.Enqueue("~it is not cold anymore")
+	loop.Tick(&state, 1.0)
+	cooled := state.Bio.BodyTemp
+	loop.Tick(&state, 600)
+	if state.Environment.TemperatureC != infrastructure.NeutralTemperatureC ||
+		math.Abs(state.Bio.BodyTemp-infrastructure.BaselineBodyTempC) > 0.01 {
+		t.Fatalf("expected negation to return temperature to neutral and the body to baseline: ambient=%.1f body %.3f -> %.3f",
+			state.Environment.TemperatureC, cooled, state.Bio.BodyTemp)
+	}
+}
+
+func TestEnvironment_MildAmbientSettlesInsteadOfDrifting(t *testing.T) {
+	for _, tc := range []struct {
+		line     string
+		min, max float64
+	}{
+		{"~warm breeze", 37, 38.5},
+		{"~chilly", 35.5, 36.5},
+	} {
+		adapter := infrastructure.NewInputAdapter(sense.NewParser(), nil)
+		loop := newEnvironmentLoop(adapter)
+		state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+
+		adapter.Enqueue(tc.line)
+		loop.Tick(&state, 1)
+		for range 120 {
+			loop.Tick(&state, 60)
+		}
+		settled := state.Bio.BodyTemp
+		if settled < tc.min || settled > tc.max {
+			t.Fatalf("%s: body temperature should settle within [%v, %v] after two hours, got %.3f", tc.line, tc.min, tc.max, settled)
+		}
+		if want := state.Environment.BodyTempEquilibrium(); math.Abs(settled-want) > 0.01 {
+			t.Fatalf("%s: body temperature %.3f, want the equilibrium %.3f", tc.line, settled, want)
+		}
+		loop.Tick(&state, 3600)
+		if math.Abs(state.Bio.BodyTemp-settled) > 0.01 {
+			t.Fatalf("%s: body temperature kept drifting: %.3f -> %.3f", tc.line, settled, state.Bio.BodyTemp)
+		}
+	}
+}
+
+func TestEnvironment_TimedChangeExpiresOnTheSimulationClock(t *testing.T) {
+	adapter := infrastructure.NewInputAdapter(sense.NewParser(), nil)
+	loop := newEnvironmentLoop(adapter)
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+
+	// The clock advances at the start of a tick, so the change starts at t=5s.
+	adapter.Enqueue("~loud and no food for 10 seconds")
+	result := loop.Tick(&state, 5.0)
+	if result.Environment.Noise <= infrastructure.NeutralNoise || result.World.Food {
+		t.Fatalf("expected loud and no food while the change holds, got env=%+v world=%+v", result.Environment, result.World)
+	}
+	result = loop.Tick(&state, 9.0)
+	if result.World.Food {
+		t.Fatalf("change expired early at %.0fs", result.Environment.Elapsed)
+	}
+	result = loop.Tick(&state, 1.0)
+	if result.Environment.Noise != infrastructure.NeutralNoise || !result.World.Food {
+		t.Fatalf("expected noise and food to revert at t=15s, got env=%+v world=%+v", result.Environment, result.World)
+	}
+	if len(result.Environment.Pending) != 0 {
+		t.Fatalf("expected no pending reverts, got %+v", result.Environment.Pending)
+	}
+}
+
+func TestEnvironment_LaterChangesSupersedePendingReverts(t *testing.T) {
+	env := infrastructure.DefaultEnvironment()
+	world := infrastructure.DefaultWorldState()
+	cold, freezing, warm := 8.0, 0.0, 26.0
+
+	world = env.Apply(infrastructure.EnvironmentChange{Ambient: infrastructure.AmbientPatch{TemperatureC: &cold}, For: 10}, world)
+	env.Advance(2)
+	world = env.Apply(infrastructure.EnvironmentChange{Ambient: infrastructure.AmbientPatch{TemperatureC: &freezing}, For: 20}, world)
+	env.Advance(10)
+	if env.TemperatureC != freezing {
+		t.Fatalf("the newer timed change should outlast the older one, got %.1f", env.TemperatureC)
+	}
+	env.Advance(10)
+	if env.TemperatureC != infrastructure.NeutralTemperatureC {
+		t.Fatalf("stacked timed changes should revert to the value before both, got %.1f", env.TemperatureC)
+	}
+
+	world = env.Apply(infrastructure.EnvironmentChange{Ambient: infrastructure.AmbientPatch{TemperatureC: &cold}, For: 5}, world)
+	env.Apply(infrastructure.EnvironmentChange{Ambient: infrastructure.AmbientPatch{TemperatureC: &warm}}, world)
+	env.Advance(10)
+	if env.TemperatureC != warm {
+		t.Fatalf("an untimed change must not be undone by an earlier timed one, got %.1f", env.TemperatureC)
+	}
+}
+
+func TestEnvironment_RatesAndLine(t *testing.T) {
+	if rates := infrastructure.DefaultEnvironment().Rates(infrastructure.BaselineBodyTempC, 0); len(rates) != 0 {
+		t.Fatalf("neutral environment should derive no rates, got %+v", rates)
+	}
+	if line := infrastructure.DefaultEnvironment().Line(); line != "" {
+		t.Fatalf("neutral environment should not be described, got %q", line)
+	}
+
+	env := infrastructure.Environment{TemperatureC: 8, Noise: 0.5, Light: 0.05, Crowding: 0.6}
+	if !containsRate(env.Rates(infrastructure.BaselineBodyTempC, 0), "body_temp", -0.03) || !containsRate(env.Rates(infrastructure.BaselineBodyTempC, 0), "stress", 0.03) {
+		t.Fatalf("unexpected rates %+v", env.Rates(infrastructure.BaselineBodyTempC, 0))
+	}
+	if got, want := env.Line(), "Around you it is cold, loud, pitch dark and crowded."; got != want {
+		t.Fatalf("line = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,248 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/motivation"
+	"github.com/marczahn/person/v2/internal/sense"
+)
+
+// Adaptation is the direction repeated exposure moves the response to a stimulus.
+type Adaptation string
+
+const (
+	// Habituates weakens the response with exposure: constant noise fades.
+	Habituates Adaptation = "habituate"
+	// Sensitizes strengthens it: each blow lands harder than the last.
+	Sensitizes Adaptation = "sensitize"
+)
+
+// StimulusAdaptation is how one stimulus category adapts.
+type StimulusAdaptation struct {
+	Adaptation Adaptation
+	// Step is the share of the distance to Limit one exposure covers at a
+	// personality factor of 1. A pulse is one exposure; an ambient level counts
+	// one exposure per minute.
+	Step float64
+	// Limit is where repeated exposure takes the sensitivity: the floor when
+	// habituating, the ceiling when sensitizing.
+	Limit float64
+	// RecoveryHalfLife is the seconds without exposure for the sensitivity to
+	// return half way to 1.
+	RecoveryHalfLife float64
+}
+
+// ambientExposurePeriod is how many seconds of an ambient level count as one exposure.
+const ambientExposurePeriod = 60.0
+
+// DefaultStimulusAdaptations covers the cues whose effects adapt. Feeding and
+// temperature are physiology rather than perception and never adapt.
+func DefaultStimulusAdaptations() map[sense.Cue]StimulusAdaptation {
+	return map[sense.Cue]StimulusAdaptation{
+		sense.CueNoise:    {Habituates, 0.15, 0.3, 1800},
+		sense.CueDark:     {Habituates, 0.10, 0.4, 1800},
+		sense.CueCrowd:    {Habituates, 0.10, 0.4, 1800},
+		sense.CueComfort:  {Habituates, 0.20, 0.4, 1800},
+		sense.CueGreeting: {Habituates, 0.30, 0.2, 1800},
+		sense.CueQuestion: {Habituates, 0.20, 0.3, 1800},
+		sense.CuePraise:   {Habituates, 0.20, 0.3, 3600},
+		sense.CueWarmth:   {Habituates, 0.20, 0.4, 1800},
+
+		sense.CueViolence:  {Sensitizes, 0.25, 2.0, 7200},
+		sense.CueThreat:    {Sensitizes, 0.20, 1.8, 7200},
+		sense.CueInsult:    {Sensitizes, 0.15, 1.6, 3600},
+		sense.CueHostility: {Sensitizes, 0.10, 1.4, 3600},
+	}
+}
+
+// Habituation is the person's adapted sensitivity per stimulus category: 1 is
+// the unadapted response, below 1 habituated, above 1 sensitized.
+// Contract: it holds plain data, so it round-trips through JSON unchanged.
+type Habituation struct {
+	// Sensitivity holds every category that has moved from 1.
+	Sensitivity map[sense.Cue]float64 `json:"sensitivity"`
+}
+
+// NewHabituation returns an unadapted habituation memory.
+func NewHabituation() *Habituation {
+	return &Habituation{Sensitivity: make(map[sense.Cue]float64)}
+}
+
+// Level returns the sensitivity to a category, 1 when it has not adapted.
+func (h *Habituation) Level(cue sense.Cue) float64 {
+	if v, ok := h.Sensitivity[cue]; ok {
+		return v
+	}
+	return 1
+}
+
+// Expose moves a category's sensitivity toward its limit by the given number
+// of exposures. Curious people habituate faster; stress-sensitive people
+// sensitize faster.
+func (h *Habituation) Expose(cue sense.Cue, a StimulusAdaptation, exposures float64, p motivation.Personality) {
+	factor := 0.5 + p.Curiosity
+	if a.Adaptation == Sensitizes {
+		factor = 0.5 + p.StressSensitivity
+	}
+	step := min(max(a.Step*factor, 0), 1)
+	h.set(cue, a.Limit+(h.Level(cue)-a.Limit)*math.Pow(1-step, exposures))
+}
+
+// Recover moves a category's sensitivity back toward 1 over dt seconds.
+func (h *Habituation) Recover(cue sense.Cue, a StimulusAdaptation, dt float64) {
+	level := h.Level(cue)
+	if level == 1 || a.RecoveryHalfLife <= 0 {
+		return
+	}
+	h.set(cue, 1+(level-1)*math.Pow(0.5, dt/a.RecoveryHalfLife))
+}
+
+func (h *Habituation) set(cue sense.Cue, v float64) {
+	if h.Sensitivity == nil {
+		h.Sensitivity = make(map[sense.Cue]float64)
+	}
+	if math.Abs(v-1) < 1e-6 {
+		delete(h.Sensitivity, cue)
+		return
+	}
+	h.Sensitivity[cue] = v
+}
+
+// LoadHabituation reads a habituation memory saved with Save. It returns an
+// unadapted one and no error when the file does not exist yet.
+func LoadHabituation(path string) (*Habituation, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewHabituation(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading habituation file: %w", err)
+	}
+	h := NewHabituation()
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("decoding habituation file: %w", err)
+	}
+	return h, nil
+}
+
+// Save writes the habituation memory to path as JSON. It writes a temporary
+// file next to path and renames it over path, so a crash mid-write leaves the
+// previous memory intact.
+func (h *Habituation) Save(path string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding habituation: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing habituation file: %w", err)
+	}
+	_, err = tmp.Write(append(data, '\n'))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing habituation file: %w", err)
+	}
+	return nil
+}
+
+// HabituationStore persists a habituation memory across runs.
+type HabituationStore interface {
+	// Load returns the stored memory, an unadapted one when nothing is stored.
+	Load() (*Habituation, error)
+	Save(h *Habituation) error
+}
+
+// HabituationFile stores habituation as JSON at a path (see LoadHabituation).
+type HabituationFile string
+
+func (f HabituationFile) Load() (*Habituation, error) {
+	return LoadHabituation(string(f))
+}
+
+func (f HabituationFile) Save(h *Habituation) error {
+	return h.Save(string(f))
+}
+
+// loadHabituation gives state its habituation memory: the stored one on the
+// first tick when a store is configured, an unadapted one otherwise. A failed
+// load keeps Close from saving over the stored memory.
+func (l *SimulationLoop) loadHabituation(state *SimulationState) error {
+	var err error
+	if state.Habituation == nil && l.habituations != nil {
+		state.Habituation, err = l.habituations.Load()
+		if err != nil {
+			l.habituationUnloaded = true
+		}
+	}
+	if state.Habituation == nil {
+		state.Habituation = NewHabituation()
+	}
+	l.habituation = state.Habituation
+	return err
+}
+
+// StimulusResponse is how strongly the person responded to a stimulus this tick.
+type StimulusResponse struct {
+	Cue sense.Cue
+	// Sensitivity is the scale applied, before this tick's exposure adapted it.
+	Sensitivity float64
+}
+
+// adaptStimuli scales this tick's input stimuli and ambient rates by the
+// person's sensitivity, then adapts: exposed categories move toward their
+// limit, the others recover toward 1.
+func (l *SimulationLoop) adaptStimuli(
+	state *SimulationState,
+	stimuli []Stimulus,
+	ambient []AmbientStimulus,
+	dt float64,
+) ([]biology.BioPulse, []biology.BioRate, []StimulusResponse) {
+	h := state.Habituation
+	var pulses []biology.BioPulse
+	var rates []biology.BioRate
+	var responses []StimulusResponse
+	exposures := make(map[sense.Cue]float64)
+
+	for _, s := range stimuli {
+		scale := h.Level(s.Cue)
+		for _, p := range s.Pulses {
+			pulses = append(pulses, biology.BioPulse{Field: p.Field, Amount: p.Amount * scale})
+		}
+		if _, ok := l.adaptations[s.Cue]; ok {
+			responses = append(responses, StimulusResponse{Cue: s.Cue, Sensitivity: scale})
+			exposures[s.Cue]++
+		}
+	}
+	for _, s := range ambient {
+		scale := h.Level(s.Cue)
+		for _, r := range s.Rates {
+			rates = append(rates, biology.BioRate{Field: r.Field, PerSecond: r.PerSecond * scale})
+		}
+		if _, ok := l.adaptations[s.Cue]; ok {
+			responses = append(responses, StimulusResponse{Cue: s.Cue, Sensitivity: scale})
+			exposures[s.Cue] += dt / ambientExposurePeriod
+		}
+	}
+
+	for cue, a := range l.adaptations {
+		if n, ok := exposures[cue]; ok {
+			h.Expose(cue, a, n, state.Personality)
+		} else {
+			h.Recover(cue, a, dt)
+		}
+	}
+	return pulses, rates, responses
+}
@@ -0,0 +1,222 @@
+package infrastructure_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/infrastructure"
+	"github.com/marczahn/person/v2/internal/motivation"
+	"github.com/marczahn/person/v2/internal/sense"
+)
+
+func newHabituationLoop(drainer *fakeInputDrainer, adaptations map[sense.Cue]infrastructure.StimulusAdaptation) *infrastructure.SimulationLoop {
+	return infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:       drainer,
+		Biology:     &fakeBioEngine{},
+		Motivation:  &fakeMotivationComputer{},
+		Mind:        &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: none] Hm."},
+		Adaptations: adaptations,
+	})
+}
+
+// stressPerTick ticks n times from the same starting stress and returns how
+// much each tick raised it.
+func stressPerTick(loop *infrastructure.SimulationLoop, state *infrastructure.SimulationState, n int, dt float64) []float64 {
+	var out []float64
+	for range n {
+		state.Bio.Stress = 0.1
+		loop.Tick(state, dt)
+		out = append(out, state.Bio.Stress-0.1)
+	}
+	return out
+}
+
+func TestHabituation_RepeatedBlowsSensitize(t *testing.T) {
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{Stimuli: []infrastructure.Stimulus{
+		{Cue: sense.CueViolence, Pulses: []biology.BioPulse{{Field: "stress", Amount: 0.1}}},
+	}}}
+	loop := newHabituationLoop(drainer, nil)
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+
+	got := stressPerTick(loop, &state, 4, 1)
+	if math.Abs(got[0]-0.1) > 1e-9 {
+		t.Fatalf("the first blow should land at full strength, got %v", got[0])
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i] <= got[i-1] {
+			t.Fatalf("each blow should land harder than the last: %v", got)
+		}
+	}
+	if level := state.Habituation.Level(sense.CueViolence); level <= 1 || level > 2 {
+		t.Errorf("violence sensitivity = %v, want above 1 and at most the limit", level)
+	}
+}
+
+func TestHabituation_ConstantNoiseFadesAndRecovers(t *testing.T) {
+	drainer := &fakeInputDrainer{}
+	loop := newHabituationLoop(drainer, nil)
+	env := infrastructure.DefaultEnvironment()
+	env.Noise = 0.5
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState(), Environment: &env}
+
+	var scales []float64
+	for range 30 {
+		state.Bio.Stress = 0.1
+		result := loop.Tick(&state, 60)
+		if len(result.Stimuli) != 1 || result.Stimuli[0].Cue != sense.CueNoise {
+			t.Fatalf("expected noise as the only adapting stimulus, got %+v", result.Stimuli)
+		}
+		scales = append(scales, result.Stimuli[0].Sensitivity)
+	}
+	if scales[0] != 1 || scales[29] >= 0.5 || scales[29] < 0.3 {
+		t.Fatalf("noise should fade toward its floor after half an hour: first %v, last %v", scales[0], scales[29])
+	}
+
+	state.Environment.Noise = infrastructure.NeutralNoise
+	habituated := state.Habituation.Level(sense.CueNoise)
+	loop.Tick(&state, 1800)
+	recovered := state.Habituation.Level(sense.CueNoise)
+	if math.Abs((1-recovered)-(1-habituated)/2) > 1e-9 {
+		t.Errorf("half an hour of quiet should recover half the habituation: %v -> %v", habituated, recovered)
+	}
+}
+
+func TestHabituation_PersonalitySetsTheRate(t *testing.T) {
+	adaptations := infrastructure.DefaultStimulusAdaptations()
+	after := func(cue sense.Cue, p motivation.Personality) float64 {
+		h := infrastructure.NewHabituation()
+		for range 3 {
+			h.Expose(cue, adaptations[cue], 1, p)
+		}
+		return h.Level(cue)
+	}
+
+	if calm, anxious := after(sense.CueThreat, motivation.Personality{StressSensitivity: 0.1}),
+		after(sense.CueThreat, motivation.Personality{StressSensitivity: 0.9}); anxious <= calm {
+		t.Errorf("stress-sensitive people should sensitize faster: %v vs %v", anxious, calm)
+	}
+	if dull, curious := after(sense.CueComfort, motivation.Personality{Curiosity: 0.1}),
+		after(sense.CueComfort, motivation.Personality{Curiosity: 0.9}); curious >= dull {
+		t.Errorf("curious people should habituate faster: %v vs %v", curious, dull)
+	}
+	if got := after(sense.CueFeeding, motivation.Personality{}); got != 1 {
+		t.Errorf("feeding has no adaptation and should stay at 1, got %v", got)
+	}
+}
+
+func TestHabituation_EmptyAdaptationsTurnItOff(t *testing.T) {
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{Stimuli: []infrastructure.Stimulus{
+		{Cue: sense.CueComfort, Pulses: []biology.BioPulse{{Field: "stress", Amount: 0.05}}},
+	}}}
+	loop := newHabituationLoop(drainer, map[sense.Cue]infrastructure.StimulusAdaptation{})
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+
+	got := stressPerTick(loop, &state, 3, 1)
+	if !reflect.DeepEqual(got, []float64{got[0], got[0], got[0]}) {
+		t.Errorf("without adaptations every hug should count the same, got %v", got)
+	}
+}
+
+func TestHabituation_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "habituation.json")
+	fresh, err := infrastructure.LoadHabituation(path)
+	if err != nil || len(fresh.Sensitivity) != 0 {
+		t.Fatalf("a missing file should load unadapted: %+v, %v", fresh, err)
+	}
+
+	adaptations := infrastructure.DefaultStimulusAdaptations()
+	h := infrastructure.NewHabituation()
+	h.Expose(sense.CueNoise, adaptations[sense.CueNoise], 5, motivation.Personality{})
+	h.Expose(sense.CueInsult, adaptations[sense.CueInsult], 2, motivation.Personality{})
+	if err := h.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := infrastructure.LoadHabituation(path)
+	if err != nil {
+		t.Fatalf("LoadHabituation: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, h) {
+		t.Errorf("loaded %+v, saved %+v", loaded, h)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("Save should leave only the habituation file behind, got %d entries", len(entries))
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := infrastructure.LoadHabituation(path); err == nil {
+		t.Error("expected an error for a corrupt file")
+	}
+}
+
+func TestHabituation_LoopLoadsFromItsStoreAndSavesOnClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "habituation.json")
+	adaptations := infrastructure.DefaultStimulusAdaptations()
+	stored := infrastructure.NewHabituation()
+	stored.Expose(sense.CueInsult, adaptations[sense.CueInsult], 3, motivation.Personality{})
+	if err := stored.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{Stimuli: []infrastructure.Stimulus{
+		{Cue: sense.CueViolence, Pulses: []biology.BioPulse{{Field: "stress", Amount: 0.1}}},
+	}}}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:        drainer,
+		Biology:      &fakeBioEngine{},
+		Motivation:   &fakeMotivationComputer{},
+		Mind:         &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: none] Hm."},
+		Habituations: infrastructure.HabituationFile(path),
+	})
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	if result := loop.Tick(&state, 1); result.HabituationErr != nil {
+		t.Fatalf("HabituationErr: %v", result.HabituationErr)
+	}
+	// One quiet second recovers a sliver of the stored sensitization.
+	if got, want := state.Habituation.Level(sense.CueInsult), stored.Level(sense.CueInsult); math.Abs(got-want) > 1e-3 {
+		t.Fatalf("insult sensitivity = %v, want about the stored %v", got, want)
+	}
+	if err := loop.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	saved, err := infrastructure.LoadHabituation(path)
+	if err != nil {
+		t.Fatalf("LoadHabituation: %v", err)
+	}
+	if saved.Level(sense.CueViolence) <= 1 || saved.Level(sense.CueInsult) != state.Habituation.Level(sense.CueInsult) {
+		t.Errorf("Close should save the adapted levels, got %+v", saved.Sensitivity)
+	}
+}
+
+func TestHabituation_CorruptStoreStartsUnadapted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "habituation.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:        &fakeInputDrainer{},
+		Biology:      &fakeBioEngine{},
+		Motivation:   &fakeMotivationComputer{},
+		Mind:         &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: none] Hm."},
+		Habituations: infrastructure.HabituationFile(path),
+	})
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	if result := loop.Tick(&state, 1); result.HabituationErr == nil {
+		t.Fatal("expected HabituationErr for a corrupt store")
+	}
+	if state.Habituation == nil || len(state.Habituation.Sensitivity) != 0 {
+		t.Errorf("a corrupt store should start unadapted, got %+v", state.Habituation)
+	}
+	if err := loop.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "{" {
+		t.Errorf("Close must not overwrite a store that failed to load, file now %q (%v)", data, err)
+	}
+}
@@ -1,21 +1,36 @@
 package infrastructure
 
 import (
+	"fmt"
+	"math"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/marczahn/person/v2/internal/biology"
-	"github.com/marczahn/person/v2/internal/motivation"
 	"github.com/marczahn/person/v2/internal/sense"
 )
 
 // InputAdapter stores raw operator lines and drains them once per tick.
 type InputAdapter struct {
-	mu     sync.Mutex
-	queue  []string
-	parser sense.Parser
-	nowFn  func() int64
+	mu          sync.Mutex
+	queue       []string
+	parser      sense.Parser
+	interpreter sense.Interpreter
+	nowFn       func() int64
+	perturber   *Perturber
+}
+
+// PerturbCommand is the operator command that schedules a perturbation:
+// "/perturb <spec>", with spec as in ParsePerturbation.
+const PerturbCommand = "/perturb"
+
+// OperatorCommand is an operator line addressed to the simulation rather than
+// the person, and its answer.
+type OperatorCommand struct {
+	Line  string
+	Reply string
+	Err   error
 }
 
 func NewInputAdapter(parser sense.Parser, nowFn func() int64) *InputAdapter {
@@ -25,12 +40,25 @@ func NewInputAdapter(parser sense.Parser, nowFn func() int64) *InputAdapter {
 	if nowFn == nil {
 		nowFn = func() int64 { return time.Now().Unix() }
 	}
+	interpreter, ok := parser.(sense.Interpreter)
+	if !ok {
+		interpreter = defaultInterpreter
+	}
 	return &InputAdapter{
-		parser: parser,
-		nowFn:  nowFn,
+		parser:      parser,
+		interpreter: interpreter,
+		nowFn:       nowFn,
 	}
 }
 
+// HandlePerturbations routes PerturbCommand lines to p, which must be the
+// perturber the loop ticks. Without it those lines are answered with an error.
+func (a *InputAdapter) HandlePerturbations(p *Perturber) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.perturber = p
+}
+
 func (a *InputAdapter) Enqueue(raw string) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -41,15 +69,19 @@ func (a *InputAdapter) Drain() TickInput {
 	a.mu.Lock()
 	rawItems := append([]string(nil), a.queue...)
 	a.queue = nil
+	perturber := a.perturber
 	a.mu.Unlock()
 
 	out := TickInput{
-		AllowedActions: defaultAllowedActions(),
-		NowSeconds:     a.nowFn(),
+		NowSeconds: a.nowFn(),
 	}
 
 	external := make([]string, 0, len(rawItems))
 	for _, raw := range rawItems {
+		if cmd, ok := perturbCommand(raw, perturber); ok {
+			out.Commands = append(out.Commands, cmd)
+			continue
+		}
 		parsed, ok := a.parser.Parse(raw)
 		if !ok {
 			continue
@@ -58,10 +90,13 @@ func (a *InputAdapter) Drain() TickInput {
 		external = append(external, strings.TrimSpace(raw))
 
 		switch parsed.Kind {
-		case sense.InputAction:
-			applyActionInput(parsed.Content, &out)
-		case sense.InputEnvironment:
-			applyEnvironmentInput(parsed.Content, &out)
+		case sense.InputSpeech:
+			out.Speech = append(out.Speech, parsed.Content)
+			reading := a.interpreter.Interpret(parsed)
+			applyInterpretation(reading, &out)
+			applySpeech(parsed.Content, reading, &out)
+		case sense.InputAction, sense.InputEnvironment:
+			applyInterpretation(a.interpreter.Interpret(parsed), &out)
 		}
 	}
 
@@ -69,86 +104,285 @@ func (a *InputAdapter) Drain() TickInput {
 	return out
 }
 
-func defaultAllowedActions() map[string]bool {
-	return map[string]bool{
-		string(motivation.ActionRest):      true,
-		string(motivation.ActionEat):       true,
-		string(motivation.ActionHydrate):   true,
-		string(motivation.ActionReachOut):  true,
-		string(motivation.ActionJournal):   true,
-		string(motivation.ActionBreathe):   true,
-		string(motivation.ActionScanArea):  true,
-		string(motivation.ActionSeekWarm):  true,
-		string(motivation.ActionSeekCool):  true,
-		string(motivation.ActionMicroTask): true,
+// perturbCommand runs raw if it is a PerturbCommand line. The person never
+// perceives it.
+func perturbCommand(raw string, p *Perturber) (OperatorCommand, bool) {
+	line := strings.TrimSpace(raw)
+	spec, ok := strings.CutPrefix(line, PerturbCommand)
+	if !ok || (spec != "" && spec[0] != ' ' && spec[0] != '\t') {
+		return OperatorCommand{}, false
+	}
+	cmd := OperatorCommand{Line: line}
+	if p == nil {
+		cmd.Err = fmt.Errorf("%s: perturbations are not enabled", PerturbCommand)
+		return cmd, true
 	}
+	pert, err := p.Command(spec, "operator")
+	if err != nil {
+		cmd.Err = fmt.Errorf("%s: %w", PerturbCommand, err)
+		return cmd, true
+	}
+	cmd.Reply = "scheduled " + pert.String()
+	if pert.At > 0 {
+		cmd.Reply += fmt.Sprintf(" at %gs", pert.At)
+	}
+	return cmd, true
 }
 
-func applyActionInput(content string, out *TickInput) {
-	lower := strings.ToLower(content)
+// minCueConfidence drops readings too uncertain to act on: hedged mentions of
+// ambiguous words and cues in questions.
+const minCueConfidence = 0.5
 
-	if containsAny(lower, "punch", "hit", "kick", "slap", "strike", "shove", "attack") {
-		out.PreBioPulses = append(out.PreBioPulses,
-			biology.BioPulse{Field: "stress", Amount: 0.20},
-			biology.BioPulse{Field: "physical_tension", Amount: 0.15},
-			biology.BioPulse{Field: "mood", Amount: -0.08},
-		)
+// ambientCue maps an environment cue to the level it sets at a given intensity.
+// A negated mention ("not cold anymore") returns the level to neutral.
+type ambientCue struct {
+	field   func(*AmbientPatch) **float64
+	at      func(intensity float64) float64
+	neutral float64
+}
+
+func temperatureField(p *AmbientPatch) **float64 { return &p.TemperatureC }
+func noiseField(p *AmbientPatch) **float64       { return &p.Noise }
+func lightField(p *AmbientPatch) **float64       { return &p.Light }
+func crowdingField(p *AmbientPatch) **float64    { return &p.Crowding }
+
+var ambientCues = map[sense.Cue]ambientCue{
+	sense.CueCold:  {temperatureField, func(i float64) float64 { return NeutralTemperatureC - 12*i }, NeutralTemperatureC},
+	sense.CueHeat:  {temperatureField, func(i float64) float64 { return NeutralTemperatureC + 12*i }, NeutralTemperatureC},
+	sense.CueNoise: {noiseField, func(i float64) float64 { return min(NeutralNoise+0.3*i, 1) }, NeutralNoise},
+	sense.CueCalm:  {noiseField, func(i float64) float64 { return max(NeutralNoise-0.2*i, 0) }, NeutralNoise},
+	sense.CueDark:  {lightField, func(i float64) float64 { return max(0.3-0.25*i, 0) }, NeutralLight},
+	sense.CueLight: {lightField, func(i float64) float64 { return min(NeutralLight+0.25*i, 1) }, NeutralLight},
+	sense.CueCrowd: {crowdingField, func(i float64) float64 { return min(0.6*i, 1) }, NeutralCrowding},
+}
+
+// cuePulses are one-off biology pulses at intensity 1.
+var cuePulses = map[sense.Cue][]biology.BioPulse{
+	sense.CueViolence: {
+		{Field: "stress", Amount: 0.20},
+		{Field: "physical_tension", Amount: 0.15},
+		{Field: "mood", Amount: -0.08},
+	},
+	sense.CueComfort: {
+		{Field: "stress", Amount: -0.12},
+		{Field: "physical_tension", Amount: -0.08},
+		{Field: "mood", Amount: 0.08},
+	},
+	sense.CueFeeding: {{Field: "hunger", Amount: -0.20}},
+
+	sense.CueGreeting: {
+		{Field: "social_deficit", Amount: -0.03},
+		{Field: "mood", Amount: 0.01},
+	},
+	sense.CueQuestion: {{Field: "social_deficit", Amount: -0.02}},
+	sense.CuePraise: {
+		{Field: "mood", Amount: 0.06},
+		{Field: "stress", Amount: -0.04},
+		{Field: "social_deficit", Amount: -0.03},
+	},
+	sense.CueInsult: {
+		{Field: "stress", Amount: 0.08},
+		{Field: "mood", Amount: -0.07},
+	},
+	sense.CueThreat: {
+		{Field: "stress", Amount: 0.16},
+		{Field: "physical_tension", Amount: 0.10},
+		{Field: "mood", Amount: -0.05},
+	},
+	sense.CueWarmth:    {{Field: "mood", Amount: 0.02}},
+	sense.CueHostility: {{Field: "stress", Amount: 0.03}},
+}
+
+// Stimulus is one cue's one-off effect from drained input, before the
+// person's habituation scales it.
+type Stimulus struct {
+	Cue    sense.Cue
+	Pulses []biology.BioPulse
+}
+
+// contactRelief is what being spoken to in a neutral tone does to social
+// deficit. Warmth raises it by up to half; hostility takes it away.
+const contactRelief = -0.04
+
+// Speech tones by HeardSpeech.Tone.
+const (
+	ToneWarm    = "warm"
+	ToneNeutral = "neutral"
+	ToneHostile = "hostile"
+	toneBand    = 0.2
+)
+
+// HeardSpeech is how the person took one line of operator speech.
+type HeardSpeech struct {
+	Text string
+	// Acts are the speech acts recognised with confidence, in sense.SpeechActs order.
+	Acts []sense.Cue
+	// Tone runs from -1 (hostile) to 1 (warm).
+	Tone float64
+	// Addressed is set when the speech was directed at the person.
+	Addressed bool
+}
+
+// ToneLabel names the tone: warm, neutral or hostile.
+func (h HeardSpeech) ToneLabel() string {
+	switch {
+	case h.Tone >= toneBand:
+		return ToneWarm
+	case h.Tone <= -toneBand:
+		return ToneHostile
+	default:
+		return ToneNeutral
 	}
+}
 
-	if containsAny(lower, "hug", "comfort", "reassure", "support", "care") {
-		out.PreBioPulses = append(out.PreBioPulses,
-			biology.BioPulse{Field: "stress", Amount: -0.12},
-			biology.BioPulse{Field: "physical_tension", Amount: -0.08},
-			biology.BioPulse{Field: "mood", Amount: 0.08},
-		)
+// Tag renders the reading for the prompt, e.g.
+// "[HEARD: greeting, question; tone=warm; to you]".
+func (h HeardSpeech) Tag() string {
+	acts := "remark"
+	if len(h.Acts) > 0 {
+		names := make([]string, len(h.Acts))
+		for i, a := range h.Acts {
+			names[i] = string(a)
+		}
+		acts = strings.Join(names, ", ")
+	}
+	to := "overheard"
+	if h.Addressed {
+		to = "to you"
 	}
+	return fmt.Sprintf("[HEARD: %s; tone=%s; %s]", acts, h.ToneLabel(), to)
+}
 
-	if containsAny(lower, "feed", "food", "meal", "snack") {
-		out.PreBioPulses = append(out.PreBioPulses, biology.BioPulse{Field: "hunger", Amount: -0.20})
-		out.AllowedActions[string(motivation.ActionEat)] = true
+// applySpeech records how a line of speech was taken. Speech directed at the
+// person is social contact: it relieves social deficit in proportion to its
+// warmth and marks the tick as spent in company.
+func applySpeech(text string, in sense.Interpretation, out *TickInput) {
+	heard := HeardSpeech{Text: text, Tone: in.Tone(), Addressed: in.Addressed}
+	for _, act := range sense.SpeechActs {
+		for _, e := range in.Effects {
+			if e.Cue == act && !e.Negated && e.Confidence >= minCueConfidence {
+				heard.Acts = append(heard.Acts, act)
+				break
+			}
+		}
+	}
+	out.Heard = append(out.Heard, heard)
+	if !heard.Addressed {
+		return
+	}
+	out.SocialContact = true
+	if warmth := min(max(1+heard.Tone, 0), 1.5); warmth > 0 {
+		out.PreBioPulses = append(out.PreBioPulses, biology.BioPulse{Field: "social_deficit", Amount: contactRelief * warmth})
 	}
 }
 
+// applyEnvironmentInput applies an environment description with the default interpreter.
 func applyEnvironmentInput(content string, out *TickInput) {
-	lower := strings.ToLower(content)
+	applyInterpretation(defaultInterpreter.Interpret(sense.ParsedInput{Kind: sense.InputEnvironment, Content: content}), out)
+}
 
-	if containsAny(lower, "cold", "freezing", "chilly", "frigid") {
-		out.PreBioRates = append(out.PreBioRates, biology.BioRate{Field: "body_temp", PerSecond: -0.03})
-	}
-	if containsAny(lower, "hot", "heat", "scorching", "sweltering") {
-		out.PreBioRates = append(out.PreBioRates, biology.BioRate{Field: "body_temp", PerSecond: 0.03})
-	}
-	if containsAny(lower, "loud", "crowd", "chaos", "sirens") {
-		out.PreBioRates = append(out.PreBioRates, biology.BioRate{Field: "stress", PerSecond: 0.03})
+var defaultInterpreter = sense.NewInterpreter()
+
+// applyInterpretation turns graded cues into tick effects. Pulse cues become
+// one stimulus per input at their strongest affirmed intensity; negated
+// mentions have no effect. Ambient cues become an EnvironmentChange: per level the strongest
+// affirmed mention wins, and a negated mention alone returns the level to
+// neutral. World cues take the last confident mention, so "no food here, but
+// food is available next door" leaves food available.
+func applyInterpretation(in sense.Interpretation, out *TickInput) {
+	strongest := make(map[sense.Cue]float64)
+	var order []sense.Cue
+	var change EnvironmentChange
+	world := &out.World
+	if in.Kind == sense.InputEnvironment && in.For > 0 {
+		change.For = in.For
+		world = &change.World
 	}
-	if containsAny(lower, "quiet", "calm", "safe", "peaceful") {
-		out.PreBioRates = append(out.PreBioRates, biology.BioRate{Field: "stress", PerSecond: -0.02})
+
+	type level struct {
+		value, deviation float64
+		affirmed         bool
 	}
+	levels := make(map[**float64]level)
+	var levelOrder []**float64
 
-	if containsAny(lower, "no food", "without food", "food unavailable") {
-		out.AllowedActions[string(motivation.ActionEat)] = false
-	} else if containsAny(lower, "food available", "food is available", "has food", "meal nearby", "kitchen stocked") {
-		out.AllowedActions[string(motivation.ActionEat)] = true
+	for _, e := range in.Effects {
+		if e.Confidence < minCueConfidence {
+			continue
+		}
+		if setWorldCue(e, world) {
+			continue
+		}
+		if ac, ok := ambientCues[e.Cue]; ok {
+			field := ac.field(&change.Ambient)
+			value := ac.neutral
+			if !e.Negated {
+				value = ac.at(e.Intensity)
+				if e.Cue == sense.CueCrowd {
+					world.PeopleNearby = boolPtr(true)
+				}
+			}
+			current, seen := levels[field]
+			if !seen {
+				levelOrder = append(levelOrder, field)
+			}
+			next := level{value: value, deviation: math.Abs(value - ac.neutral), affirmed: !e.Negated}
+			switch {
+			case !seen,
+				next.affirmed && !current.affirmed,
+				next.affirmed && next.deviation > current.deviation:
+				levels[field] = next
+			}
+			continue
+		}
+		if e.Negated || e.Intensity <= 0 {
+			continue
+		}
+		if _, seen := strongest[e.Cue]; !seen {
+			order = append(order, e.Cue)
+		}
+		strongest[e.Cue] = max(strongest[e.Cue], e.Intensity)
 	}
 
-	if containsAny(lower, "no water", "without water", "water unavailable") {
-		out.AllowedActions[string(motivation.ActionHydrate)] = false
-	} else if containsAny(lower, "water available", "drinkable water") {
-		out.AllowedActions[string(motivation.ActionHydrate)] = true
+	for _, field := range levelOrder {
+		*field = floatPtr(levels[field].value)
+	}
+	if !change.Ambient.Empty() || !change.World.Empty() {
+		out.Environment = append(out.Environment, change)
 	}
 
-	if containsAny(lower, "no quiet space", "cannot rest", "rest impossible") {
-		out.AllowedActions[string(motivation.ActionRest)] = false
-	} else if containsAny(lower, "quiet space available", "can rest", "safe resting place") {
-		out.AllowedActions[string(motivation.ActionRest)] = true
+	for _, cue := range order {
+		intensity := strongest[cue]
+		if pulses := cuePulses[cue]; len(pulses) > 0 {
+			stimulus := Stimulus{Cue: cue}
+			for _, p := range pulses {
+				stimulus.Pulses = append(stimulus.Pulses, biology.BioPulse{Field: p.Field, Amount: p.Amount * intensity})
+			}
+			out.Stimuli = append(out.Stimuli, stimulus)
+		}
+		if cue == sense.CueFeeding {
+			world.Food = boolPtr(true)
+		}
 	}
 }
 
-func containsAny(s string, patterns ...string) bool {
-	for _, p := range patterns {
-		if strings.Contains(s, p) {
-			return true
-		}
+// setWorldCue applies a world availability cue and reports whether e was one.
+func setWorldCue(e sense.Effect, patch *WorldPatch) bool {
+	var field **bool
+	switch e.Cue {
+	case sense.CueFood:
+		field = &patch.Food
+	case sense.CueWater:
+		field = &patch.Water
+	case sense.CueQuietSpace:
+		field = &patch.QuietSpace
+	case sense.CuePeople:
+		field = &patch.PeopleNearby
+	case sense.CueExplorable:
+		field = &patch.Explorable
+	default:
+		return false
 	}
-	return false
+	*field = boolPtr(!e.Negated)
+	return true
 }
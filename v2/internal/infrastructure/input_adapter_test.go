@@ -1,12 +1,24 @@
 package infrastructure_test
 
 import (
+	"math"
+	"reflect"
 	"testing"
 
+	"github.com/marczahn/person/v2/internal/biology"
 	"github.com/marczahn/person/v2/internal/infrastructure"
 	"github.com/marczahn/person/v2/internal/sense"
 )
 
+// inputPulses returns every pulse a drain carries at full strength.
+func inputPulses(in infrastructure.TickInput) []biology.BioPulse {
+	pulses := append([]biology.BioPulse(nil), in.PreBioPulses...)
+	for _, s := range in.Stimuli {
+		pulses = append(pulses, s.Pulses...)
+	}
+	return pulses
+}
+
 func TestInputAdapter_DrainNoInputNoOp(t *testing.T) {
 	adapter := infrastructure.NewInputAdapter(sense.NewParser(), func() int64 { return 77 })
 
@@ -18,11 +30,11 @@ func TestInputAdapter_DrainNoInputNoOp(t *testing.T) {
 	if len(got.PreBioRates) != 0 {
 		t.Fatalf("expected no rates, got %d", len(got.PreBioRates))
 	}
-	if len(got.PreBioPulses) != 0 {
-		t.Fatalf("expected no pulses, got %d", len(got.PreBioPulses))
+	if len(inputPulses(got)) != 0 {
+		t.Fatalf("expected no pulses, got %d", len(inputPulses(got)))
 	}
-	if !got.AllowedActions["eat"] {
-		t.Fatalf("expected default eat action to be allowed")
+	if !got.World.Empty() {
+		t.Fatalf("expected no world changes without input, got %+v", got.World)
 	}
 	if got.NowSeconds != 77 {
 		t.Fatalf("unexpected now seconds: got=%d want=77", got.NowSeconds)
@@ -41,14 +53,17 @@ func TestInputAdapter_DrainParsesSpeechActionEnvironment(t *testing.T) {
 	if got.ExternalText != wantText {
 		t.Fatalf("unexpected external text: got=%q want=%q", got.ExternalText, wantText)
 	}
-	if len(got.PreBioPulses) == 0 {
-		t.Fatalf("expected action pulse effects from punch input")
+	if len(got.Stimuli) != 2 || got.Stimuli[0].Cue != sense.CueGreeting || got.Stimuli[1].Cue != sense.CueViolence {
+		t.Fatalf("expected greeting and violence stimuli in drain order, got %+v", got.Stimuli)
 	}
-	if len(got.PreBioRates) == 0 {
-		t.Fatalf("expected environment rate effects from cold input")
+	if len(got.Environment) != 1 || got.Environment[0].Ambient.TemperatureC == nil {
+		t.Fatalf("expected an ambient temperature change from cold input, got %+v", got.Environment)
 	}
-	if got.AllowedActions["eat"] {
-		t.Fatalf("expected eat action blocked by 'no food' environment input")
+	if got.World.Food == nil || *got.World.Food {
+		t.Fatalf("expected food marked unavailable by 'no food' environment input")
+	}
+	if len(got.Speech) != 1 || got.Speech[0] != "hello operator" {
+		t.Fatalf("expected operator speech to be collected, got %q", got.Speech)
 	}
 }
 
@@ -58,14 +73,163 @@ func TestInputAdapter_DrainDeterministicOrderingWithinCycle(t *testing.T) {
 	adapter.Enqueue("~food is available")
 
 	got := adapter.Drain()
-	if !got.AllowedActions["eat"] {
-		t.Fatalf("expected later environment input to deterministically override eat gate to allowed")
+	if got.World.Food == nil || !*got.World.Food {
+		t.Fatalf("expected later environment input to deterministically override food to available")
 	}
 
 	adapter.Enqueue("~food is available")
 	adapter.Enqueue("~no food available")
 	got = adapter.Drain()
-	if got.AllowedActions["eat"] {
-		t.Fatalf("expected later environment input to deterministically override eat gate to blocked")
+	if got.World.Food == nil || *got.World.Food {
+		t.Fatalf("expected later environment input to deterministically override food to unavailable")
+	}
+}
+
+func TestInputAdapter_InterpretsNegationIntensityAndWordBoundaries(t *testing.T) {
+	rateFor := func(raw, field string) float64 {
+		adapter := infrastructure.NewInputAdapter(sense.NewParser(), nil)
+		adapter.Enqueue(raw)
+		total := 0.0
+		for _, r := range environmentRates(adapter.Drain()) {
+			if r.Field == field {
+				total += r.PerSecond
+			}
+		}
+		return total
+	}
+	tests := []struct {
+		raw   string
+		field string
+		want  float64
+	}{
+		{"~it is not cold anymore", "body_temp", 0},
+		{"~no longer loud", "stress", 0},
+		{"~a bit hot", "body_temp", 0.015},
+		{"~hot", "body_temp", 0.03},
+		{"~scorching", "body_temp", 0.051},
+		{"~cold, freezing cold", "body_temp", -0.048},
+		{"~is it loud?", "stress", 0},
+	}
+	for _, tc := range tests {
+		if got := rateFor(tc.raw, tc.field); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%q %s rate = %v, want %v", tc.raw, tc.field, got, tc.want)
+		}
+	}
+
+	adapter := infrastructure.NewInputAdapter(sense.NewParser(), nil)
+	adapter.Enqueue("*careful*")
+	if got := adapter.Drain(); len(got.Stimuli) != 0 {
+		t.Fatalf("\"careful\" must not read as comfort, got %+v", got.Stimuli)
+	}
+	adapter.Enqueue("*hits you hard*")
+	var stress float64
+	for _, p := range inputPulses(adapter.Drain()) {
+		if p.Field == "stress" {
+			stress += p.Amount
+		}
+	}
+	if math.Abs(stress-0.30) > 1e-9 {
+		t.Fatalf("hard hit stress pulse = %v, want 0.30", stress)
+	}
+}
+
+type interpretingParser struct {
+	*sense.ConventionParser
+	calls int
+}
+
+func (p *interpretingParser) Interpret(in sense.ParsedInput) sense.Interpretation {
+	p.calls++
+	return sense.Interpretation{Kind: in.Kind, Effects: []sense.Effect{{Cue: sense.CueWater, Negated: true, Intensity: 1, Confidence: 1}}}
+}
+
+func TestInputAdapter_ParserMayReplaceInterpreter(t *testing.T) {
+	parser := &interpretingParser{ConventionParser: sense.NewParser()}
+	adapter := infrastructure.NewInputAdapter(parser, nil)
+	adapter.Enqueue("~sunny meadow")
+	adapter.Enqueue("plain speech")
+
+	got := adapter.Drain()
+	if parser.calls != 2 {
+		t.Fatalf("expected the parser's interpreter for both lines, got %d calls", parser.calls)
+	}
+	if got.World.Water == nil || *got.World.Water {
+		t.Fatalf("expected the custom interpretation to apply, got %+v", got.World)
+	}
+}
+
+func TestInputAdapter_SpeechIsGradedByActAndTone(t *testing.T) {
+	drain := func(line string) infrastructure.TickInput {
+		adapter := infrastructure.NewInputAdapter(sense.NewParser(), nil)
+		adapter.Enqueue(line)
+		return adapter.Drain()
+	}
+	sum := func(in infrastructure.TickInput, field string) float64 {
+		total := 0.0
+		for _, p := range inputPulses(in) {
+			if p.Field == field {
+				total += p.Amount
+			}
+		}
+		return total
+	}
+
+	warm := drain("hello, thank you so much!")
+	neutral := drain("do you know the time?")
+	insult := drain("you are worthless")
+	overheard := drain("the bus is late again")
+
+	if !(sum(warm, "social_deficit") < sum(neutral, "social_deficit") && sum(neutral, "social_deficit") < 0) {
+		t.Errorf("warm speech should relieve more than neutral: warm=%v neutral=%v", sum(warm, "social_deficit"), sum(neutral, "social_deficit"))
+	}
+	if sum(insult, "social_deficit") != 0 || sum(insult, "stress") <= 0 || sum(insult, "mood") >= 0 {
+		t.Errorf("an insult should hurt without relief, got %+v", inputPulses(insult))
+	}
+	if len(inputPulses(overheard)) != 0 || overheard.SocialContact {
+		t.Errorf("overheard small talk should not count as contact, got %+v", overheard)
+	}
+	if !warm.SocialContact || !insult.SocialContact {
+		t.Error("speech addressed to the person is social contact")
+	}
+
+	want := map[string]string{
+		"hello, thank you so much!": "[HEARD: greeting, praise; tone=warm; to you]",
+		"do you know the time?":     "[HEARD: question; tone=neutral; to you]",
+		"you are worthless":         "[HEARD: insult; tone=hostile; to you]",
+		"the bus is late again":     "[HEARD: remark; tone=neutral; overheard]",
+	}
+	for _, in := range []infrastructure.TickInput{warm, neutral, insult, overheard} {
+		if len(in.Heard) != 1 || in.Heard[0].Text != in.Speech[0] {
+			t.Fatalf("Heard should read each line of Speech, got %+v", in.Heard)
+		}
+		if got := in.Heard[0].Tag(); got != want[in.Speech[0]] {
+			t.Errorf("%q: Tag = %q, want %q", in.Speech[0], got, want[in.Speech[0]])
+		}
+	}
+}
+
+func TestSimulationLoop_HeardSpeechIsTaggedForTheMind(t *testing.T) {
+	adapter := infrastructure.NewInputAdapter(sense.NewParser(), nil)
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      adapter,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: none] Hm."},
+	})
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+
+	adapter.Enqueue("you are worthless")
+	adapter.Enqueue("the bus is late again")
+	result := loop.Tick(&state, 1)
+	want := []string{"[HEARD: insult; tone=hostile; to you]", "[HEARD: remark; tone=neutral; overheard]"}
+	if !reflect.DeepEqual(result.Prompt.Heard, want) {
+		t.Fatalf("Prompt.Heard = %q, want %q", result.Prompt.Heard, want)
+	}
+
+	bare := infrastructure.MindRequest{Input: result.Input}
+	tagged := bare
+	tagged.Prompt.Heard = result.Prompt.Heard
+	if infrastructure.EstimateMindRequestTokens(tagged) <= infrastructure.EstimateMindRequestTokens(bare) {
+		t.Error("the token estimate should count the heard tags")
 	}
 }
@@ -0,0 +1,425 @@
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/marczahn/person/v2/internal/consciousness"
+)
+
+// ErrMindBudget is returned by MeteredMind when a call would exceed its budget.
+// The loop treats it like any mind error: the prior parse is carried.
+var ErrMindBudget = errors.New("mind budget exhausted")
+
+// DefaultMindReserve is the share of each limit kept for triggers that answer
+// the world when MindBudget.Reserve is unset.
+const DefaultMindReserve = 0.2
+
+// MindBudget limits estimated mind tokens (input + output). Zero limits are unlimited.
+type MindBudget struct {
+	TokensPerMinute int
+	SessionTokens   int
+	// Reserve is the share of each limit kept for requests that answer the
+	// world; past 1-Reserve, self-generated requests are refused (see
+	// MindTrigger.discretionary). Outside (0, 1) it uses DefaultMindReserve.
+	Reserve float64
+}
+
+// MindPricing converts tokens into cost, in USD per million tokens.
+type MindPricing struct {
+	InputPerMTok  float64
+	OutputPerMTok float64
+}
+
+// MindUsage accumulates metered mind calls.
+type MindUsage struct {
+	Calls        int
+	InputTokens  int
+	OutputTokens int
+	// Denied counts calls refused by the budget.
+	Denied int
+}
+
+// Tokens returns input plus output tokens.
+func (u MindUsage) Tokens() int {
+	return u.InputTokens + u.OutputTokens
+}
+
+// Cost returns the cost of u under p.
+func (u MindUsage) Cost(p MindPricing) float64 {
+	return float64(u.InputTokens)/1e6*p.InputPerMTok + float64(u.OutputTokens)/1e6*p.OutputPerMTok
+}
+
+func (u *MindUsage) add(o MindUsage) {
+	u.Calls += o.Calls
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
+	u.Denied += o.Denied
+}
+
+// Model calls made on the mind's behalf, metered next to its own triggers.
+const (
+	// TriggerEvaluator is the blind evaluator judging a thought's impact.
+	TriggerEvaluator MindTrigger = "evaluator"
+	// TriggerSummary is the continuity buffer summarizing old thoughts.
+	TriggerSummary MindTrigger = "summary"
+)
+
+// discretionary reports whether a trigger is shed first when the budget runs
+// low. Spontaneous thoughts and drift in drives or biology come from within;
+// input, interruptions and thresholds answer something that happened.
+// Evaluations and summaries have a fallback and are always shed.
+func (t MindTrigger) discretionary() bool {
+	switch t {
+	case TriggerSpontaneous, TriggerDrive, TriggerBio, TriggerEvaluator, TriggerSummary:
+		return true
+	}
+	return false
+}
+
+// MindMeterConfig configures a MeteredMind.
+type MindMeterConfig struct {
+	Budget  MindBudget
+	Pricing MindPricing
+	// Now defaults to time.Now; the per-minute window is wall-clock, like API quotas.
+	Now func() time.Time
+}
+
+// mindSpend is one call in the per-minute window: its estimate while in
+// flight, its recorded tokens once settled.
+type mindSpend struct {
+	id     int
+	at     time.Time
+	tokens int
+}
+
+// MeteredMind wraps a MindResponder, estimating tokens per call and refusing
+// calls over budget with ErrMindBudget before they reach the wrapped mind.
+// Contract: a call's estimate is reserved when it is admitted and settled when
+// it returns, so concurrent calls (the asynchronous pipeline, a population)
+// cannot together overshoot a limit.
+type MeteredMind struct {
+	mind    MindResponder
+	budget  MindBudget
+	pricing MindPricing
+	now     func() time.Time
+
+	mu        sync.Mutex
+	started   time.Time
+	byTrigger map[MindTrigger]MindUsage
+	reserved  int
+	nextID    int
+	window    []mindSpend
+}
+
+var _ MindResponder = (*MeteredMind)(nil)
+
+// NewMeteredMind wraps mind with metering.
+func NewMeteredMind(mind MindResponder, cfg MindMeterConfig) *MeteredMind {
+	if mind == nil {
+		panic(fmt.Errorf("metered mind requires MindResponder"))
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if cfg.Budget.Reserve <= 0 || cfg.Budget.Reserve >= 1 {
+		cfg.Budget.Reserve = DefaultMindReserve
+	}
+	return &MeteredMind{
+		mind:      mind,
+		budget:    cfg.Budget,
+		pricing:   cfg.Pricing,
+		now:       cfg.Now,
+		started:   cfg.Now(),
+		byTrigger: make(map[MindTrigger]MindUsage),
+	}
+}
+
+// Respond meters and forwards one mind request.
+func (m *MeteredMind) Respond(ctx context.Context, in MindRequest) (string, error) {
+	input := EstimateMindRequestTokens(in)
+	id, err := m.admit(in.Trigger, input)
+	if err != nil {
+		return "", err
+	}
+	raw, err := m.mind.Respond(ctx, in)
+	m.record(id, in.Trigger, input, consciousness.EstimateTokens(raw))
+	return raw, err
+}
+
+// Completer meters model calls made on the mind's behalf under trigger
+// (TriggerEvaluator, TriggerSummary) against the same budget as the mind's
+// own calls. Wrap the model given to LLMEvaluator and LLMSummarizer with it.
+func (m *MeteredMind) Completer(model consciousness.Completer, trigger MindTrigger) consciousness.Completer {
+	if model == nil {
+		panic(fmt.Errorf("metered completer requires Completer"))
+	}
+	return meteredCompleter{meter: m, model: model, trigger: trigger}
+}
+
+type meteredCompleter struct {
+	meter   *MeteredMind
+	model   consciousness.Completer
+	trigger MindTrigger
+}
+
+func (c meteredCompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	input := consciousness.EstimateTokens(prompt)
+	id, err := c.meter.admit(c.trigger, input)
+	if err != nil {
+		return "", err
+	}
+	raw, err := c.model.Complete(ctx, prompt)
+	c.meter.record(id, c.trigger, input, consciousness.EstimateTokens(raw))
+	return raw, err
+}
+
+// Usage returns the totals so far.
+func (m *MeteredMind) Usage() MindUsage {
+	return m.Report().Usage
+}
+
+// Degraded reports whether self-generated requests are currently being shed.
+func (m *MeteredMind) Degraded() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.degradedLocked(m.now())
+}
+
+// degradedLocked reports whether even a one-token self-generated request
+// would be refused.
+func (m *MeteredMind) degradedLocked(now time.Time) bool {
+	return m.overLocked(now, 1, 1-m.budget.Reserve) != ""
+}
+
+// MindUsageReport is a snapshot of a MeteredMind's accounting.
+type MindUsageReport struct {
+	Started   time.Time
+	ByTrigger map[MindTrigger]MindUsage
+	Usage     MindUsage
+	CostUSD   float64
+	// Degraded is set while self-generated requests are being shed.
+	Degraded bool
+}
+
+// Report returns a snapshot of usage so far.
+func (m *MeteredMind) Report() MindUsageReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r := MindUsageReport{
+		Started:   m.started,
+		ByTrigger: make(map[MindTrigger]MindUsage, len(m.byTrigger)),
+	}
+	for t, u := range m.byTrigger {
+		r.ByTrigger[t] = u
+		r.Usage.add(u)
+	}
+	r.CostUSD = r.Usage.Cost(m.pricing)
+	r.Degraded = m.degradedLocked(m.now())
+	return r
+}
+
+func (m *MeteredMind) admit(trigger MindTrigger, estimate int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	share, reserve := 1.0, ""
+	if trigger.discretionary() {
+		share, reserve = 1-m.budget.Reserve, " reserve"
+	}
+	if scope := m.overLocked(now, estimate, share); scope != "" {
+		u := m.byTrigger[trigger]
+		u.Denied++
+		m.byTrigger[trigger] = u
+		return 0, fmt.Errorf("mind call refused by %s budget%s: %w", scope, reserve, ErrMindBudget)
+	}
+	m.nextID++
+	m.reserved += estimate
+	m.window = append(m.window, mindSpend{id: m.nextID, at: now, tokens: estimate})
+	return m.nextID, nil
+}
+
+// record settles an admitted call: its reservation is replaced by the tokens
+// it actually used.
+func (m *MeteredMind) record(id int, trigger MindTrigger, input, output int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reserved -= input
+	u := m.byTrigger[trigger]
+	u.add(MindUsage{Calls: 1, InputTokens: input, OutputTokens: output})
+	m.byTrigger[trigger] = u
+	for i := range m.window {
+		if m.window[i].id == id {
+			m.window[i].tokens = input + output
+			break
+		}
+	}
+}
+
+// overLocked returns the budget that adding estimate tokens would take past
+// share of its limit, or "" when none would. Reservations of calls in flight count.
+func (m *MeteredMind) overLocked(now time.Time, estimate int, share float64) string {
+	over := func(spent, limit int) bool {
+		return limit > 0 && float64(spent+estimate) > float64(limit)*share
+	}
+	session := m.reserved
+	for _, u := range m.byTrigger {
+		session += u.Tokens()
+	}
+	switch {
+	case over(session, m.budget.SessionTokens):
+		return "session"
+	case over(m.minuteSpendLocked(now), m.budget.TokensPerMinute):
+		return "per-minute"
+	default:
+		return ""
+	}
+}
+
+func (m *MeteredMind) minuteSpendLocked(now time.Time) int {
+	cutoff := now.Add(-time.Minute)
+	keep := m.window[:0]
+	total := 0
+	for _, s := range m.window {
+		if s.at.After(cutoff) {
+			keep = append(keep, s)
+			total += s.tokens
+		}
+	}
+	m.window = keep
+	return total
+}
+
+// EstimateMindRequestTokens approximates the prompt size of a request from
+// the text the prompt is rendered from.
+func EstimateMindRequestTokens(in MindRequest) int {
+	p := in.Prompt
+	n := consciousness.EstimateTokens(p.GoalPull) + consciousness.EstimateTokens(p.Activity) +
+		consciousness.EstimateTokens(p.Surroundings) +
+		consciousness.EstimateTokens(in.Correction)
+	for _, d := range append(append([]consciousness.PromptDrive(nil), p.Primary...), p.Background...) {
+		n += consciousness.EstimateTokens(d.Felt)
+	}
+	for _, lines := range [][]string{p.ContinuityBuffer, p.Conflicts, p.Memories, p.Heard, in.Input.Speech} {
+		for _, line := range lines {
+			n += consciousness.EstimateTokens(line)
+		}
+	}
+	return n
+}
+
+// MindUsageLine renders mind usage totals for the session summary.
+func MindUsageLine(u MindUsage) string {
+	line := fmt.Sprintf("mind usage: %d calls, ~%d in / ~%d out tokens", u.Calls, u.InputTokens, u.OutputTokens)
+	if u.Denied > 0 {
+		line += fmt.Sprintf(", %d calls skipped by budget", u.Denied)
+	}
+	return line
+}
+
+// String renders a one-line summary with cost for the console.
+func (r MindUsageReport) String() string {
+	return fmt.Sprintf("%s; $%.4f", MindUsageLine(r.Usage), r.CostUSD)
+}
+
+// MindSessionRecord is the persisted usage of one finished session.
+type MindSessionRecord struct {
+	ID        string             `json:"id"`
+	StartedAt time.Time          `json:"started_at"`
+	EndedAt   time.Time          `json:"ended_at"`
+	Model     string             `json:"model"`
+	Triggers  []MindTriggerUsage `json:"triggers"`
+	CostUSD   float64            `json:"cost_usd"`
+}
+
+// MindTriggerUsage is the token accounting for one trigger within a session.
+type MindTriggerUsage struct {
+	Trigger      MindTrigger `json:"trigger"`
+	Calls        int         `json:"calls"`
+	InputTokens  int         `json:"input_tokens"`
+	OutputTokens int         `json:"output_tokens"`
+	Denied       int         `json:"denied"`
+}
+
+// SessionRecord converts the report into a persisted session record, with
+// triggers in name order.
+func (r MindUsageReport) SessionRecord(model string, ended time.Time) MindSessionRecord {
+	rec := MindSessionRecord{
+		ID:        r.Started.UTC().Format("20060102T150405.000Z"),
+		StartedAt: r.Started,
+		EndedAt:   ended,
+		Model:     model,
+		CostUSD:   r.CostUSD,
+	}
+	for t, u := range r.ByTrigger {
+		rec.Triggers = append(rec.Triggers, MindTriggerUsage{
+			Trigger:      t,
+			Calls:        u.Calls,
+			InputTokens:  u.InputTokens,
+			OutputTokens: u.OutputTokens,
+			Denied:       u.Denied,
+		})
+	}
+	sort.Slice(rec.Triggers, func(i, j int) bool { return rec.Triggers[i].Trigger < rec.Triggers[j].Trigger })
+	return rec
+}
+
+// AppendMindSession adds a session record to the JSON-lines file at path,
+// creating it if needed.
+func AppendMindSession(path string, rec MindSessionRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encoding mind session: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening mind session file: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing mind session file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing mind session file: %w", err)
+	}
+	return nil
+}
+
+// LoadMindSessions reads the records written by AppendMindSession, oldest
+// first. A missing file holds no sessions.
+func LoadMindSessions(path string) ([]MindSessionRecord, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading mind session file: %w", err)
+	}
+	var out []MindSessionRecord
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var rec MindSessionRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("decoding mind session line %d: %w", line, err)
+		}
+		out = append(out, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading mind session file: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,239 @@
+package infrastructure
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/consciousness"
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+const (
+	DefaultMindTimeout = 20 * time.Second
+	// DefaultStaleAfter is the sim seconds after which a late reply's action is dropped.
+	DefaultStaleAfter int64 = 30
+)
+
+// AsyncMindConfig enables the asynchronous mind pipeline: requests are dispatched
+// with a snapshot while biology keeps ticking, and replies are applied on arrival.
+// Zero fields use the defaults above.
+type AsyncMindConfig struct {
+	// Timeout bounds one request including its corrective retries.
+	Timeout    time.Duration
+	StaleAfter int64
+}
+
+// MindArrival describes an asynchronous reply applied on this tick.
+type MindArrival struct {
+	DispatchTick int
+	// AgeSeconds is the sim time between dispatch and arrival.
+	AgeSeconds int64
+	Trigger    MindTrigger
+}
+
+// mindSnapshot is what the world looked like when a request was dispatched.
+type mindSnapshot struct {
+	tick       int
+	nowSeconds int64
+	goal       motivation.Drive
+	trigger    MindTrigger
+}
+
+type mindReply struct {
+	raw      string
+	parsed   consciousness.ParsedResponse
+	retries  int
+	err      error
+	snapshot mindSnapshot
+	// somatic is the blind evaluation of the narrative, nil when not evaluated.
+	somatic    []biology.BioPulse
+	somaticErr error
+}
+
+// mindFlight is a dispatched request. done is buffered, so a reply sent after
+// the flight was dropped is discarded instead of blocking its goroutine.
+type mindFlight struct {
+	cancel   context.CancelFunc
+	done     chan mindReply
+	deadline time.Time
+	snapshot mindSnapshot
+}
+
+// expired turns the flight into a timeout reply once its deadline has passed,
+// even if the responder ignores ctx and never answers.
+func (f *mindFlight) expired(now time.Time) (*mindReply, bool) {
+	if now.Before(f.deadline) {
+		return nil, false
+	}
+	f.cancel()
+	return &mindReply{err: context.DeadlineExceeded, snapshot: f.snapshot}, true
+}
+
+// consultNow runs the mind synchronously.
+func (l *SimulationLoop) consultNow(req MindRequest, snapshot mindSnapshot) *mindReply {
+	raw, parsed, retries, err := l.respond(context.Background(), req, req.PriorParsed)
+	reply := &mindReply{raw: raw, parsed: parsed, retries: retries, err: err, snapshot: snapshot}
+	l.evaluate(context.Background(), reply)
+	return reply
+}
+
+// dispatch starts an asynchronous request. Callers must ensure none is in flight.
+func (l *SimulationLoop) dispatch(req MindRequest, snapshot mindSnapshot) {
+	timeout := l.async.Timeout
+	if timeout <= 0 {
+		timeout = DefaultMindTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	flight := &mindFlight{cancel: cancel, done: make(chan mindReply, 1), deadline: deadline, snapshot: snapshot}
+	l.inflight = flight
+
+	go func() {
+		defer cancel()
+		raw, parsed, retries, err := l.respond(ctx, req, req.PriorParsed)
+		if err == nil {
+			err = ctx.Err()
+		}
+		reply := mindReply{raw: raw, parsed: parsed, retries: retries, err: err, snapshot: snapshot}
+		l.evaluate(ctx, &reply)
+		flight.done <- reply
+	}()
+}
+
+// evaluate runs the blind evaluator on a usable reply's narrative. It sees only
+// the narrative and the reported state, never the request.
+func (l *SimulationLoop) evaluate(ctx context.Context, reply *mindReply) {
+	if l.evaluator == nil || l.somatic == consciousness.SomaticStatePulse || reply.err != nil {
+		return
+	}
+	status := reply.parsed.Status
+	if status != consciousness.ParseStatusOK && status != consciousness.ParseStatusRecovered {
+		return
+	}
+	if strings.TrimSpace(reply.parsed.Narrative) == "" {
+		return
+	}
+	reply.somatic, reply.somaticErr = l.evaluator.Evaluate(ctx, reply.parsed.Narrative, reply.parsed.State)
+	if reply.somaticErr != nil {
+		reply.somatic = nil
+	}
+}
+
+// collect returns the in-flight reply if it has arrived, or a timeout error once
+// its deadline has passed, without blocking.
+func (l *SimulationLoop) collect() *mindReply {
+	if l.arrived != nil {
+		reply := l.arrived
+		l.arrived = nil
+		return reply
+	}
+	if l.inflight == nil {
+		return nil
+	}
+	select {
+	case reply := <-l.inflight.done:
+		l.inflight = nil
+		return &reply
+	default:
+	}
+	reply, ok := l.inflight.expired(time.Now())
+	if !ok {
+		return nil
+	}
+	l.inflight = nil
+	return reply
+}
+
+// AwaitMind blocks until the in-flight request answers, times out, or ctx ends.
+// The reply or timeout is applied on the next Tick. It reports false when
+// nothing was in flight or ctx ended first.
+func (l *SimulationLoop) AwaitMind(ctx context.Context) bool {
+	if l.inflight == nil {
+		return false
+	}
+	timer := time.NewTimer(time.Until(l.inflight.deadline))
+	defer timer.Stop()
+	select {
+	case reply := <-l.inflight.done:
+		l.inflight = nil
+		l.arrived = &reply
+		return true
+	case <-timer.C:
+		l.arrived, _ = l.inflight.expired(l.inflight.deadline)
+		l.inflight = nil
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// MindPending reports whether an asynchronous request is in flight.
+func (l *SimulationLoop) MindPending() bool {
+	return l.inflight != nil
+}
+
+// Close cancels any in-flight request and saves habituation to its store,
+// unless loading from the store failed.
+func (l *SimulationLoop) Close() error {
+	if l.inflight != nil {
+		l.inflight.cancel()
+		l.inflight = nil
+	}
+	if l.habituations == nil || l.habituation == nil || l.habituationUnloaded {
+		return nil
+	}
+	return l.habituations.Save(l.habituation)
+}
+
+// holdInput keeps operator input that arrives while a request is in flight,
+// so it triggers and reaches the next request instead of being lost.
+func (l *SimulationLoop) holdInput(in TickInput) {
+	l.held = mergeHeldInput(l.held, in)
+}
+
+// releaseInput merges held input into this tick's input.
+func (l *SimulationLoop) releaseInput(in TickInput) TickInput {
+	merged := mergeHeldInput(l.held, in)
+	merged.PreBioRates = in.PreBioRates
+	merged.PreBioPulses = in.PreBioPulses
+	merged.Stimuli = in.Stimuli
+	merged.World = in.World
+	merged.Environment = in.Environment
+	merged.NowSeconds = in.NowSeconds
+	merged.Commands = in.Commands
+	l.held = TickInput{}
+	return merged
+}
+
+func mergeHeldInput(held, in TickInput) TickInput {
+	switch {
+	case held.ExternalText == "":
+		held.ExternalText = in.ExternalText
+	case in.ExternalText != "":
+		held.ExternalText += "\n" + in.ExternalText
+	}
+	held.Speech = append(held.Speech, in.Speech...)
+	held.Heard = append(held.Heard, in.Heard...)
+	held.SocialContact = held.SocialContact || in.SocialContact
+	return held
+}
+
+// stale reports whether a late reply's action no longer makes sense: it is
+// older than StaleAfter, or the goal it served has been replaced and the
+// current goal does not offer it.
+func (l *SimulationLoop) stale(reply *mindReply, nowSeconds int64, goal motivation.Drive, offered []motivation.Action) bool {
+	if l.async == nil || reply.parsed.Action == "" {
+		return false
+	}
+	staleAfter := l.async.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+	if nowSeconds-reply.snapshot.nowSeconds > staleAfter {
+		return true
+	}
+	return reply.snapshot.goal != goal && !slices.Contains(offered, motivation.Action(reply.parsed.Action))
+}
@@ -0,0 +1,98 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/marczahn/person/v2/internal/consciousness"
+)
+
+// ParseFallback decides what a turn does when mind output stays unusable.
+type ParseFallback string
+
+const (
+	// FallbackReusePrior keeps the prior State/Action (the parser's own fallback).
+	FallbackReusePrior ParseFallback = "reuse_prior"
+	// FallbackNoop drops the stale action, emotional state and speech: the person does nothing.
+	FallbackNoop ParseFallback = "noop"
+)
+
+// ParseFailurePolicy controls how the loop reacts to unusable mind output.
+// The zero value reuses prior values without retrying.
+type ParseFailurePolicy struct {
+	// Retries is the number of corrective follow-up prompts before falling back.
+	Retries  int
+	Fallback ParseFallback
+}
+
+// respond asks the mind for this tick's response and applies the parse-failure policy.
+// It returns the last raw output, the final parse and the number of corrective retries sent.
+// An error from the first call is returned as is; a failing retry ends the retries.
+func (l *SimulationLoop) respond(
+	ctx context.Context,
+	req MindRequest,
+	prior consciousness.ParsedResponse,
+) (string, consciousness.ParsedResponse, int, error) {
+	raw, err := l.mind.Respond(ctx, req)
+	if err != nil {
+		return "", consciousness.ParsedResponse{}, 0, err
+	}
+	parsed := consciousness.ParseResponseWith(l.protocol, raw, prior)
+	original := parsed.Reason
+
+	retries := 0
+	for parsed.Status == consciousness.ParseStatusFailed && retries < l.parsePolicy.Retries {
+		retries++
+		req.Attempt = retries
+		req.Correction = consciousness.CorrectivePrompt(l.protocol, parsed.Reason)
+		retryRaw, err := l.mind.Respond(ctx, req)
+		if err != nil {
+			break
+		}
+		raw = retryRaw
+		parsed = consciousness.ParseResponseWith(l.protocol, raw, prior)
+		if parsed.Status == consciousness.ParseStatusOK {
+			parsed.Status = consciousness.ParseStatusRecovered
+			parsed.Reason = original
+		}
+	}
+
+	if parsed.Status == consciousness.ParseStatusFailed && l.parsePolicy.Fallback == FallbackNoop {
+		parsed.State = consciousness.ParsedState{}
+		parsed.Action = ""
+		parsed.DriveOverrides = nil
+		parsed.Speech = ""
+		parsed.Status = consciousness.ParseStatusNoop
+	}
+	if parsed.Status != consciousness.ParseStatusOK {
+		// Report why the first attempt failed, even after a failed retry.
+		parsed.Reason = original
+	}
+	return raw, parsed, retries, nil
+}
+
+func (m *SimulationMetrics) recordParse(parsed consciousness.ParsedResponse, retries int) {
+	m.MindTurns++
+	m.ParseRetries += retries
+	if parsed.Status == consciousness.ParseStatusOK {
+		return
+	}
+	if m.ParseFailures == nil {
+		m.ParseFailures = make(map[consciousness.ParseFailure]int)
+	}
+	m.ParseFailures[parsed.Reason]++
+	if parsed.Status == consciousness.ParseStatusRecovered {
+		m.ParseRecovered++
+	}
+}
+
+// ParseFailureRate returns the share of mind turns whose first output was unusable.
+func (m SimulationMetrics) ParseFailureRate() float64 {
+	if m.MindTurns == 0 {
+		return 0
+	}
+	failures := 0
+	for _, n := range m.ParseFailures {
+		failures += n
+	}
+	return float64(failures) / float64(m.MindTurns)
+}
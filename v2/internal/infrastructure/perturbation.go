@@ -0,0 +1,250 @@
+package infrastructure
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// Perturbation kinds.
+const (
+	// PerturbSet sets the target to Value.
+	PerturbSet = "set"
+	// PerturbAdd changes the target by Value once.
+	PerturbAdd = "add"
+	// PerturbRamp changes the target by Value in total, spread evenly over Over seconds.
+	PerturbRamp = "ramp"
+)
+
+// Perturbation forces a bio variable or chronic load on the simulation clock,
+// e.g. "set stress 0.9 at 120" or "ramp body_temp -2 over 300".
+type Perturbation struct {
+	Kind string
+	// Target is a bio field ("stress", "body_temp") or chronic load ("threat_load").
+	Target string
+	Value  float64
+	// At is the simulation second the perturbation starts; Over is a ramp's duration.
+	At   float64
+	Over float64
+	// Source attributes the resulting deltas ("operator", "script cold-night").
+	Source string
+}
+
+// Validate checks a perturbation before it is scheduled.
+func (p Perturbation) Validate() error {
+	if !perturbable(p.Target) {
+		return fmt.Errorf("perturbation: unknown target %q", p.Target)
+	}
+	if p.At < 0 || p.Over < 0 {
+		return fmt.Errorf("perturbation: times must not be negative")
+	}
+	switch p.Kind {
+	case PerturbSet, PerturbAdd:
+		if p.Over != 0 {
+			return fmt.Errorf("perturbation: only ramp takes a duration")
+		}
+	case PerturbRamp:
+		if p.Over == 0 {
+			return fmt.Errorf("perturbation: ramp requires a duration")
+		}
+	default:
+		return fmt.Errorf("perturbation: unknown kind %q", p.Kind)
+	}
+	return nil
+}
+
+func (p Perturbation) String() string {
+	s := fmt.Sprintf("%s %s %g", p.Kind, p.Target, p.Value)
+	if p.Kind == PerturbRamp {
+		s += fmt.Sprintf(" over %g", p.Over)
+	}
+	return s
+}
+
+// ParsePerturbation parses "<set|add|ramp> <target> <value> [over <seconds>] [at <seconds>]".
+func ParsePerturbation(spec string) (Perturbation, error) {
+	fields := strings.Fields(spec)
+	if len(fields) < 3 || len(fields)%2 == 0 {
+		return Perturbation{}, fmt.Errorf("perturbation %q: want \"<set|add|ramp> <target> <value> [over <seconds>] [at <seconds>]\"", spec)
+	}
+	p := Perturbation{Kind: strings.ToLower(fields[0]), Target: strings.ToLower(fields[1])}
+	var err error
+	if p.Value, err = strconv.ParseFloat(fields[2], 64); err != nil {
+		return Perturbation{}, fmt.Errorf("perturbation %q: value %q is not a number", spec, fields[2])
+	}
+	for i := 3; i < len(fields); i += 2 {
+		v, err := strconv.ParseFloat(fields[i+1], 64)
+		if err != nil {
+			return Perturbation{}, fmt.Errorf("perturbation %q: %s %q is not a number", spec, fields[i], fields[i+1])
+		}
+		switch strings.ToLower(fields[i]) {
+		case "over":
+			p.Over = v
+		case "at":
+			p.At = v
+		default:
+			return Perturbation{}, fmt.Errorf("perturbation %q: unknown clause %q", spec, fields[i])
+		}
+	}
+	if err := p.Validate(); err != nil {
+		return Perturbation{}, fmt.Errorf("%w (in %q)", err, spec)
+	}
+	return p, nil
+}
+
+func perturbable(target string) bool {
+	if _, ok := (biology.State{}).Field(target); ok {
+		return true
+	}
+	_, ok := (motivation.ChronicState{}).Field(target)
+	return ok
+}
+
+// AttributedDelta is a change a perturbation made, after clamping.
+type AttributedDelta struct {
+	biology.Delta
+	At     float64
+	Kind   string
+	Source string
+}
+
+type scheduledPerturbation struct {
+	seq int
+	Perturbation
+}
+
+// Perturber applies scheduled perturbations to the state a loop ticks.
+// Contract: its clock advances only with the loop's dt, so the same schedule
+// and dt sequence yield the same deltas; it is safe to schedule from another
+// goroutine while the loop ticks.
+type Perturber struct {
+	mu      sync.Mutex
+	clock   float64
+	seq     int
+	pending []scheduledPerturbation
+	history []AttributedDelta
+}
+
+// NewPerturber creates a perturber with nothing scheduled at clock 0.
+func NewPerturber() *Perturber {
+	return &Perturber{}
+}
+
+// Clock returns simulated seconds since the perturber started.
+func (p *Perturber) Clock() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.clock
+}
+
+// Schedule queues a perturbation. One due before the current clock starts on
+// the next tick; a ramp then still runs its full duration.
+func (p *Perturber) Schedule(pert Perturbation) error {
+	if err := pert.Validate(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pert.At = max(pert.At, p.clock)
+	p.pending = append(p.pending, scheduledPerturbation{seq: p.seq, Perturbation: pert})
+	p.seq++
+	sort.SliceStable(p.pending, func(i, j int) bool {
+		if p.pending[i].At != p.pending[j].At {
+			return p.pending[i].At < p.pending[j].At
+		}
+		return p.pending[i].seq < p.pending[j].seq
+	})
+	return nil
+}
+
+// Command schedules an operator's perturbation spec (see ParsePerturbation).
+// Without an "at" clause it starts on the next tick.
+func (p *Perturber) Command(spec, source string) (Perturbation, error) {
+	pert, err := ParsePerturbation(spec)
+	if err != nil {
+		return Perturbation{}, err
+	}
+	pert.Source = source
+	if err := p.Schedule(pert); err != nil {
+		return Perturbation{}, err
+	}
+	return pert, nil
+}
+
+// Pending returns the perturbations not yet finished, in start order.
+func (p *Perturber) Pending() []Perturbation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]Perturbation, len(p.pending))
+	for i, s := range p.pending {
+		out[i] = s.Perturbation
+	}
+	return out
+}
+
+// History returns every delta applied so far.
+func (p *Perturber) History() []AttributedDelta {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]AttributedDelta(nil), p.history...)
+}
+
+// Advance moves the clock by dt and applies what falls into the interval:
+// set and add once their start is reached, and a ramp's share of the overlap.
+func (p *Perturber) Advance(bio *biology.State, chronic *motivation.ChronicState, dt float64) []AttributedDelta {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	from := p.clock
+	p.clock += dt
+
+	var applied []AttributedDelta
+	kept := p.pending[:0]
+	for _, s := range p.pending {
+		if s.At > p.clock {
+			kept = append(kept, s)
+			continue
+		}
+		switch s.Kind {
+		case PerturbSet:
+			applied = append(applied, p.apply(s.Perturbation, bio, chronic, func(float64) float64 { return s.Value }))
+		case PerturbAdd:
+			applied = append(applied, p.apply(s.Perturbation, bio, chronic, func(v float64) float64 { return v + s.Value }))
+		case PerturbRamp:
+			end := s.At + s.Over
+			share := (min(end, p.clock) - max(s.At, from)) / s.Over
+			if share > 0 {
+				applied = append(applied, p.apply(s.Perturbation, bio, chronic, func(v float64) float64 { return v + s.Value*share }))
+			}
+			if end > p.clock {
+				kept = append(kept, s)
+			}
+		}
+	}
+	p.pending = kept
+	p.history = append(p.history, applied...)
+	return applied
+}
+
+func (p *Perturber) apply(pert Perturbation, bio *biology.State, chronic *motivation.ChronicState, next func(float64) float64) AttributedDelta {
+	before, ok := bio.Field(pert.Target)
+	after := before
+	if ok {
+		bio.SetField(pert.Target, next(before))
+		after, _ = bio.Field(pert.Target)
+	} else {
+		before, _ = chronic.Field(pert.Target)
+		chronic.SetField(pert.Target, next(before))
+		after, _ = chronic.Field(pert.Target)
+	}
+	return AttributedDelta{
+		Delta:  biology.Delta{Field: pert.Target, Amount: after - before},
+		At:     p.clock,
+		Kind:   pert.Kind,
+		Source: pert.Source,
+	}
+}
@@ -0,0 +1,213 @@
+package infrastructure_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/infrastructure"
+	"github.com/marczahn/person/v2/internal/motivation"
+	"github.com/marczahn/person/v2/internal/sense"
+)
+
+func TestParsePerturbation(t *testing.T) {
+	got, err := infrastructure.ParsePerturbation("ramp body_temp -2 over 300 at 60")
+	want := infrastructure.Perturbation{Kind: "ramp", Target: "body_temp", Value: -2, Over: 300, At: 60}
+	if err != nil || got != want {
+		t.Fatalf("got %+v, %v; want %+v", got, err, want)
+	}
+	if got, err := infrastructure.ParsePerturbation("SET threat_load 0.5"); err != nil || got.Kind != "set" || got.Target != "threat_load" {
+		t.Fatalf("chronic loads should be targets: %+v, %v", got, err)
+	}
+
+	for spec, wantErr := range map[string]string{
+		"set stress":                "want",
+		"nudge stress 1":            "unknown kind",
+		"set courage 1":             "unknown target",
+		"add stress much":           "not a number",
+		"ramp stress 0.5":           "requires a duration",
+		"add stress 0.5 over 10":    "only ramp",
+		"set stress 0.9 when 10":    "unknown clause",
+		"set stress 0.9 at -5":      "negative",
+		"ramp stress 0.5 over soon": "not a number",
+	} {
+		if _, err := infrastructure.ParsePerturbation(spec); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("%q: err = %v, want mention of %q", spec, err, wantErr)
+		}
+	}
+}
+
+func TestPerturber_AppliesOnTheSimulationClock(t *testing.T) {
+	p := infrastructure.NewPerturber()
+	for _, spec := range []string{"set stress 0.9 at 120", "ramp body_temp -2 over 300 at 60", "add threat_load 0.4 at 30"} {
+		pert, err := infrastructure.ParsePerturbation(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pert.Source = "test"
+		if err := p.Schedule(pert); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bio := *biology.NewDefaultState()
+	var chronic motivation.ChronicState
+	var deltas []infrastructure.AttributedDelta
+	for p.Clock() < 110 {
+		deltas = append(deltas, p.Advance(&bio, &chronic, 10)...)
+	}
+	if bio.Stress != 0.1 || chronic.ThreatLoad != 0.4 {
+		t.Fatalf("before 120s: stress=%v threat_load=%v", bio.Stress, chronic.ThreatLoad)
+	}
+	deltas = append(deltas, p.Advance(&bio, &chronic, 10)...)
+	if bio.Stress != 0.9 {
+		t.Fatalf("stress at 120s = %v, want 0.9", bio.Stress)
+	}
+	for p.Clock() < 400 {
+		deltas = append(deltas, p.Advance(&bio, &chronic, 10)...)
+	}
+	if math.Abs(bio.BodyTemp-34.6) > 1e-9 {
+		t.Fatalf("body_temp after the ramp = %v, want 34.6", bio.BodyTemp)
+	}
+	if len(p.Pending()) != 0 {
+		t.Fatalf("everything should have run, pending %v", p.Pending())
+	}
+
+	ramped, steps := 0.0, 0
+	for _, d := range deltas {
+		if d.Source != "test" {
+			t.Errorf("delta %+v lost its source", d)
+		}
+		if d.Field == "body_temp" {
+			ramped += d.Amount
+			steps++
+			if d.At <= 60 || d.At > 360 {
+				t.Errorf("ramp delta at %v, outside 60..360", d.At)
+			}
+		}
+	}
+	if steps != 30 || math.Abs(ramped+2) > 1e-9 {
+		t.Errorf("ramp should spread -2 over 30 ticks, got %v over %d", ramped, steps)
+	}
+	if got := p.History(); len(got) != len(deltas) {
+		t.Errorf("history has %d deltas, ticks returned %d", len(got), len(deltas))
+	}
+}
+
+func TestPerturber_RecordsClampedChange(t *testing.T) {
+	p := infrastructure.NewPerturber()
+	if _, err := p.Command("add stress 5", "operator"); err != nil {
+		t.Fatal(err)
+	}
+	bio := *biology.NewDefaultState()
+	var chronic motivation.ChronicState
+	deltas := p.Advance(&bio, &chronic, 1)
+	if len(deltas) != 1 || math.Abs(deltas[0].Amount-0.9) > 1e-9 || deltas[0].Kind != "add" {
+		t.Fatalf("deltas = %+v, want one add of 0.9 after clamping", deltas)
+	}
+}
+
+func TestPerturber_CommandStartsOnTheNextTick(t *testing.T) {
+	p := infrastructure.NewPerturber()
+	bio := *biology.NewDefaultState()
+	var chronic motivation.ChronicState
+	p.Advance(&bio, &chronic, 100)
+
+	pert, err := p.Command("ramp mood -0.2 over 20", "operator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pert.At != 0 || pert.Source != "operator" {
+		t.Fatalf("command = %+v", pert)
+	}
+	if pending := p.Pending(); len(pending) != 1 || pending[0].At != 100 {
+		t.Fatalf("a past start should move to now, pending %+v", pending)
+	}
+	p.Advance(&bio, &chronic, 10)
+	p.Advance(&bio, &chronic, 10)
+	if math.Abs(bio.Mood-0.3) > 1e-9 {
+		t.Fatalf("mood = %v, want the full ramp to 0.3", bio.Mood)
+	}
+	if _, err := p.Command("set stress", "operator"); err == nil {
+		t.Error("expected an error for a malformed command")
+	}
+}
+
+func TestSimulationLoop_PerturbsBeforeBiology(t *testing.T) {
+	perturber := infrastructure.NewPerturber()
+	motivationComputer := &fakeMotivationComputer{}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:         &fakeInputDrainer{},
+		Biology:       &fakeBioEngine{},
+		Motivation:    motivationComputer,
+		Mind:          &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: none] Hm."},
+		Perturbations: perturber,
+	})
+	defer loop.Close()
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+
+	if _, err := perturber.Command("set stress 0.8", "operator"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := perturber.Command("set isolation_load 0.7", "operator"); err != nil {
+		t.Fatal(err)
+	}
+	result := loop.Tick(&state, 1)
+
+	if len(result.Perturbations) != 2 || result.Perturbations[0].Field != "stress" {
+		t.Fatalf("perturbations = %+v", result.Perturbations)
+	}
+	if motivationComputer.seenBio.Stress != 0.8 || state.Chronic.IsolationLoad != 0.7 {
+		t.Errorf("motivation saw stress %v, chronic isolation %v", motivationComputer.seenBio.Stress, state.Chronic.IsolationLoad)
+	}
+	if next := loop.Tick(&state, 1); len(next.Perturbations) != 0 {
+		t.Errorf("a set applies once, got %+v", next.Perturbations)
+	}
+}
+
+func TestInputAdapter_PerturbCommandSchedulesOnThePerturber(t *testing.T) {
+	perturber := infrastructure.NewPerturber()
+	adapter := infrastructure.NewInputAdapter(sense.NewParser(), func() int64 { return 0 })
+	adapter.HandlePerturbations(perturber)
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:         adapter,
+		Biology:       &fakeBioEngine{},
+		Motivation:    &fakeMotivationComputer{},
+		Mind:          &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: none] Hm."},
+		Perturbations: perturber,
+	})
+	defer loop.Close()
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+
+	adapter.Enqueue("/perturb set stress 0.7")
+	adapter.Enqueue("hello")
+	adapter.Enqueue("/perturb nudge stress 1")
+	adapter.Enqueue("/perturbed is just speech")
+	result := loop.Tick(&state, 1)
+
+	cmds := result.Input.Commands
+	if len(cmds) != 2 || cmds[0].Err != nil || cmds[0].Reply != "scheduled set stress 0.7" {
+		t.Fatalf("commands = %+v", cmds)
+	}
+	if cmds[1].Err == nil || !strings.Contains(cmds[1].Err.Error(), "unknown kind") {
+		t.Errorf("a bad spec should be answered with its error, got %+v", cmds[1])
+	}
+	if want := []string{"hello", "/perturbed is just speech"}; !reflect.DeepEqual(result.Input.Speech, want) {
+		t.Errorf("the person should hear only speech, got %q", result.Input.Speech)
+	}
+	if len(result.Perturbations) != 1 || result.Perturbations[0].Source != "operator" || state.Bio.Stress != 0.7 {
+		t.Errorf("the command should apply this tick: %+v, stress %v", result.Perturbations, state.Bio.Stress)
+	}
+}
+
+func TestInputAdapter_PerturbCommandWithoutPerturber(t *testing.T) {
+	adapter := infrastructure.NewInputAdapter(sense.NewParser(), func() int64 { return 0 })
+	adapter.Enqueue("/perturb add stress 0.1")
+
+	in := adapter.Drain()
+	if len(in.Commands) != 1 || in.Commands[0].Err == nil || len(in.Speech) != 0 {
+		t.Errorf("an unrouted command should fail and not be heard: %+v", in)
+	}
+}
@@ -0,0 +1,335 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/consciousness"
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// Social exchange responses.
+const (
+	ResponseAnswered = "answered"
+	ResponseIgnored  = "ignored"
+)
+
+// rebuffPulse is what reaching out to someone who does not respond costs.
+var rebuffPulse = []biology.BioPulse{
+	{Field: "mood", Amount: -0.03},
+	{Field: "stress", Amount: 0.02},
+}
+
+// SocialExchange is one person reaching out to another.
+type SocialExchange struct {
+	From  string
+	To    string
+	Round int
+	// Response is empty while the exchange awaits the other person's next tick.
+	Response string
+}
+
+// AgentTick is one agent's part of a population round.
+type AgentTick struct {
+	Name string
+	// Location is where the agent was during the tick; empty without a world model.
+	Location string
+	Result   TickResult
+}
+
+// PopulationTick is one round: every agent ticked once, in the order added.
+type PopulationTick struct {
+	Round  int
+	Agents []AgentTick
+	// Exchanges are the social exchanges opened or resolved this round.
+	Exchanges []SocialExchange
+}
+
+type agent struct {
+	name     string
+	loop     *SimulationLoop
+	state    *SimulationState
+	lines    LineEnqueuer
+	inbox    *socialInbox
+	location string
+	// outbox holds lines for this agent until the next round starts.
+	outbox []string
+}
+
+// socialInbox wraps an agent's drainer and adds pulses from other agents.
+type socialInbox struct {
+	base   InputDrainer
+	pulses []biology.BioPulse
+}
+
+func (in *socialInbox) Drain() TickInput {
+	out := in.base.Drain()
+	if len(in.pulses) > 0 {
+		out.PreBioPulses = append(append([]biology.BioPulse(nil), out.PreBioPulses...), in.pulses...)
+		in.pulses = nil
+	}
+	return out
+}
+
+// Population hosts several persons in a shared world. Speech heard by others
+// and reach_out actions become input lines for the other agents, and reaching
+// out relieves social deficit only when the other person answers on their
+// next tick by speaking or reaching back.
+// Contract: agents tick in the order added, and everything one agent causes in
+// a round reaches the others at the start of the next round, so the outcome
+// of a round does not depend on where in the order an agent ticks, apart from
+// who takes the last unit of a shared stock.
+type Population struct {
+	world   *WorldModel
+	agents  []*agent
+	index   map[string]int
+	round   int
+	pending []SocialExchange
+}
+
+// NewPopulation creates an empty population. A nil world puts everyone in one
+// room where everybody can hear and reach everybody.
+func NewPopulation(world *WorldModel) *Population {
+	return &Population{world: world, index: make(map[string]int)}
+}
+
+// Add builds an agent's loop from deps. deps.Input is the agent's own input
+// (an InputAdapter, possibly under a ScenarioInjector); lines is where other
+// agents' speech and actions are enqueued, usually the same InputAdapter.
+// With a world model the agent starts at location, or at the world's current
+// location when empty; state.Places is managed by the population.
+func (p *Population) Add(name string, deps SimulationLoopDeps, state *SimulationState, lines LineEnqueuer, location string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("agent name must not be empty")
+	}
+	if _, dup := p.index[name]; dup {
+		return fmt.Errorf("duplicate agent %q", name)
+	}
+	if state == nil || lines == nil || deps.Input == nil {
+		return fmt.Errorf("agent %q requires input, state and a line enqueuer", name)
+	}
+	if p.world != nil {
+		if location == "" {
+			location = p.world.Here().Name
+		}
+		if _, ok := p.world.Location(location); !ok {
+			return fmt.Errorf("agent %q: unknown location %q", name, location)
+		}
+		state.Places = p.world
+	}
+	inbox := &socialInbox{base: deps.Input}
+	deps.Input = inbox
+	deps.DeferSocialRelief = true
+	p.index[name] = len(p.agents)
+	p.agents = append(p.agents, &agent{
+		name:     name,
+		loop:     NewSimulationLoop(deps),
+		state:    state,
+		lines:    lines,
+		inbox:    inbox,
+		location: location,
+	})
+	return nil
+}
+
+// Names returns the agents in tick order.
+func (p *Population) Names() []string {
+	names := make([]string, len(p.agents))
+	for i, a := range p.agents {
+		names[i] = a.name
+	}
+	return names
+}
+
+// Location returns where an agent is; empty without a world model.
+func (p *Population) Location(name string) string {
+	if i, ok := p.index[name]; ok {
+		return p.agents[i].location
+	}
+	return ""
+}
+
+// Close stops every agent's loop and reports every failure to save.
+func (p *Population) Close() error {
+	var errs []error
+	for _, a := range p.agents {
+		errs = append(errs, a.loop.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Tick runs one round of dt seconds for every agent.
+func (p *Population) Tick(dt float64) PopulationTick {
+	p.round++
+	tick := PopulationTick{Round: p.round}
+
+	// Deliver last round's lines, and take company from where everyone stood
+	// when the round began.
+	company := make([][]string, len(p.agents))
+	for i, a := range p.agents {
+		for _, line := range a.outbox {
+			a.lines.Enqueue(line)
+		}
+		a.outbox = nil
+		company[i] = p.companyOf(i)
+	}
+
+	results := make([]TickResult, len(p.agents))
+	for i, a := range p.agents {
+		if p.world != nil {
+			_ = p.world.MoveTo(a.location)
+			p.world.SetCompany(company[i])
+		}
+		results[i] = a.loop.Tick(a.state, dt)
+		if p.world != nil {
+			a.location = p.world.Here().Name
+			p.world.SetCompany(nil)
+		}
+		tick.Agents = append(tick.Agents, AgentTick{Name: a.name, Location: a.location, Result: results[i]})
+	}
+
+	settled := p.resolve(results)
+	tick.Exchanges = append(tick.Exchanges, settled...)
+	tick.Exchanges = append(tick.Exchanges, p.exchange(results, company, settled)...)
+	return tick
+}
+
+// companyOf lists the other agents sharing agent i's location, in tick order.
+func (p *Population) companyOf(i int) []string {
+	var names []string
+	for j, other := range p.agents {
+		if j != i && other.location == p.agents[i].location {
+			names = append(names, other.name)
+		}
+	}
+	return names
+}
+
+// resolve settles last round's exchanges by how the addressed agent acted.
+func (p *Population) resolve(results []TickResult) []SocialExchange {
+	var settled []SocialExchange
+	for _, ex := range p.pending {
+		to := p.index[ex.To]
+		ex.Response = ResponseIgnored
+		if answered(results[to]) {
+			ex.Response = ResponseAnswered
+		}
+		p.settle(ex)
+		settled = append(settled, ex)
+	}
+	p.pending = nil
+	return settled
+}
+
+// exchange turns this round's heard speech and reach_outs into input for the
+// others. A reach_out that answered someone closes that exchange; any other
+// opens one with the agent target picks. Two agents
+// reaching for each other in the same round answer each other.
+func (p *Population) exchange(results []TickResult, company [][]string, settled []SocialExchange) []SocialExchange {
+	var opened []SocialExchange
+	for i, a := range p.agents {
+		r := results[i]
+		if r.Speech != nil && r.Speech.Heard {
+			for _, name := range company[i] {
+				other := p.agents[p.index[name]]
+				other.outbox = append(other.outbox, fmt.Sprintf("%s: %s", a.name, r.Speech.Text))
+			}
+		}
+		if !reachedOut(r) || answeredSomeone(settled, a.name) {
+			continue
+		}
+		target, ok := p.target(i, company[i])
+		if !ok {
+			continue
+		}
+		other := p.agents[p.index[target]]
+		other.outbox = append(other.outbox, fmt.Sprintf("*%s turns to you, wanting to talk*", a.name))
+		opened = append(opened, SocialExchange{From: a.name, To: target, Round: p.round})
+	}
+
+	var out []SocialExchange
+	for i, ex := range opened {
+		if mirror, ok := mutual(opened, i); ok {
+			ex.Response = ResponseAnswered
+			// Each pair relieves both sides once, through its first half.
+			if mirror > i {
+				p.settle(ex)
+			}
+		} else {
+			p.pending = append(p.pending, ex)
+		}
+		out = append(out, ex)
+	}
+	return out
+}
+
+// target picks who agent i reaches: the present agent whose name follows i's
+// alphabetically, wrapping around, so the choice does not depend on tick order.
+func (p *Population) target(i int, company []string) (string, bool) {
+	if len(company) == 0 {
+		return "", false
+	}
+	self := p.agents[i].name
+	names := append([]string(nil), company...)
+	sort.Strings(names)
+	for _, name := range names {
+		if name > self {
+			return name, true
+		}
+	}
+	return names[0], true
+}
+
+// settle queues the exchange's outcome for both agents' next drain.
+func (p *Population) settle(ex SocialExchange) {
+	from := p.agents[p.index[ex.From]]
+	if ex.Response != ResponseAnswered {
+		from.inbox.pulses = append(from.inbox.pulses, rebuffPulse...)
+		return
+	}
+	relief := consciousness.ActionPulse(consciousness.ActionOutcome{
+		Action:    string(motivation.ActionReachOut),
+		Executed:  true,
+		Satisfied: true,
+	})
+	from.inbox.pulses = append(from.inbox.pulses, relief...)
+	to := p.agents[p.index[ex.To]]
+	to.inbox.pulses = append(to.inbox.pulses, relief...)
+}
+
+func answeredSomeone(settled []SocialExchange, name string) bool {
+	for _, ex := range settled {
+		if ex.To == name && ex.Response == ResponseAnswered {
+			return true
+		}
+	}
+	return false
+}
+
+// mutual returns the index of the exchange that mirrors opened[i], if any.
+func mutual(opened []SocialExchange, i int) (int, bool) {
+	for j, ex := range opened {
+		if j != i && ex.From == opened[i].To && ex.To == opened[i].From {
+			return j, true
+		}
+	}
+	return 0, false
+}
+
+// answered reports whether a tick responds to being addressed: saying
+// something aloud or reaching out.
+func answered(r TickResult) bool {
+	return (r.Speech != nil && r.Speech.Text != "") || reachedOut(r)
+}
+
+func reachedOut(r TickResult) bool {
+	action := string(motivation.ActionReachOut)
+	if r.ActionOutcome.Executed && r.ActionOutcome.Action == action {
+		return true
+	}
+	return r.QueuedOutcome != nil && r.QueuedOutcome.Executed && r.QueuedOutcome.Action == action
+}
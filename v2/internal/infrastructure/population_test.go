@@ -0,0 +1,191 @@
+package infrastructure_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/consciousness"
+	"github.com/marczahn/person/v2/internal/infrastructure"
+	"github.com/marczahn/person/v2/internal/sense"
+)
+
+const (
+	reachOutReply = "[STATE: arousal=0.0, valence=0.0] [ACTION: reach_out] I turn to them."
+	silentReply   = "[STATE: arousal=0.0, valence=0.0] [ACTION: none] I keep to myself."
+	answerReply   = "[STATE: arousal=0.0, valence=0.0] [ACTION: none] [SPEECH: Oh, hi!] Someone wants to talk."
+)
+
+type populationAgent struct {
+	name  string
+	mind  *fakeMind
+	input *infrastructure.InputAdapter
+	state *infrastructure.SimulationState
+}
+
+func addAgent(t *testing.T, p *infrastructure.Population, name, reply, location string) *populationAgent {
+	t.Helper()
+	a := &populationAgent{
+		name:  name,
+		mind:  &fakeMind{raw: reply},
+		input: infrastructure.NewInputAdapter(sense.NewParser(), func() int64 { return 0 }),
+		state: &infrastructure.SimulationState{Bio: *biology.NewDefaultState()},
+	}
+	a.state.Bio.SocialDeficit = 0.6
+	err := p.Add(name, infrastructure.SimulationLoopDeps{
+		Input:      a.input,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       a.mind,
+		Activities: consciousness.ActivityCatalog{},
+	}, a.state, a.input, location)
+	if err != nil {
+		t.Fatalf("Add(%s): %v", name, err)
+	}
+	return a
+}
+
+func TestPopulation_ReliefDependsOnTheResponse(t *testing.T) {
+	for _, tc := range []struct {
+		reply    string
+		response string
+		relieved bool
+	}{
+		{answerReply, infrastructure.ResponseAnswered, true},
+		{silentReply, infrastructure.ResponseIgnored, false},
+	} {
+		p := infrastructure.NewPopulation(nil)
+		ana := addAgent(t, p, "ana", reachOutReply, "")
+		ben := addAgent(t, p, "ben", tc.reply, "")
+
+		first := p.Tick(1)
+		if len(first.Exchanges) != 1 || first.Exchanges[0].From != "ana" || first.Exchanges[0].To != "ben" || first.Exchanges[0].Response != "" {
+			t.Fatalf("round 1 exchanges = %+v", first.Exchanges)
+		}
+		if ana.state.Bio.SocialDeficit != 0.6 {
+			t.Fatalf("reaching out alone must not relieve: deficit=%v", ana.state.Bio.SocialDeficit)
+		}
+
+		ana.mind.raw = silentReply
+		second := p.Tick(1)
+		if !strings.Contains(second.Agents[1].Result.Input.ExternalText, "ana turns to you") {
+			t.Errorf("ben should perceive ana in round 2, got %q", second.Agents[1].Result.Input.ExternalText)
+		}
+		if len(second.Exchanges) != 1 || second.Exchanges[0].Response != tc.response {
+			t.Fatalf("round 2 exchanges = %+v, want %s", second.Exchanges, tc.response)
+		}
+
+		p.Tick(1)
+		relieved := ana.state.Bio.SocialDeficit < 0.6
+		if relieved != tc.relieved {
+			t.Errorf("%s: ana deficit=%v, relieved=%v want %v", tc.response, ana.state.Bio.SocialDeficit, relieved, tc.relieved)
+		}
+		if tc.relieved {
+			if ben.state.Bio.SocialDeficit >= 0.6 {
+				t.Error("answering should relieve the answerer too")
+			}
+			if !strings.Contains(p.Tick(1).Agents[0].Result.Input.ExternalText, "ben: Oh, hi!") {
+				t.Error("ben's answer should be heard by ana")
+			}
+		} else if ana.state.Bio.Mood >= 0.5 {
+			t.Errorf("being ignored should cost mood, got %v", ana.state.Bio.Mood)
+		}
+		p.Close()
+	}
+}
+
+func TestPopulation_MutualReachOutAnswersAtOnce(t *testing.T) {
+	p := infrastructure.NewPopulation(nil)
+	ana := addAgent(t, p, "ana", reachOutReply, "")
+	ben := addAgent(t, p, "ben", reachOutReply, "")
+	round := p.Tick(1)
+	if len(round.Exchanges) != 2 || round.Exchanges[0].Response != infrastructure.ResponseAnswered {
+		t.Fatalf("exchanges = %+v", round.Exchanges)
+	}
+	ana.mind.raw, ben.mind.raw = silentReply, silentReply
+	if next := p.Tick(1); len(next.Exchanges) != 0 {
+		t.Errorf("nothing should stay pending, got %+v", next.Exchanges)
+	}
+	// One answered exchange relieves each side once, as a normal answer does.
+	if math.Abs(ana.state.Bio.SocialDeficit-0.4) > 1e-9 || math.Abs(ben.state.Bio.SocialDeficit-0.4) > 1e-9 {
+		t.Errorf("both should be relieved exactly once: ana=%v ben=%v", ana.state.Bio.SocialDeficit, ben.state.Bio.SocialDeficit)
+	}
+}
+
+func TestPopulation_RoundsDoNotDependOnTickOrder(t *testing.T) {
+	run := func(order []string) map[string][]string {
+		p := infrastructure.NewPopulation(nil)
+		defer p.Close()
+		replies := map[string]string{"ana": reachOutReply, "ben": answerReply, "cem": silentReply}
+		agents := map[string]*populationAgent{}
+		for _, name := range order {
+			agents[name] = addAgent(t, p, name, replies[name], "")
+		}
+		seen := map[string][]string{}
+		for r := 0; r < 3; r++ {
+			for _, at := range p.Tick(1).Agents {
+				seen[at.Name] = append(seen[at.Name], at.Result.Input.ExternalText)
+			}
+		}
+		return seen
+	}
+	forward := run([]string{"ana", "ben", "cem"})
+	if forward["ben"][0] != "" || forward["cem"][0] != "" {
+		t.Fatalf("nothing may arrive within the round it was caused: %+v", forward)
+	}
+	if !strings.Contains(forward["ben"][1], "ana turns to you") {
+		t.Fatalf("ana should reach ben, the next name after hers: %+v", forward)
+	}
+	if backward := run([]string{"cem", "ben", "ana"}); !reflect.DeepEqual(forward, backward) {
+		t.Errorf("tick order changed what agents perceived:\n%+v\n%+v", forward, backward)
+	}
+}
+
+func TestPopulation_SharedWorldSeparatesAgents(t *testing.T) {
+	p := infrastructure.NewPopulation(flat())
+	ana := addAgent(t, p, "ana", reachOutReply, "bedroom")
+	addAgent(t, p, "ben", answerReply, "hallway")
+
+	round := p.Tick(1)
+	if round.Agents[0].Result.ActionOutcome.Executed {
+		t.Errorf("nobody is in the bedroom to reach: %+v", round.Agents[0].Result.ActionOutcome)
+	}
+	if len(round.Exchanges) != 0 {
+		t.Errorf("no exchange without company, got %+v", round.Exchanges)
+	}
+	if strings.Contains(round.Agents[0].Result.Prompt.Surroundings, "ben") {
+		t.Error("ben is not in the bedroom")
+	}
+
+	ana.mind.raw = "[STATE: arousal=0.0, valence=0.0] [ACTION: move] I go and find someone."
+	p.Tick(1)
+	if p.Location("ana") != "hallway" {
+		t.Fatalf("ana should have moved to the hallway, is in %q", p.Location("ana"))
+	}
+	ana.mind.raw = reachOutReply
+	round = p.Tick(1)
+	if !strings.Contains(round.Agents[0].Result.Prompt.Surroundings, "ben is here.") {
+		t.Errorf("surroundings = %q", round.Agents[0].Result.Prompt.Surroundings)
+	}
+	if len(round.Exchanges) != 1 || round.Exchanges[0].To != "ben" {
+		t.Errorf("ana should reach ben in the hallway, got %+v", round.Exchanges)
+	}
+}
+
+func TestPopulation_RejectsBadAgents(t *testing.T) {
+	p := infrastructure.NewPopulation(flat())
+	addAgent(t, p, "ana", silentReply, "")
+	input := infrastructure.NewInputAdapter(sense.NewParser(), nil)
+	deps := infrastructure.SimulationLoopDeps{Input: input, Biology: &fakeBioEngine{}, Motivation: &fakeMotivationComputer{}, Mind: &fakeMind{}}
+	if err := p.Add("ana", deps, &infrastructure.SimulationState{}, input, ""); err == nil {
+		t.Error("duplicate names should be rejected")
+	}
+	if err := p.Add("ben", deps, &infrastructure.SimulationState{}, input, "attic"); err == nil {
+		t.Error("unknown locations should be rejected")
+	}
+	if got := p.Names(); !reflect.DeepEqual(got, []string{"ana"}) {
+		t.Errorf("Names = %v", got)
+	}
+}
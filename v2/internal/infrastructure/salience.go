@@ -0,0 +1,155 @@
+package infrastructure
+
+import (
+	"math"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+const (
+	DefaultSalienceDriveDelta = 0.10
+	DefaultSalienceBioDelta   = 0.08
+)
+
+// MindTrigger names why the mind was consulted on a tick.
+type MindTrigger string
+
+const (
+	// TriggerAlways: no salience gate is configured, the mind runs every tick.
+	TriggerAlways       MindTrigger = "always"
+	TriggerFirst        MindTrigger = "first"
+	TriggerInput        MindTrigger = "operator_input"
+	TriggerThreshold    MindTrigger = "threshold"
+	TriggerInterruption MindTrigger = "interruption"
+	TriggerSpontaneous  MindTrigger = "spontaneous"
+	TriggerDrive        MindTrigger = "drive_change"
+	TriggerBio          MindTrigger = "bio_change"
+)
+
+// SalienceGate decides which ticks are worth a mind call. Changes are measured
+// against what the mind saw last time it ran, so slow drifts add up until they matter.
+// Zero fields use the defaults above.
+type SalienceGate struct {
+	// DriveDelta is the urgency change of any drive that makes a tick salient.
+	DriveDelta float64
+	// BioDelta is the change of any bio variable (0-1 scale; body temp per 8°C).
+	BioDelta float64
+}
+
+// MindGateState is what the gate carries across ticks.
+type MindGateState struct {
+	// Seen is false until the mind has run once.
+	Seen bool
+	// Bio is the body at the end of the last consulted tick, so the direct effects
+	// of the mind's own action and feelings do not count as news.
+	Bio        biology.State
+	Motivation motivation.MotivationState
+	// Thresholds are the threshold tiers active on the previous tick.
+	Thresholds map[string]biology.Severity
+}
+
+// gateSignals is everything about the current tick the gate looks at.
+type gateSignals struct {
+	input       TickInput
+	thresholds  []biology.ThresholdEvent
+	bio         biology.State
+	motivation  motivation.MotivationState
+	interrupted bool
+	spontaneous bool
+}
+
+// evaluate reports whether the tick is salient and why. Threshold tracking advances
+// every tick; the drive baseline only moves when the mind runs (see settle for bio).
+func (g SalienceGate) evaluate(gs *MindGateState, s gateSignals) (MindTrigger, bool) {
+	onset := thresholdOnset(gs.Thresholds, s.thresholds)
+	gs.Thresholds = activeThresholds(s.thresholds)
+
+	trigger, salient := g.trigger(gs, s, onset)
+	if salient {
+		gs.Seen = true
+		gs.Motivation = s.motivation
+	}
+	return trigger, salient
+}
+
+// settle records the end-of-tick body after a consulted tick.
+func (gs *MindGateState) settle(bio biology.State) {
+	gs.Bio = bio
+}
+
+func (g SalienceGate) trigger(gs *MindGateState, s gateSignals, onset bool) (MindTrigger, bool) {
+	driveDelta := g.DriveDelta
+	if driveDelta <= 0 {
+		driveDelta = DefaultSalienceDriveDelta
+	}
+	bioDelta := g.BioDelta
+	if bioDelta <= 0 {
+		bioDelta = DefaultSalienceBioDelta
+	}
+
+	switch {
+	case !gs.Seen:
+		return TriggerFirst, true
+	case s.input.ExternalText != "" || len(s.input.Speech) > 0:
+		return TriggerInput, true
+	case onset:
+		return TriggerThreshold, true
+	case s.interrupted:
+		return TriggerInterruption, true
+	case s.spontaneous:
+		return TriggerSpontaneous, true
+	case maxDriveChange(gs.Motivation, s.motivation) >= driveDelta:
+		return TriggerDrive, true
+	case maxBioChange(gs.Bio, s.bio) >= bioDelta:
+		return TriggerBio, true
+	default:
+		return "", false
+	}
+}
+
+func thresholdOnset(previous map[string]biology.Severity, current []biology.ThresholdEvent) bool {
+	for _, ev := range current {
+		if prev, ok := previous[ev.Variable]; !ok || ev.Severity > prev {
+			return true
+		}
+	}
+	return false
+}
+
+func activeThresholds(events []biology.ThresholdEvent) map[string]biology.Severity {
+	if len(events) == 0 {
+		return nil
+	}
+	out := make(map[string]biology.Severity, len(events))
+	for _, ev := range events {
+		out[ev.Variable] = max(out[ev.Variable], ev.Severity)
+	}
+	return out
+}
+
+// maxDriveChange returns the largest urgency change across registered drives.
+// A switch of active goal always counts as a full change.
+func maxDriveChange(a, b motivation.MotivationState) float64 {
+	if a.ActiveGoalDrive != b.ActiveGoalDrive {
+		return 1
+	}
+	largest := 0.0
+	for _, d := range motivation.DefaultRegistry().Drives() {
+		largest = max(largest, math.Abs(a.Urgency(d)-b.Urgency(d)))
+	}
+	return largest
+}
+
+func maxBioChange(a, b biology.State) float64 {
+	return max(
+		math.Abs(a.Energy-b.Energy),
+		math.Abs(a.Stress-b.Stress),
+		math.Abs(a.CognitiveCapacity-b.CognitiveCapacity),
+		math.Abs(a.Mood-b.Mood),
+		math.Abs(a.PhysicalTension-b.PhysicalTension),
+		math.Abs(a.Hunger-b.Hunger),
+		math.Abs(a.SocialDeficit-b.SocialDeficit),
+		math.Abs(a.BodyTemp-b.BodyTemp)/8,
+	)
+}
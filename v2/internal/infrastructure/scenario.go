@@ -15,6 +15,9 @@ type ScenarioInjector struct {
 	mu        sync.Mutex
 	scenarios map[string][]string
 	active    string
+	// ended are scenarios switched off since the last drain; their ambient
+	// levels and resources return to defaults on the next drain.
+	ended []string
 }
 
 func NewScenarioInjector(base InputDrainer) *ScenarioInjector {
@@ -62,15 +65,44 @@ func (s *ScenarioInjector) Activate(name string) bool {
 	if _, ok := s.scenarios[trimmedName]; !ok {
 		return false
 	}
+	if s.active != "" && s.active != trimmedName {
+		s.ended = append(s.ended, s.active)
+	}
 	s.active = trimmedName
 	return true
 }
 
+// Deactivate switches the active scenario off. It reports whether one was active.
+func (s *ScenarioInjector) Deactivate() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active == "" {
+		return false
+	}
+	s.ended = append(s.ended, s.active)
+	s.active = ""
+	return true
+}
+
+// Active returns the active scenario name, empty when none is.
+func (s *ScenarioInjector) Active() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active
+}
+
 func (s *ScenarioInjector) Drain() TickInput {
 	out := s.base.Drain()
-	out.AllowedActions = cloneAllowedActions(out.AllowedActions)
 
-	active, descriptors := s.snapshotActiveScenario()
+	active, descriptors, ended := s.snapshotActiveScenario()
+	for _, descriptor := range ended {
+		var reset TickInput
+		applyEnvironmentInput(descriptor, &reset)
+		for _, change := range reset.Environment {
+			out.Environment = append(out.Environment, change.Reset())
+		}
+		out.World = mergeWorldPatch(resetWorldPatch(reset.World), out.World)
+	}
 	if active == "" {
 		return out
 	}
@@ -88,26 +120,22 @@ func (s *ScenarioInjector) Drain() TickInput {
 	return out
 }
 
-func (s *ScenarioInjector) snapshotActiveScenario() (string, []string) {
+func (s *ScenarioInjector) snapshotActiveScenario() (string, []string, []string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	var ended []string
+	for _, name := range s.ended {
+		if name != s.active {
+			ended = append(ended, s.scenarios[name]...)
+		}
+	}
+	s.ended = nil
 	if s.active == "" {
-		return "", nil
+		return "", nil, ended
 	}
 	descriptors, ok := s.scenarios[s.active]
 	if !ok {
-		return "", nil
+		return "", nil, ended
 	}
-	return s.active, append([]string(nil), descriptors...)
-}
-
-func cloneAllowedActions(in map[string]bool) map[string]bool {
-	if in == nil {
-		return defaultAllowedActions()
-	}
-	out := make(map[string]bool, len(in))
-	for action, allowed := range in {
-		out[action] = allowed
-	}
-	return out
+	return s.active, append([]string(nil), descriptors...), ended
 }
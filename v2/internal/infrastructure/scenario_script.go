@@ -0,0 +1,409 @@
+package infrastructure
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/biology"
+)
+
+// ScenarioScript is a timed experiment: steps that switch scenarios, inject
+// operator lines and perturb the person on the simulation clock, until an end
+// condition holds.
+type ScenarioScript struct {
+	Name  string
+	Steps []ScriptStep
+	End   ScriptEnd
+}
+
+// ScriptStep is one timed action. At is seconds after the script starts, or
+// after the branch point for steps inside a branch.
+type ScriptStep struct {
+	At float64
+	// Activate switches to a registered scenario; Deactivate switches the active one off.
+	Activate   string
+	Deactivate bool
+	// Inject enqueues raw operator lines ("*someone offers food*", "~dark").
+	Inject []string
+	// Perturb schedules perturbations starting at the step's time; their own At is ignored.
+	Perturb []Perturbation
+	// Every repeats the step every Every seconds; Times bounds how often it fires
+	// in total (0 repeats until the script ends).
+	Every float64
+	Times int
+	// Branches picks one weighted alternative when the step fires.
+	Branches []ScriptBranch
+}
+
+// ScriptBranch is one random alternative. Weights are relative; zero counts as one.
+type ScriptBranch struct {
+	Label  string
+	Weight float64
+	Steps  []ScriptStep
+}
+
+// ScriptEnd stops the script after a duration or once any condition holds.
+// A zero ScriptEnd runs until the steps are exhausted.
+type ScriptEnd struct {
+	After float64
+	When  []BioCondition
+}
+
+// BioCondition compares one biology field ("stress", "body_temp", ...) with a value.
+type BioCondition struct {
+	Field string
+	// Op is one of <, <=, >, >=.
+	Op    string
+	Value float64
+}
+
+// Holds reports whether the condition is true for bio.
+func (c BioCondition) Holds(bio biology.State) bool {
+	v, ok := bio.Field(c.Field)
+	if !ok {
+		return false
+	}
+	switch c.Op {
+	case "<":
+		return v < c.Value
+	case "<=":
+		return v <= c.Value
+	case ">":
+		return v > c.Value
+	case ">=":
+		return v >= c.Value
+	}
+	return false
+}
+
+func (c BioCondition) String() string {
+	return fmt.Sprintf("%s %s %g", c.Field, c.Op, c.Value)
+}
+
+// Validate checks a script before it runs.
+func (s ScenarioScript) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("script name must not be empty")
+	}
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("script %q requires at least one step", s.Name)
+	}
+	for _, c := range s.End.When {
+		if _, ok := (biology.State{}).Field(c.Field); !ok {
+			return fmt.Errorf("script %q: unknown end field %q", s.Name, c.Field)
+		}
+		if !validOp(c.Op) {
+			return fmt.Errorf("script %q: unknown operator %q", s.Name, c.Op)
+		}
+	}
+	return validateSteps(s.Name, s.Steps)
+}
+
+func validateSteps(name string, steps []ScriptStep) error {
+	for i, step := range steps {
+		if step.At < 0 || step.Every < 0 || step.Times < 0 {
+			return fmt.Errorf("script %q step %d: times must not be negative", name, i)
+		}
+		if step.Activate == "" && !step.Deactivate && len(step.Inject) == 0 && len(step.Perturb) == 0 && len(step.Branches) == 0 {
+			return fmt.Errorf("script %q step %d does nothing", name, i)
+		}
+		for _, p := range step.Perturb {
+			if err := p.Validate(); err != nil {
+				return fmt.Errorf("script %q step %d: %w", name, i, err)
+			}
+		}
+		for _, b := range step.Branches {
+			if b.Weight < 0 {
+				return fmt.Errorf("script %q step %d: branch %q has negative weight", name, i, b.Label)
+			}
+			if err := validateSteps(name, b.Steps); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validOp(op string) bool {
+	switch op {
+	case "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// LineEnqueuer accepts raw operator lines, like InputAdapter.
+type LineEnqueuer interface {
+	Enqueue(raw string)
+}
+
+// ScriptEvent records something the runner did.
+type ScriptEvent struct {
+	At     float64
+	Action string // "activate", "deactivate", "inject", "perturb", "branch" or "end"
+	Detail string
+}
+
+type scheduledStep struct {
+	at    float64
+	seq   int
+	fired int
+	step  ScriptStep
+}
+
+// ScriptRunner drives a ScenarioScript against the simulation clock.
+// Contract: the same script, seed and sequence of dt values fire the same
+// events in the same order.
+type ScriptRunner struct {
+	script    ScenarioScript
+	injector  *ScenarioInjector
+	input     LineEnqueuer
+	perturber *Perturber
+	rng       *rand.Rand
+
+	clock   float64
+	seq     int
+	queue   []scheduledStep
+	done    bool
+	reason  string
+	history []ScriptEvent
+}
+
+// NewScriptRunner prepares a validated script. Activate and Deactivate steps
+// require injector; Inject steps require input; Perturb steps require
+// perturber, which must be the one the loop ticks.
+func NewScriptRunner(script ScenarioScript, injector *ScenarioInjector, input LineEnqueuer, perturber *Perturber, seed int64) (*ScriptRunner, error) {
+	if err := script.Validate(); err != nil {
+		return nil, err
+	}
+	if injector == nil && usesScenarios(script.Steps) {
+		return nil, fmt.Errorf("script %q switches scenarios but has no scenario injector", script.Name)
+	}
+	if input == nil && injects(script.Steps) {
+		return nil, fmt.Errorf("script %q injects input but has no input adapter", script.Name)
+	}
+	if perturber == nil && perturbs(script.Steps) {
+		return nil, fmt.Errorf("script %q perturbs but has no perturber", script.Name)
+	}
+	r := &ScriptRunner{
+		script:    script,
+		injector:  injector,
+		input:     input,
+		perturber: perturber,
+		rng:       rand.New(rand.NewSource(seed)),
+	}
+	r.schedule(0, script.Steps)
+	return r, nil
+}
+
+// Clock returns simulated seconds since the script started.
+func (r *ScriptRunner) Clock() float64 {
+	return r.clock
+}
+
+// Done reports whether the script has ended, and why.
+func (r *ScriptRunner) Done() (bool, string) {
+	return r.done, r.reason
+}
+
+// History returns every event so far.
+func (r *ScriptRunner) History() []ScriptEvent {
+	return append([]ScriptEvent(nil), r.history...)
+}
+
+// Advance moves the clock by dt and fires every step now due, in time order.
+// Call it before the tick that covers the interval so injected lines are
+// drained by that tick.
+func (r *ScriptRunner) Advance(dt float64) []ScriptEvent {
+	if r.done {
+		return nil
+	}
+	r.clock += dt
+	var events []ScriptEvent
+	for len(r.queue) > 0 && r.queue[0].at <= r.clock {
+		next := r.queue[0]
+		r.queue = r.queue[1:]
+		events = append(events, r.fire(next)...)
+	}
+	r.history = append(r.history, events...)
+	return events
+}
+
+// Observe checks the end conditions against the state after a tick.
+func (r *ScriptRunner) Observe(bio biology.State) []ScriptEvent {
+	if r.done {
+		return nil
+	}
+	switch {
+	case r.script.End.After > 0 && r.clock >= r.script.End.After:
+		r.finish(fmt.Sprintf("after %gs", r.script.End.After))
+	case r.script.End.After == 0 && len(r.script.End.When) == 0 && len(r.queue) == 0:
+		r.finish("steps exhausted")
+	default:
+		for _, c := range r.script.End.When {
+			if c.Holds(bio) {
+				r.finish(c.String())
+				break
+			}
+		}
+	}
+	if !r.done {
+		return nil
+	}
+	event := ScriptEvent{At: r.clock, Action: "end", Detail: r.reason}
+	r.history = append(r.history, event)
+	return []ScriptEvent{event}
+}
+
+// ScriptTick is one tick of a scripted run.
+type ScriptTick struct {
+	Events []ScriptEvent
+	Result TickResult
+}
+
+// Run ticks loop until the script ends or maxTicks is reached (0 means no
+// limit; the script must then end on its own). It returns the end reason.
+func (r *ScriptRunner) Run(loop *SimulationLoop, state *SimulationState, dt float64, maxTicks int, onTick func(ScriptTick)) string {
+	if dt <= 0 {
+		panic(fmt.Errorf("script run requires positive dt"))
+	}
+	for tick := 0; maxTicks == 0 || tick < maxTicks; tick++ {
+		events := r.Advance(dt)
+		result := loop.Tick(state, dt)
+		events = append(events, r.Observe(state.Bio)...)
+		if onTick != nil {
+			onTick(ScriptTick{Events: events, Result: result})
+		}
+		if r.done {
+			return r.reason
+		}
+	}
+	return fmt.Sprintf("stopped after %d ticks", maxTicks)
+}
+
+func (r *ScriptRunner) finish(reason string) {
+	r.done = true
+	r.reason = reason
+}
+
+func (r *ScriptRunner) schedule(base float64, steps []ScriptStep) {
+	for _, step := range steps {
+		r.push(scheduledStep{at: base + step.At, step: step})
+	}
+}
+
+func (r *ScriptRunner) push(s scheduledStep) {
+	s.seq = r.seq
+	r.seq++
+	r.queue = append(r.queue, s)
+	sort.SliceStable(r.queue, func(i, j int) bool {
+		if r.queue[i].at != r.queue[j].at {
+			return r.queue[i].at < r.queue[j].at
+		}
+		return r.queue[i].seq < r.queue[j].seq
+	})
+}
+
+func (r *ScriptRunner) fire(s scheduledStep) []ScriptEvent {
+	var events []ScriptEvent
+	step := s.step
+	if step.Deactivate && r.injector.Deactivate() {
+		events = append(events, ScriptEvent{At: s.at, Action: "deactivate"})
+	}
+	if step.Activate != "" {
+		detail := step.Activate
+		if !r.injector.Activate(step.Activate) {
+			detail += " (not registered)"
+		}
+		events = append(events, ScriptEvent{At: s.at, Action: "activate", Detail: detail})
+	}
+	for _, line := range step.Inject {
+		r.input.Enqueue(line)
+		events = append(events, ScriptEvent{At: s.at, Action: "inject", Detail: line})
+	}
+	for _, p := range step.Perturb {
+		p.At = s.at
+		if p.Source == "" {
+			p.Source = "script " + r.script.Name
+		}
+		_ = r.perturber.Schedule(p) // validated with the script
+		events = append(events, ScriptEvent{At: s.at, Action: "perturb", Detail: p.String()})
+	}
+	if len(step.Branches) > 0 {
+		b := r.pick(step.Branches)
+		events = append(events, ScriptEvent{At: s.at, Action: "branch", Detail: b.Label})
+		r.schedule(s.at, b.Steps)
+	}
+
+	s.fired++
+	if step.Every > 0 && (step.Times == 0 || s.fired < step.Times) {
+		s.at += step.Every
+		r.push(s)
+	}
+	return events
+}
+
+func (r *ScriptRunner) pick(branches []ScriptBranch) ScriptBranch {
+	weight := func(b ScriptBranch) float64 {
+		if b.Weight == 0 {
+			return 1
+		}
+		return b.Weight
+	}
+	total := 0.0
+	for _, b := range branches {
+		total += weight(b)
+	}
+	x := r.rng.Float64() * total
+	for _, b := range branches {
+		if x < weight(b) {
+			return b
+		}
+		x -= weight(b)
+	}
+	return branches[len(branches)-1]
+}
+
+func usesScenarios(steps []ScriptStep) bool {
+	for _, s := range steps {
+		if s.Activate != "" || s.Deactivate {
+			return true
+		}
+		for _, b := range s.Branches {
+			if usesScenarios(b.Steps) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func injects(steps []ScriptStep) bool {
+	for _, s := range steps {
+		if len(s.Inject) > 0 {
+			return true
+		}
+		for _, b := range s.Branches {
+			if injects(b.Steps) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func perturbs(steps []ScriptStep) bool {
+	for _, s := range steps {
+		if len(s.Perturb) > 0 {
+			return true
+		}
+		for _, b := range s.Branches {
+			if perturbs(b.Steps) {
+				return true
+			}
+		}
+	}
+	return false
+}
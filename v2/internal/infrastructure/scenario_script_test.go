@@ -0,0 +1,248 @@
+package infrastructure_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/infrastructure"
+	"github.com/marczahn/person/v2/internal/sense"
+)
+
+type scriptRig struct {
+	adapter   *infrastructure.InputAdapter
+	injector  *infrastructure.ScenarioInjector
+	perturber *infrastructure.Perturber
+	loop      *infrastructure.SimulationLoop
+	state     infrastructure.SimulationState
+}
+
+func newScriptRig(t *testing.T) *scriptRig {
+	t.Helper()
+	adapter := infrastructure.NewInputAdapter(sense.NewParser(), func() int64 { return 0 })
+	injector := infrastructure.NewScenarioInjector(adapter)
+	if err := injector.Register("cold_night", []string{"freezing cold", "dark", "alone"}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	perturber := infrastructure.NewPerturber()
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:         injector,
+		Biology:       &fakeBioEngine{},
+		Motivation:    &fakeMotivationComputer{},
+		Mind:          &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: none] Hm."},
+		Perturbations: perturber,
+	})
+	return &scriptRig{
+		adapter:   adapter,
+		injector:  injector,
+		perturber: perturber,
+		loop:      loop,
+		state:     infrastructure.SimulationState{Bio: *biology.NewDefaultState()},
+	}
+}
+
+func (r *scriptRig) runner(t *testing.T, script infrastructure.ScenarioScript, seed int64) *infrastructure.ScriptRunner {
+	t.Helper()
+	runner, err := infrastructure.NewScriptRunner(script, r.injector, r.adapter, r.perturber, seed)
+	if err != nil {
+		t.Fatalf("NewScriptRunner: %v", err)
+	}
+	return runner
+}
+
+func TestScriptRunner_ColdNightThenRescue(t *testing.T) {
+	rig := newScriptRig(t)
+	script := infrastructure.ScenarioScript{
+		Name: "isolated cold night then rescue",
+		Steps: []infrastructure.ScriptStep{
+			{At: 60, Activate: "cold_night"},
+			{At: 300, Deactivate: true, Inject: []string{"*someone offers food*", "~someone nearby"}},
+		},
+		End: infrastructure.ScriptEnd{After: 400},
+	}
+	runner := rig.runner(t, script, 1)
+
+	var tempAt = map[float64]float64{}
+	var peopleAt = map[float64]bool{}
+	reason := runner.Run(rig.loop, &rig.state, 10, 100, func(tick infrastructure.ScriptTick) {
+		tempAt[runner.Clock()] = rig.state.Bio.BodyTemp
+		peopleAt[runner.Clock()] = tick.Result.World.PeopleNearby
+	})
+
+	if reason != "after 400s" {
+		t.Fatalf("end reason = %q", reason)
+	}
+	if tempAt[50] != tempAt[10] {
+		t.Fatalf("body temperature changed before the cold night: %v -> %v", tempAt[10], tempAt[50])
+	}
+	if tempAt[290] >= tempAt[60] {
+		t.Fatalf("expected cooling during the cold night: %v -> %v", tempAt[60], tempAt[290])
+	}
+	if peopleAt[290] || !peopleAt[300] {
+		t.Fatalf("expected isolation during the night and company at the rescue, got night=%v rescue=%v", peopleAt[290], peopleAt[300])
+	}
+	if tempAt[400] <= tempAt[310] || tempAt[400] > infrastructure.BaselineBodyTempC {
+		t.Fatalf("expected warming back toward baseline once the scenario ends: %v -> %v", tempAt[310], tempAt[400])
+	}
+
+	var actions []string
+	for _, e := range runner.History() {
+		actions = append(actions, e.Action)
+	}
+	want := []string{"activate", "deactivate", "inject", "inject", "end"}
+	if !reflect.DeepEqual(actions, want) {
+		t.Fatalf("history = %v, want %v", actions, want)
+	}
+}
+
+func TestScriptRunner_RepeatFiresOnSchedule(t *testing.T) {
+	rig := newScriptRig(t)
+	runner := rig.runner(t, infrastructure.ScenarioScript{
+		Name:  "knocks",
+		Steps: []infrastructure.ScriptStep{{At: 5, Inject: []string{"*knocks on the door*"}, Every: 10, Times: 3}},
+	}, 1)
+
+	reason := runner.Run(rig.loop, &rig.state, 5, 50, nil)
+	if reason != "steps exhausted" {
+		t.Fatalf("end reason = %q", reason)
+	}
+	var at []float64
+	for _, e := range runner.History() {
+		if e.Action == "inject" {
+			at = append(at, e.At)
+		}
+	}
+	if !reflect.DeepEqual(at, []float64{5, 15, 25}) {
+		t.Fatalf("injections at %v, want [5 15 25]", at)
+	}
+}
+
+func TestScriptRunner_BranchesAreSeeded(t *testing.T) {
+	script := infrastructure.ScenarioScript{
+		Name: "rescue or not",
+		Steps: []infrastructure.ScriptStep{{At: 10, Branches: []infrastructure.ScriptBranch{
+			{Label: "rescue", Weight: 1, Steps: []infrastructure.ScriptStep{{At: 5, Inject: []string{"*someone hugs you*"}}}},
+			{Label: "nobody", Weight: 1, Steps: []infrastructure.ScriptStep{{At: 5, Inject: []string{"~alone"}}}},
+		}}},
+	}
+	branchFor := func(seed int64) []infrastructure.ScriptEvent {
+		rig := newScriptRig(t)
+		runner := rig.runner(t, script, seed)
+		runner.Run(rig.loop, &rig.state, 5, 10, nil)
+		return runner.History()
+	}
+
+	seen := map[string]bool{}
+	for seed := int64(0); seed < 20; seed++ {
+		first, second := branchFor(seed), branchFor(seed)
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("seed %d diverged:\n%v\n%v", seed, first, second)
+		}
+		if first[0].Action != "branch" || first[1].At != 15 {
+			t.Fatalf("expected a branch at 10s and its step at 15s, got %v", first)
+		}
+		seen[first[0].Detail] = true
+	}
+	if !seen["rescue"] || !seen["nobody"] {
+		t.Fatalf("expected both branches across seeds, got %v", seen)
+	}
+}
+
+func TestScriptRunner_EndsOnBioCondition(t *testing.T) {
+	rig := newScriptRig(t)
+	runner := rig.runner(t, infrastructure.ScenarioScript{
+		Name:  "until hypothermic",
+		Steps: []infrastructure.ScriptStep{{At: 0, Activate: "cold_night"}},
+		End:   infrastructure.ScriptEnd{After: 10_000, When: []infrastructure.BioCondition{{Field: "body_temp", Op: "<", Value: 36}}},
+	}, 1)
+
+	reason := runner.Run(rig.loop, &rig.state, 10, 0, nil)
+	if reason != "body_temp < 36" {
+		t.Fatalf("end reason = %q", reason)
+	}
+	if rig.state.Bio.BodyTemp >= 36 || runner.Clock() >= 10_000 {
+		t.Fatalf("expected an early stop below 36°C, got %.2f at %.0fs", rig.state.Bio.BodyTemp, runner.Clock())
+	}
+	if events := runner.Advance(10); events != nil {
+		t.Fatalf("a finished script must not fire, got %v", events)
+	}
+}
+
+func TestScenarioScript_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		script infrastructure.ScenarioScript
+		want   string
+	}{
+		{"no name", infrastructure.ScenarioScript{Steps: []infrastructure.ScriptStep{{Deactivate: true}}}, "name"},
+		{"no steps", infrastructure.ScenarioScript{Name: "x"}, "at least one step"},
+		{"empty step", infrastructure.ScenarioScript{Name: "x", Steps: []infrastructure.ScriptStep{{At: 1}}}, "does nothing"},
+		{"negative time", infrastructure.ScenarioScript{Name: "x", Steps: []infrastructure.ScriptStep{{At: -1, Deactivate: true}}}, "negative"},
+		{"bad perturbation", infrastructure.ScenarioScript{Name: "x", Steps: []infrastructure.ScriptStep{{Perturb: []infrastructure.Perturbation{{Kind: "ramp", Target: "stress", Value: 1}}}}}, "duration"},
+		{"unknown field", infrastructure.ScenarioScript{Name: "x", Steps: []infrastructure.ScriptStep{{Deactivate: true}},
+			End: infrastructure.ScriptEnd{When: []infrastructure.BioCondition{{Field: "cortisol", Op: ">", Value: 1}}}}, "unknown end field"},
+		{"unknown op", infrastructure.ScenarioScript{Name: "x", Steps: []infrastructure.ScriptStep{{Deactivate: true}},
+			End: infrastructure.ScriptEnd{When: []infrastructure.BioCondition{{Field: "stress", Op: "==", Value: 1}}}}, "operator"},
+	}
+	for _, tc := range tests {
+		err := tc.script.Validate()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want mention of %q", tc.name, err, tc.want)
+		}
+	}
+
+	if _, err := infrastructure.NewScriptRunner(infrastructure.ScenarioScript{
+		Name: "x", Steps: []infrastructure.ScriptStep{{Activate: "cold_night"}},
+	}, nil, nil, nil, 1); err == nil {
+		t.Error("expected an error for scenario steps without an injector")
+	}
+	if _, err := infrastructure.NewScriptRunner(infrastructure.ScenarioScript{
+		Name: "x", Steps: []infrastructure.ScriptStep{{Perturb: []infrastructure.Perturbation{{Kind: "add", Target: "stress", Value: 0.1}}}},
+	}, nil, nil, nil, 1); err == nil {
+		t.Error("expected an error for perturb steps without a perturber")
+	}
+}
+
+func TestScriptRunner_PerturbStepsRunOnTheScriptClock(t *testing.T) {
+	rig := newScriptRig(t)
+	script := infrastructure.ScenarioScript{
+		Name: "forced stress",
+		Steps: []infrastructure.ScriptStep{
+			{At: 120, Perturb: []infrastructure.Perturbation{{Kind: "set", Target: "stress", Value: 0.9}}},
+			{At: 60, Perturb: []infrastructure.Perturbation{{Kind: "ramp", Target: "body_temp", Value: -2, Over: 300}}},
+		},
+		End: infrastructure.ScriptEnd{After: 400},
+	}
+	runner := rig.runner(t, script, 1)
+
+	stressAt := map[float64]float64{}
+	var ramped float64
+	runner.Run(rig.loop, &rig.state, 10, 0, func(tick infrastructure.ScriptTick) {
+		stressAt[runner.Clock()] = rig.state.Bio.Stress
+		for _, d := range tick.Result.Perturbations {
+			if d.Source != "script forced stress" {
+				t.Errorf("delta %+v should be attributed to the script", d)
+			}
+			if d.Field == "body_temp" {
+				ramped += d.Amount
+			}
+		}
+	})
+
+	if stressAt[110] == 0.9 || stressAt[120] != 0.9 {
+		t.Errorf("stress should be forced at 120s: 110s=%v 120s=%v", stressAt[110], stressAt[120])
+	}
+	if ramped > -1.999 || ramped < -2.001 {
+		t.Errorf("body_temp ramp applied %v, want -2", ramped)
+	}
+	var details []string
+	for _, e := range runner.History() {
+		if e.Action == "perturb" {
+			details = append(details, e.Detail)
+		}
+	}
+	if want := []string{"ramp body_temp -2 over 300", "set stress 0.9"}; !reflect.DeepEqual(details, want) {
+		t.Errorf("perturb events = %v, want %v", details, want)
+	}
+}
@@ -1,6 +1,7 @@
 package infrastructure_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/marczahn/person/v2/internal/biology"
@@ -16,9 +17,7 @@ func (d *staticDrainer) Drain() infrastructure.TickInput {
 }
 
 func TestScenarioInjector_RegisterActivateAndDrainEffects(t *testing.T) {
-	base := &staticDrainer{input: infrastructure.TickInput{
-		AllowedActions: map[string]bool{"eat": true},
-	}}
+	base := &staticDrainer{}
 	injector := infrastructure.NewScenarioInjector(base)
 
 	if err := injector.Register("cold_room", []string{"cold room", "no food available"}); err != nil {
@@ -30,18 +29,16 @@ func TestScenarioInjector_RegisterActivateAndDrainEffects(t *testing.T) {
 
 	got := injector.Drain()
 
-	if !containsRate(got.PreBioRates, "body_temp", -0.03) {
+	if !containsRate(environmentRates(got), "body_temp", -0.03) {
 		t.Fatalf("expected cold scenario to inject body_temp decay rate")
 	}
-	if got.AllowedActions["eat"] {
+	if got.World.Apply(infrastructure.DefaultWorldState()).AllowedActions()["eat"] {
 		t.Fatalf("expected scenario environment descriptor to block eat action")
 	}
 }
 
 func TestScenarioInjector_SwitchIsDeterministicLatestWins(t *testing.T) {
-	base := &staticDrainer{input: infrastructure.TickInput{
-		AllowedActions: map[string]bool{"eat": true},
-	}}
+	base := &staticDrainer{}
 	injector := infrastructure.NewScenarioInjector(base)
 	if err := injector.Register("cold_room", []string{"cold room"}); err != nil {
 		t.Fatalf("register cold scenario failed: %v", err)
@@ -56,25 +53,23 @@ func TestScenarioInjector_SwitchIsDeterministicLatestWins(t *testing.T) {
 
 	got := injector.Drain()
 
-	if !containsRate(got.PreBioRates, "body_temp", -0.03) {
+	if !containsRate(environmentRates(got), "body_temp", -0.03) {
 		t.Fatalf("expected latest active scenario (cold_room) to control rate effects")
 	}
-	if containsRate(got.PreBioRates, "body_temp", 0.03) {
+	if containsRate(environmentRates(got), "body_temp", 0.03) {
 		t.Fatalf("did not expect hot scenario effects after switching back to cold")
 	}
 }
 
 func TestScenarioInjector_EffectsAppearOnNextDrainAfterRuntimeSwitch(t *testing.T) {
-	base := &staticDrainer{input: infrastructure.TickInput{
-		AllowedActions: map[string]bool{"rest": true},
-	}}
+	base := &staticDrainer{}
 	injector := infrastructure.NewScenarioInjector(base)
 	if err := injector.Register("calm_space", []string{"quiet and peaceful"}); err != nil {
 		t.Fatalf("register scenario failed: %v", err)
 	}
 
 	first := injector.Drain()
-	if containsRate(first.PreBioRates, "stress", -0.02) {
+	if containsRate(environmentRates(first), "stress", -0.02) {
 		t.Fatalf("did not expect scenario effects before activation")
 	}
 
@@ -83,16 +78,63 @@ func TestScenarioInjector_EffectsAppearOnNextDrainAfterRuntimeSwitch(t *testing.
 	}
 
 	second := injector.Drain()
-	if !containsRate(second.PreBioRates, "stress", -0.02) {
+	if !containsRate(environmentRates(second), "stress", -0.02) {
 		t.Fatalf("expected scenario stress-calming rate in next drain cycle")
 	}
 }
 
+// environmentRates returns the rates a neutral environment derives once the
+// drained changes are applied.
+func environmentRates(in infrastructure.TickInput) []biology.BioRate {
+	env := infrastructure.DefaultEnvironment()
+	world := infrastructure.DefaultWorldState()
+	for _, change := range in.Environment {
+		world = env.Apply(change, world)
+	}
+	return append(append([]biology.BioRate(nil), in.PreBioRates...), env.Rates(infrastructure.BaselineBodyTempC, 0)...)
+}
+
 func containsRate(rates []biology.BioRate, field string, perSecond float64) bool {
 	for _, rate := range rates {
-		if rate.Field == field && rate.PerSecond == perSecond {
+		if rate.Field == field && math.Abs(rate.PerSecond-perSecond) < 1e-9 {
 			return true
 		}
 	}
 	return false
 }
+
+func TestScenarioInjector_DeactivateResetsWhatTheScenarioSet(t *testing.T) {
+	base := &staticDrainer{}
+	injector := infrastructure.NewScenarioInjector(base)
+	if err := injector.Register("cold_room", []string{"freezing cold", "no food available"}); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	injector.Activate("cold_room")
+
+	env := infrastructure.DefaultEnvironment()
+	world := infrastructure.DefaultWorldState()
+	apply := func(in infrastructure.TickInput) {
+		for _, change := range in.Environment {
+			world = env.Apply(change, world)
+		}
+		world = in.World.Apply(world)
+	}
+	apply(injector.Drain())
+	if env.TemperatureC >= infrastructure.NeutralTemperatureC || world.Food {
+		t.Fatalf("expected the scenario to take effect, got env=%+v world=%+v", env, world)
+	}
+
+	if !injector.Deactivate() || injector.Active() != "" {
+		t.Fatal("expected deactivate to switch the scenario off")
+	}
+	if injector.Deactivate() {
+		t.Fatal("deactivating twice should report nothing was active")
+	}
+	apply(injector.Drain())
+	if env.TemperatureC != infrastructure.NeutralTemperatureC || !world.Food {
+		t.Fatalf("expected defaults after deactivation, got env=%+v world=%+v", env, world)
+	}
+	if next := injector.Drain(); len(next.Environment) != 0 || !next.World.Empty() {
+		t.Fatalf("reset must be emitted once, got %+v", next)
+	}
+}
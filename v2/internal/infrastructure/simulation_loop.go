@@ -1,11 +1,15 @@
 package infrastructure
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/marczahn/person/v2/internal/biology"
 	"github.com/marczahn/person/v2/internal/consciousness"
+	"github.com/marczahn/person/v2/internal/memory"
 	"github.com/marczahn/person/v2/internal/motivation"
+	"github.com/marczahn/person/v2/internal/sense"
 )
 
 // InputDrainer drains pending external inputs once per tick.
@@ -24,26 +28,54 @@ type MotivationComputer interface {
 }
 
 // MindResponder returns one structured consciousness response for this tick.
+// Implementations must honour ctx cancellation; the loop cancels on timeout and Close.
 type MindResponder interface {
-	Respond(in MindRequest) string
+	Respond(ctx context.Context, in MindRequest) (string, error)
 }
 
-// TickInput contains drained input effects and current action gating for one tick.
+// TickInput contains drained input effects and world changes for one tick.
 type TickInput struct {
-	PreBioRates    []biology.BioRate
-	PreBioPulses   []biology.BioPulse
-	AllowedActions map[string]bool
-	NowSeconds     int64
-	ExternalText   string
+	PreBioRates  []biology.BioRate
+	PreBioPulses []biology.BioPulse
+	// Stimuli are cue effects the loop scales by habituation before applying.
+	Stimuli []Stimulus
+	World   WorldPatch
+	// Environment holds ambient changes in drain order; they persist in
+	// SimulationState.Environment until changed or expired.
+	Environment  []EnvironmentChange
+	NowSeconds   int64
+	ExternalText string
+	// Speech holds what operators said to the person this tick.
+	Speech []string
+	// Heard reads each line of Speech, in the same order.
+	Heard []HeardSpeech
+	// SocialContact is set when someone spoke to the person this tick; the
+	// person counts as in company for the rest of the tick.
+	SocialContact bool
+	// Commands are operator commands run while draining, in drain order.
+	Commands []OperatorCommand
 }
 
 // MindRequest is the consciousness-stage payload for one tick.
 type MindRequest struct {
-	Bio         biology.State
-	Motivation  motivation.MotivationState
+	Bio        biology.State
+	Motivation motivation.MotivationState
+	// Perceived is the carried self-perception of drives the prompt was built from.
+	Perceived   motivation.MotivationState
 	Prompt      consciousness.PromptContext
 	Input       TickInput
 	PriorParsed consciousness.ParsedResponse
+	Activity    consciousness.Activity
+	World       WorldState
+	// Environment is the ambient state the person is in this tick.
+	Environment Environment
+	// Offered lists the active goal's candidate actions the world permits.
+	Offered []motivation.Action
+	// Correction is set on corrective retries after unusable output; Attempt counts them.
+	Correction string
+	Attempt    int
+	// Trigger is why the loop consulted the mind.
+	Trigger MindTrigger
 }
 
 // SimulationState is the mutable simulation state carried across ticks.
@@ -54,18 +86,102 @@ type SimulationState struct {
 	PriorParsed   consciousness.ParsedResponse
 	CooldownState consciousness.ActionCooldownState
 	Continuity    *consciousness.ContinuityBuffer
+	// Seeds draws spontaneous thoughts on its own schedule; nil uses the
+	// top-drive selection on SimulationLoopDeps.Thoughts.
+	Seeds *consciousness.ThoughtSeeder
+	// Perceived is the mind's reported drive perception carried into the next tick.
+	Perceived *motivation.MotivationState
+	// World persists across ticks; nil starts from DefaultWorldState.
+	World *WorldState
+	// Places is the optional world model. When set, World is derived from it
+	// every tick and executed actions consume its stocks or move the person.
+	Places *WorldModel
+	// Environment persists across ticks; nil starts from DefaultEnvironment.
+	Environment *Environment
+	// Habituation persists across ticks; nil starts unadapted.
+	Habituation   *Habituation
+	Activity      consciousness.Activity
+	PendingAction string
+	Gate          MindGateState
+	Metrics       SimulationMetrics
+}
+
+// SimulationMetrics accumulates per-session counters across ticks.
+type SimulationMetrics struct {
+	Ticks         int
+	ConflictTicks int
+	// PerceptionGapTotal sums the per-tick mean raw/perceived drive gap.
+	PerceptionGapTotal float64
+	PerceptionGapMax   float64
+	// MindTurns counts ticks whose mind output was parsed.
+	MindTurns int
+	// ParseFailures counts unusable first attempts by reason.
+	ParseFailures  map[consciousness.ParseFailure]int
+	ParseRetries   int
+	ParseRecovered int
+	// GatedTicks counts ticks without a mind reply to apply.
+	GatedTicks int
+	MindErrors int
+}
+
+// MeanPerceptionGap returns the average raw/perceived drive gap per tick,
+// a running measure of self-deception or denial.
+func (m SimulationMetrics) MeanPerceptionGap() float64 {
+	if m.Ticks == 0 {
+		return 0
+	}
+	return m.PerceptionGapTotal / float64(m.Ticks)
 }
 
 // TickResult captures one fully-orchestrated INF-07 tick.
 type TickResult struct {
-	Input               TickInput
+	Input TickInput
+	// Perturbations are the forced changes applied before biology this tick.
+	Perturbations []AttributedDelta
+	// Stimuli are the adapting stimuli the person responded to this tick.
+	Stimuli             []StimulusResponse
 	Bio                 biology.TickResult
 	Motivation          motivation.MotivationState
 	PerceivedMotivation motivation.MotivationState
-	Prompt              consciousness.PromptContext
-	Raw                 string
-	Parsed              consciousness.ParsedResponse
-	ActionOutcome       consciousness.ActionOutcome
+	PerceptionGap       consciousness.PerceptionGap
+	SpontaneousThought  *consciousness.Thought
+	// ThoughtChainBroken reports that input or an interruption broke an absorbed thought chain.
+	ThoughtChainBroken bool
+	Conflicts          []motivation.DriveConflict
+	Prompt             consciousness.PromptContext
+	// MindSkipped is set when no mind reply was applied this tick (gated, still
+	// in flight, or failed); Parsed then carries the prior parse forward and Raw is empty.
+	MindSkipped bool
+	// Trigger is why a request was made this tick, empty when none was.
+	Trigger     MindTrigger
+	MindPending bool
+	// Arrival describes the asynchronous reply applied this tick, if any.
+	Arrival *MindArrival
+	MindErr error
+	// EvaluatorErr reports a failed blind evaluation; the [STATE] pulse was applied instead.
+	EvaluatorErr error
+	Raw          string
+	Parsed       consciousness.ParsedResponse
+	ParseRetries int
+	// Speech is what the person said aloud this tick, nil when silent.
+	Speech        *Utterance
+	ActionOutcome consciousness.ActionOutcome
+	// QueuedOutcome is the resolution of a queued action started when the activity finished.
+	QueuedOutcome *consciousness.ActionOutcome
+	Activity      consciousness.Activity
+	Interruption  *Interruption
+	World         WorldState
+	Environment   Environment
+	// WorldEffects are what executed actions did to Places, in execution order.
+	WorldEffects []WorldEffect
+	// Recalled are the episodes shown to the mind; Formed are those stored this tick.
+	Recalled []memory.Episode
+	Formed   []memory.Episode
+	// MemoryErr reports a failure to persist formed episodes.
+	MemoryErr error
+	// HabituationErr reports a failure to load stored habituation; the person
+	// starts unadapted.
+	HabituationErr error
 }
 
 // SimulationLoopDeps wires infrastructure orchestration to layer contracts.
@@ -75,15 +191,92 @@ type SimulationLoopDeps struct {
 	Motivation MotivationComputer
 	Mind       MindResponder
 	Cooldowns  consciousness.ActionCooldowns
+	// ConflictThreshold is the urgency both drives must reach to count as conflicting.
+	// Zero uses motivation.DefaultConflictThreshold.
+	ConflictThreshold float64
+	// Activities gives actions a duration. Nil uses consciousness.DefaultActivityCatalog;
+	// an empty catalog makes every action instant.
+	Activities consciousness.ActivityCatalog
+	// Occupancy decides what happens to new actions during an activity. Empty rejects them.
+	Occupancy consciousness.OccupancyPolicy
+	// Interrupts overrides DefaultActivityInterruptRules when non-nil.
+	Interrupts *ActivityInterruptRules
+	// PerceptionHalfLife is the seconds for reported drive perception to close half
+	// its gap to raw drives. Zero uses consciousness.DefaultPerceptionHalfLife.
+	PerceptionHalfLife float64
+	// Thoughts schedules spontaneous thoughts. The zero value never fires.
+	Thoughts consciousness.TickSchedule
+	// Protocol selects the mind response format. Empty uses bracket tags.
+	Protocol consciousness.ResponseProtocol
+	// ParsePolicy decides how unusable mind output is handled.
+	ParsePolicy ParseFailurePolicy
+	// Speech receives what the person says aloud. Nil keeps speech in the tick result only.
+	Speech SpeechSink
+	// Memory is long-term episodic memory. Nil runs without recall or formation.
+	Memory *memory.Episodic
+	// Salience gates mind calls to salient ticks. Nil consults the mind every tick.
+	Salience *SalienceGate
+	// Async runs the mind off the tick. Nil consults it synchronously.
+	Async *AsyncMindConfig
+	// Somatic selects how a thought's bodily impact is derived. Empty uses the [STATE] pulse.
+	Somatic consciousness.SomaticMode
+	// Evaluator judges narratives blind for the evaluator and blend modes.
+	// EvaluatorWeight is its share in blend mode; zero uses 0.5.
+	Evaluator       consciousness.ThoughtEvaluator
+	EvaluatorWeight float64
+	// DeferSocialRelief withholds reach_out's own relief: it comes from the other
+	// person's response instead, delivered as input (see Population).
+	DeferSocialRelief bool
+	// Perturbations forces bio variables and chronic loads on the tick clock.
+	// Nil runs without.
+	Perturbations *Perturber
+	// Adaptations overrides DefaultStimulusAdaptations when non-nil; an empty
+	// map turns habituation and sensitization off.
+	Adaptations map[sense.Cue]StimulusAdaptation
+	// Habituations persists habituation across runs: it is loaded on the first
+	// tick of a state without one and saved by Close. Nil keeps it in memory.
+	Habituations HabituationStore
 }
 
 // SimulationLoop orchestrates one sequential tick: input -> biology -> motivation -> consciousness -> feedback.
+// Tick, AwaitMind and Close must be called from one goroutine.
 type SimulationLoop struct {
 	input      InputDrainer
 	biology    BioEngine
 	motivation MotivationComputer
 	mind       MindResponder
 	cooldowns  consciousness.ActionCooldowns
+
+	conflictThreshold float64
+	activities        consciousness.ActivityCatalog
+	occupancy         consciousness.OccupancyPolicy
+	interrupts        ActivityInterruptRules
+
+	perceptionHalfLife float64
+	thoughts           consciousness.TickSchedule
+	protocol           consciousness.ResponseProtocol
+	parsePolicy        ParseFailurePolicy
+	speech             SpeechSink
+	memory             *memory.Episodic
+	salience           *SalienceGate
+	async              *AsyncMindConfig
+	somatic            consciousness.SomaticMode
+	evaluator          consciousness.ThoughtEvaluator
+	evaluatorWeight    float64
+	deferSocialRelief  bool
+	perturbations      *Perturber
+	adaptations        map[sense.Cue]StimulusAdaptation
+	habituations       HabituationStore
+	// habituation is the last ticked state's memory, saved by Close.
+	habituation *Habituation
+	// habituationUnloaded is set when the store failed to load, so Close does
+	// not overwrite what it holds with an unadapted memory.
+	habituationUnloaded bool
+
+	// Async pipeline state: at most one request in flight.
+	inflight *mindFlight
+	arrived  *mindReply
+	held     TickInput
 }
 
 func NewSimulationLoop(deps SimulationLoopDeps) *SimulationLoop {
@@ -100,12 +293,66 @@ func NewSimulationLoop(deps SimulationLoopDeps) *SimulationLoop {
 		panic(fmt.Errorf("simulation loop requires MindResponder"))
 	}
 
+	conflictThreshold := deps.ConflictThreshold
+	if conflictThreshold <= 0 {
+		conflictThreshold = motivation.DefaultConflictThreshold
+	}
+
+	activities := deps.Activities
+	if activities == nil {
+		activities = consciousness.DefaultActivityCatalog()
+	}
+	occupancy := deps.Occupancy
+	if occupancy == "" {
+		occupancy = consciousness.OccupancyReject
+	}
+	perceptionHalfLife := deps.PerceptionHalfLife
+	if perceptionHalfLife <= 0 {
+		perceptionHalfLife = consciousness.DefaultPerceptionHalfLife
+	}
+	interrupts := DefaultActivityInterruptRules()
+	if deps.Interrupts != nil {
+		interrupts = *deps.Interrupts
+	}
+	somatic := deps.Somatic
+	if somatic == "" {
+		somatic = consciousness.SomaticStatePulse
+	}
+	evaluatorWeight := deps.EvaluatorWeight
+	if evaluatorWeight <= 0 {
+		evaluatorWeight = 0.5
+	}
+	adaptations := deps.Adaptations
+	if adaptations == nil {
+		adaptations = DefaultStimulusAdaptations()
+	}
+
 	return &SimulationLoop{
-		input:      deps.Input,
-		biology:    deps.Biology,
-		motivation: deps.Motivation,
-		mind:       deps.Mind,
-		cooldowns:  deps.Cooldowns,
+		input:             deps.Input,
+		biology:           deps.Biology,
+		motivation:        deps.Motivation,
+		mind:              deps.Mind,
+		cooldowns:         deps.Cooldowns,
+		conflictThreshold: conflictThreshold,
+		activities:        activities,
+		occupancy:         occupancy,
+		interrupts:        interrupts,
+
+		perceptionHalfLife: perceptionHalfLife,
+		thoughts:           deps.Thoughts,
+		protocol:           deps.Protocol,
+		parsePolicy:        deps.ParsePolicy,
+		speech:             deps.Speech,
+		memory:             deps.Memory,
+		salience:           deps.Salience,
+		async:              deps.Async,
+		somatic:            somatic,
+		evaluator:          deps.Evaluator,
+		evaluatorWeight:    evaluatorWeight,
+		deferSocialRelief:  deps.DeferSocialRelief,
+		perturbations:      deps.Perturbations,
+		adaptations:        adaptations,
+		habituations:       deps.Habituations,
 	}
 }
 
@@ -115,66 +362,369 @@ func (l *SimulationLoop) Tick(state *SimulationState, dt float64) TickResult {
 	}
 
 	input := l.input.Drain()
+	if state.World == nil {
+		world := DefaultWorldState()
+		state.World = &world
+	}
+	if state.Places != nil {
+		*state.World = state.Places.State()
+	}
+	if state.Environment == nil {
+		env := DefaultEnvironment()
+		if state.Places != nil {
+			env.applyAmbient(state.Places.Here().Ambient)
+		}
+		state.Environment = &env
+	}
+	derived := *state.World
+	*state.World = state.Environment.Advance(dt).Apply(*state.World)
+	for _, change := range input.Environment {
+		*state.World = state.Environment.Apply(change, *state.World)
+	}
+	state.Environment.Supersede(AmbientPatch{}, input.World)
+	*state.World = input.World.Apply(*state.World)
+	if state.Places != nil {
+		state.Places.Sync(derived, *state.World)
+		*state.World = state.Places.State()
+	}
+	world := *state.World
+	if input.SocialContact {
+		world.PeopleNearby = true
+	}
+	environment := *state.Environment
 
-	if len(input.PreBioRates) > 0 || len(input.PreBioPulses) > 0 {
+	habituationErr := l.loadHabituation(state)
+	stimulusPulses, ambientRates, responses := l.adaptStimuli(state, input.Stimuli, environment.Stimuli(state.Bio.BodyTemp, dt), dt)
+	rates := append(append([]biology.BioRate(nil), input.PreBioRates...), ambientRates...)
+	pulses := append(append([]biology.BioPulse(nil), input.PreBioPulses...), stimulusPulses...)
+	if len(rates) > 0 || len(pulses) > 0 {
 		biology.ApplyFeedbackAtTickEnd(&state.Bio, dt, biology.FeedbackEnvelope{
-			Rates:  input.PreBioRates,
-			Pulses: input.PreBioPulses,
+			Rates:  rates,
+			Pulses: pulses,
 		})
 	}
+	var perturbed []AttributedDelta
+	if l.perturbations != nil {
+		perturbed = l.perturbations.Advance(&state.Bio, &state.Chronic, dt)
+	}
 
 	bioResult := l.biology.Tick(&state.Bio, dt)
+
+	var interruption *Interruption
+	if state.Activity.Active() {
+		if reason, ok := l.interrupts.reason(pulses, bioResult); ok {
+			interruption = &Interruption{Activity: state.Activity, Reason: reason}
+			state.Activity = consciousness.Activity{}
+			state.PendingAction = ""
+		}
+	}
+
 	motivationState := l.motivation.Compute(state.Bio, state.Personality, state.Chronic)
 
-	var prompt consciousness.PromptContext
-	if state.Continuity != nil {
-		prompt = consciousness.BuildPromptContextWithContinuity(motivationState, state.Continuity.Items())
-	} else {
-		prompt = consciousness.BuildPromptContext(motivationState)
+	// What the mind last reported about its drives fades back toward the raw values
+	// and colours everything the mind is shown this tick.
+	carried := motivationState
+	if state.Perceived != nil {
+		carried = consciousness.DecayPerceived(*state.Perceived, motivationState, dt, l.perceptionHalfLife)
 	}
+	gap := consciousness.MeasurePerceptionGap(motivationState, carried)
 
-	raw := l.mind.Respond(MindRequest{
-		Bio:         state.Bio,
-		Motivation:  motivationState,
-		Prompt:      prompt,
-		Input:       input,
-		PriorParsed: state.PriorParsed,
-	})
+	var recalled []memory.Episode
+	var recalledLines []string
+	if l.memory != nil {
+		recalled = l.memory.Recall(memory.Cue{Bio: state.Bio, Drives: carried, NowSeconds: input.NowSeconds})
+		recalledLines = memoryLines(recalled, input.NowSeconds)
+	}
 
-	parsed := consciousness.ParseResponse(raw, state.PriorParsed)
-	perceived := consciousness.ApplyParsedDriveOverridesForNextTick(motivationState, parsed)
+	spontaneous, chainBroken := l.spontaneousThought(state, carried, input, recalledLines, interruption != nil)
+	if spontaneous != nil && state.Continuity != nil {
+		state.Continuity.Add(*spontaneous)
+	}
 
-	allowed := false
-	if input.AllowedActions != nil {
-		allowed = input.AllowedActions[parsed.Action]
+	prompt := consciousness.BuildPromptContext(carried)
+	prompt.ContinuityBuffer = state.Continuity.Lines()
+	conflicts := motivation.DetectConflicts(carried, world.Constraints(), l.conflictThreshold)
+	prompt.Conflicts = consciousness.DescribeConflicts(conflicts)
+	prompt.Activity = consciousness.ActivityLine(state.Activity)
+	prompt.Memories = recalledLines
+	for _, heard := range input.Heard {
+		prompt.Heard = append(prompt.Heard, heard.Tag())
+	}
+	prompt.Surroundings = environment.Line()
+	if state.Places != nil {
+		prompt.Surroundings = strings.TrimSpace(state.Places.Line() + " " + prompt.Surroundings)
 	}
-	actionOutcome, nextCooldownState := consciousness.ResolveActionOutcomeWithCooldown(
-		parsed.Action,
-		allowed,
-		input.NowSeconds,
-		l.cooldowns,
-		state.CooldownState,
-	)
 
+	// In async mode a reply that arrived since the last tick is applied first,
+	// and no new request is considered while one is still in flight.
+	var reply *mindReply
+	if l.async != nil {
+		reply = l.collect()
+	}
+	var trigger MindTrigger
+	if l.inflight != nil {
+		l.holdInput(input)
+	} else {
+		mindInput := input
+		if l.async != nil {
+			mindInput = l.releaseInput(input)
+		}
+		var consult bool
+		trigger, consult = l.shouldConsult(state, gateSignals{
+			input:       mindInput,
+			thresholds:  bioResult.Thresholds,
+			bio:         state.Bio,
+			motivation:  motivationState,
+			interrupted: interruption != nil,
+			spontaneous: spontaneous != nil,
+		})
+		if consult {
+			req := MindRequest{
+				Bio:         state.Bio,
+				Motivation:  motivationState,
+				Perceived:   carried,
+				Prompt:      prompt,
+				Input:       mindInput,
+				PriorParsed: state.PriorParsed,
+				Activity:    state.Activity,
+				World:       world,
+				Environment: environment,
+				Offered:     world.OfferedActions(carried.ActiveGoalDrive),
+				Trigger:     trigger,
+			}
+			snapshot := mindSnapshot{
+				tick:       state.Metrics.Ticks + 1,
+				nowSeconds: input.NowSeconds,
+				goal:       carried.ActiveGoalDrive,
+				trigger:    trigger,
+			}
+			if l.async != nil {
+				l.dispatch(req, snapshot)
+			} else {
+				reply = l.consultNow(req, snapshot)
+			}
+		}
+	}
+
+	// Ticks without a usable reply carry the prior parse forward without acting on it
+	// again: its action, emotional pulse and speech belonged to the tick that produced it.
 	var feedback biology.TickFeedbackBuffer
-	feedback.AddPulses(consciousness.EmotionalPulseFromState(parsed.State))
-	feedback.AddPulses(consciousness.ActionPulse(actionOutcome))
+	raw, parsed, parseRetries := "", state.PriorParsed, 0
+	perceived := carried
+	var speech *Utterance
+	var arrival *MindArrival
+	var mindErr, evaluatorErr error
+	actionOutcome := consciousness.ActionOutcome{Reason: consciousness.OutcomeIdle}
+	nextCooldownState := state.CooldownState
+	applied := reply != nil && reply.err == nil
+	switch {
+	case reply != nil && reply.err != nil:
+		mindErr = reply.err
+		state.Metrics.MindErrors++
+	case applied:
+		raw, parsed, parseRetries = reply.raw, reply.parsed, reply.retries
+		state.Metrics.recordParse(parsed, parseRetries)
+		perceived = consciousness.ApplyDriveOverridesOnto(carried, motivationState, parsed.DriveOverrides)
+		speech = l.applyMind(parsed, reply.somatic, world, input.NowSeconds, &feedback)
+		evaluatorErr = reply.somaticErr
+		offered := world.OfferedActions(carried.ActiveGoalDrive)
+		if l.stale(reply, input.NowSeconds, carried.ActiveGoalDrive, offered) {
+			actionOutcome = consciousness.ActionOutcome{Action: parsed.Action, Reason: consciousness.OutcomeStale}
+		} else {
+			actionOutcome, nextCooldownState = l.chooseAction(state, parsed.Action, world, input.NowSeconds, &feedback)
+		}
+		if l.async != nil {
+			arrival = &MindArrival{
+				DispatchTick: reply.snapshot.tick,
+				AgeSeconds:   input.NowSeconds - reply.snapshot.nowSeconds,
+				Trigger:      reply.snapshot.trigger,
+			}
+		}
+	default:
+		state.Metrics.GatedTicks++
+	}
+
+	feedback.AddRates(consciousness.ActivityRates(state.Activity, l.activities, dt))
+	var queuedOutcome *consciousness.ActionOutcome
+	if next, finished := consciousness.AdvanceActivity(state.Activity, dt); finished {
+		state.Activity = consciousness.Activity{}
+		if state.PendingAction != "" {
+			outcome, cooldowns := l.resolveAction(state.PendingAction, world, input.NowSeconds, nextCooldownState)
+			nextCooldownState = cooldowns
+			state.PendingAction = ""
+			l.performAction(state, outcome, &feedback)
+			queuedOutcome = &outcome
+		}
+	} else {
+		state.Activity = next
+	}
+	worldEffects := l.changeWorld(state, carried.ActiveGoalDrive, &actionOutcome, queuedOutcome)
+	if state.Seeds != nil {
+		state.Seeds.NoteOutcome(actionOutcome)
+		if queuedOutcome != nil {
+			state.Seeds.NoteOutcome(*queuedOutcome)
+		}
+	}
 	feedback.ApplyAtTickEnd(&state.Bio, dt)
+	if applied {
+		state.Gate.settle(state.Bio)
+	}
 
 	state.PriorParsed = parsed
 	state.CooldownState = nextCooldownState
-	if state.Continuity != nil && parsed.Narrative != "" {
-		state.Continuity.Add(consciousness.Thought{Text: parsed.Narrative})
+	state.Perceived = &perceived
+	state.Metrics.Ticks++
+	state.Metrics.PerceptionGapTotal += gap.Mean
+	state.Metrics.PerceptionGapMax = max(state.Metrics.PerceptionGapMax, gap.Mean)
+	if len(conflicts) > 0 {
+		state.Metrics.ConflictTicks++
+	}
+	if applied && state.Continuity != nil && parsed.Narrative != "" {
+		state.Continuity.Add(consciousness.Thought{Text: parsed.Narrative, Affect: parsed.State})
+	}
+
+	var formed []memory.Episode
+	var memoryErr error
+	if l.memory != nil {
+		moment := memory.Moment{
+			Tick:           state.Metrics.Ticks,
+			NowSeconds:     input.NowSeconds,
+			Bio:            state.Bio,
+			Drives:         motivationState,
+			Thresholds:     bioResult.Thresholds,
+			Valence:        parsed.State.Valence,
+			OperatorSpeech: input.Speech,
+		}
+		if applied {
+			moment.Arousal = parsed.State.Arousal
+			moment.Narrative = parsed.Narrative
+		}
+		if actionOutcome.Executed {
+			moment.ExecutedAction = actionOutcome.Action
+		}
+		formed, memoryErr = l.memory.Record(moment)
 	}
 
 	return TickResult{
 		Input:               input,
+		Perturbations:       perturbed,
+		Stimuli:             responses,
 		Bio:                 bioResult,
 		Motivation:          motivationState,
 		PerceivedMotivation: perceived,
+		PerceptionGap:       gap,
+		SpontaneousThought:  spontaneous,
+		ThoughtChainBroken:  chainBroken,
+		Conflicts:           conflicts,
 		Prompt:              prompt,
+		MindSkipped:         !applied,
+		Trigger:             trigger,
+		MindPending:         l.inflight != nil,
+		Arrival:             arrival,
+		MindErr:             mindErr,
+		EvaluatorErr:        evaluatorErr,
 		Raw:                 raw,
 		Parsed:              parsed,
+		ParseRetries:        parseRetries,
+		Speech:              speech,
 		ActionOutcome:       actionOutcome,
+		QueuedOutcome:       queuedOutcome,
+		Activity:            state.Activity,
+		Interruption:        interruption,
+		World:               world,
+		Environment:         environment,
+		WorldEffects:        worldEffects,
+		Recalled:            recalled,
+		Formed:              formed,
+		MemoryErr:           memoryErr,
+		HabituationErr:      habituationErr,
+	}
+}
+
+func memoryLines(episodes []memory.Episode, nowSeconds int64) []string {
+	if len(episodes) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(episodes))
+	for _, ep := range episodes {
+		lines = append(lines, memory.EpisodeLine(ep, nowSeconds))
+	}
+	return lines
+}
+
+// spontaneousThought draws this tick's spontaneous thought. Input and activity
+// interruptions first try to break an absorbed chain; a deep chain resists.
+func (l *SimulationLoop) spontaneousThought(
+	state *SimulationState,
+	carried motivation.MotivationState,
+	input TickInput,
+	recalled []string,
+	interrupted bool,
+) (*consciousness.Thought, bool) {
+	tick := state.Metrics.Ticks + 1
+	if state.Seeds == nil {
+		thought, ok := consciousness.SelectSpontaneousThought(carried, l.thoughts, tick)
+		if !ok {
+			return nil, false
+		}
+		return &thought, false
+	}
+
+	strength := 0.0
+	if len(input.Speech) > 0 {
+		strength = 0.6
+	} else if input.ExternalText != "" {
+		strength = 0.4
+	}
+	if interrupted {
+		strength = max(strength, 0.8)
+	}
+	broken := strength > 0 && state.Seeds.Interrupt(strength)
+
+	// ExternalText holds every perceived line once, speech included.
+	var inputs []string
+	if input.ExternalText != "" {
+		inputs = strings.Split(input.ExternalText, "\n")
+	}
+	src := consciousness.SeedSources{
+		Motivation: carried,
+		Memories:   recalled,
+		Inputs:     inputs,
+	}
+	if state.Continuity != nil {
+		src.Continuity = state.Continuity.Items()
+	}
+	thought, ok := state.Seeds.Next(src, tick)
+	if !ok {
+		return nil, broken
+	}
+	return &thought, broken
+}
+
+// shouldConsult applies the salience gate, if any, to this tick.
+func (l *SimulationLoop) shouldConsult(state *SimulationState, s gateSignals) (MindTrigger, bool) {
+	if l.salience == nil {
+		return TriggerAlways, true
+	}
+	return l.salience.evaluate(&state.Gate, s)
+}
+
+// applyMind adds the parse's somatic pulse and delivers any speech.
+func (l *SimulationLoop) applyMind(
+	parsed consciousness.ParsedResponse,
+	evaluated []biology.BioPulse,
+	world WorldState,
+	nowSeconds int64,
+	feedback *biology.TickFeedbackBuffer,
+) *Utterance {
+	feedback.AddPulses(consciousness.SomaticPulses(l.somatic, parsed.State, evaluated, l.evaluatorWeight))
+	if parsed.Speech == "" {
+		return nil
+	}
+	speech := &Utterance{Text: parsed.Speech, NowSeconds: nowSeconds, Heard: world.PeopleNearby}
+	feedback.AddPulses(consciousness.SpeechPulse(speech.Text, speech.Heard))
+	if l.speech != nil {
+		l.speech.Deliver(*speech)
 	}
+	return speech
 }
@@ -1,14 +1,23 @@
 package infrastructure_test
 
 import (
+	"context"
+	"errors"
 	"math"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/marczahn/person/v2/internal/biology"
 	"github.com/marczahn/person/v2/internal/consciousness"
 	"github.com/marczahn/person/v2/internal/infrastructure"
+	"github.com/marczahn/person/v2/internal/memory"
 	"github.com/marczahn/person/v2/internal/motivation"
+	"github.com/marczahn/person/v2/internal/sense"
 )
 
 type fakeInputDrainer struct {
@@ -63,11 +72,11 @@ type fakeMind struct {
 	capturedIn infrastructure.MindRequest
 }
 
-func (f *fakeMind) Respond(in infrastructure.MindRequest) string {
+func (f *fakeMind) Respond(_ context.Context, in infrastructure.MindRequest) (string, error) {
 	f.calls++
 	f.order = append(f.order, "consciousness")
 	f.capturedIn = in
-	return f.raw
+	return f.raw, nil
 }
 
 func TestSimulationLoop_TickRunsStagesSequentiallyOnce(t *testing.T) {
@@ -75,8 +84,7 @@ func TestSimulationLoop_TickRunsStagesSequentiallyOnce(t *testing.T) {
 	bio.Hunger = 0.70
 
 	drainer := &fakeInputDrainer{input: infrastructure.TickInput{
-		AllowedActions: map[string]bool{"eat": true},
-		NowSeconds:     42,
+		NowSeconds: 42,
 	}}
 	bioEngine := &fakeBioEngine{}
 	motivationComputer := &fakeMotivationComputer{result: motivation.MotivationState{
@@ -150,8 +158,7 @@ func TestSimulationLoop_FeedbackAppliesAtTickEnd(t *testing.T) {
 	bio.Hunger = 0.70
 
 	drainer := &fakeInputDrainer{input: infrastructure.TickInput{
-		AllowedActions: map[string]bool{"eat": true},
-		NowSeconds:     100,
+		NowSeconds: 100,
 	}}
 	bioEngine := &fakeBioEngine{}
 	motivationComputer := &fakeMotivationComputer{result: motivation.MotivationState{
@@ -188,3 +195,1018 @@ func TestSimulationLoop_FeedbackAppliesAtTickEnd(t *testing.T) {
 		t.Fatalf("expected end-of-tick feedback to apply eat pulse (0.70 -> 0.40), got %f", state.Bio.Hunger)
 	}
 }
+
+func TestSimulationLoop_ConflictsSurfaceInPromptResultAndMetrics(t *testing.T) {
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{}}
+	motivationComputer := &fakeMotivationComputer{result: motivation.MotivationState{
+		SafetyUrgency:     0.8,
+		SocialUrgency:     0.7,
+		ActiveGoalDrive:   motivation.DriveSafety,
+		ActiveGoalUrgency: 0.8,
+	}}
+	mind := &fakeMind{raw: "torn [STATE: arousal=0.2, valence=0.0] [ACTION: breathe]"}
+
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: motivationComputer,
+		Mind:       mind,
+	})
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	result := loop.Tick(&state, 1.0)
+
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Kind != motivation.ConflictApproachAvoidance {
+		t.Fatalf("expected one approach-avoidance conflict in result, got %+v", result.Conflicts)
+	}
+	if len(mind.capturedIn.Prompt.Conflicts) != 1 {
+		t.Fatalf("expected conflict surfaced in prompt, got %v", mind.capturedIn.Prompt.Conflicts)
+	}
+	if state.Metrics.Ticks != 1 || state.Metrics.ConflictTicks != 1 {
+		t.Fatalf("expected conflict tick counted, got %+v", state.Metrics)
+	}
+}
+
+func TestSimulationLoop_TimedActivityOccupiesAndAccumulatesOverTicks(t *testing.T) {
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{}}
+	mind := &fakeMind{raw: "lie down [STATE: arousal=0.0, valence=0.0] [ACTION: rest]"}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+		Activities: consciousness.ActivityCatalog{
+			"rest": {Duration: 4, Rates: []biology.BioRate{{Field: "energy", PerSecond: 0.02}}},
+		},
+	})
+
+	bio := biology.NewDefaultState()
+	bio.Energy = 0.5
+	state := infrastructure.SimulationState{Bio: *bio}
+
+	first := loop.Tick(&state, 1.0)
+	if !first.ActionOutcome.Executed || !first.Activity.Active() {
+		t.Fatalf("expected rest to start an activity, got outcome=%+v activity=%+v", first.ActionOutcome, first.Activity)
+	}
+	if math.Abs(state.Bio.Energy-0.52) > 1e-9 {
+		t.Fatalf("expected one second of rest effect (0.52), got %f", state.Bio.Energy)
+	}
+
+	mind.raw = "snack [STATE: arousal=0.0, valence=0.0] [ACTION: eat]"
+	second := loop.Tick(&state, 1.0)
+	if second.ActionOutcome.Executed || second.ActionOutcome.Reason != consciousness.OutcomeBusy {
+		t.Fatalf("expected eat rejected while resting, got %+v", second.ActionOutcome)
+	}
+
+	loop.Tick(&state, 1.0)
+	loop.Tick(&state, 1.0)
+	if state.Activity.Active() {
+		t.Fatalf("expected rest to complete after its duration, got %+v", state.Activity)
+	}
+	if math.Abs(state.Bio.Energy-0.58) > 1e-9 {
+		t.Fatalf("expected full rest effect (0.58), got %f", state.Bio.Energy)
+	}
+}
+
+func TestSimulationLoop_QueuedActionStartsWhenActivityFinishes(t *testing.T) {
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{}}
+	mind := &fakeMind{raw: "lie down [STATE: arousal=0.0, valence=0.0] [ACTION: rest]"}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+		Occupancy:  consciousness.OccupancyQueue,
+		Activities: consciousness.ActivityCatalog{
+			"rest": {Duration: 2},
+		},
+	})
+
+	bio := biology.NewDefaultState()
+	bio.Hunger = 0.7
+	state := infrastructure.SimulationState{Bio: *bio}
+
+	loop.Tick(&state, 1.0)
+	mind.raw = "then eat [STATE: arousal=0.0, valence=0.0] [ACTION: eat]"
+	result := loop.Tick(&state, 1.0)
+
+	if result.ActionOutcome.Reason != consciousness.OutcomeQueued {
+		t.Fatalf("expected eat to be queued, got %+v", result.ActionOutcome)
+	}
+	if result.QueuedOutcome == nil || !result.QueuedOutcome.Executed {
+		t.Fatalf("expected queued eat to execute when rest finished, got %+v", result.QueuedOutcome)
+	}
+	if math.Abs(state.Bio.Hunger-0.40) > 1e-9 {
+		t.Fatalf("expected queued eat pulse to apply (0.70 -> 0.40), got %f", state.Bio.Hunger)
+	}
+}
+
+func TestSimulationLoop_StrongStimulusInterruptsActivity(t *testing.T) {
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{}}
+	mind := &fakeMind{raw: "lie down [STATE: arousal=0.0, valence=0.0] [ACTION: rest]"}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+	})
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	loop.Tick(&state, 1.0)
+	if !state.Activity.Active() {
+		t.Fatal("expected rest activity to be running")
+	}
+
+	drainer.input.PreBioPulses = []biology.BioPulse{{Field: "stress", Amount: 0.20}}
+	mind.raw = "what was that [STATE: arousal=0.8, valence=-0.5] [ACTION: breathe]"
+	result := loop.Tick(&state, 1.0)
+
+	if result.Interruption == nil || result.Interruption.Activity.Action != "rest" {
+		t.Fatalf("expected rest to be interrupted, got %+v", result.Interruption)
+	}
+	if !result.ActionOutcome.Executed || result.ActionOutcome.Action != "breathe" {
+		t.Fatalf("expected the person to be free to act after interruption, got %+v", result.ActionOutcome)
+	}
+}
+
+func TestSimulationLoop_ComfortDoesNotInterruptRest(t *testing.T) {
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{}}
+	mind := &fakeMind{raw: "lie down [STATE: arousal=0.0, valence=0.0] [ACTION: rest]"}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+	})
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	loop.Tick(&state, 1.0)
+	drainer.input.Stimuli = []infrastructure.Stimulus{{Cue: sense.CueComfort, Pulses: []biology.BioPulse{
+		{Field: "stress", Amount: -0.12},
+		{Field: "physical_tension", Amount: -0.08},
+		{Field: "mood", Amount: 0.08},
+	}}}
+	result := loop.Tick(&state, 1.0)
+
+	if result.Interruption != nil || !state.Activity.Active() {
+		t.Fatalf("a hug should not break off rest, got %+v", result.Interruption)
+	}
+}
+
+func TestSimulationLoop_WorldPersistsAcrossTicksAndGatesOffersAndActions(t *testing.T) {
+	noFood := false
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{
+		World: infrastructure.WorldPatch{Food: &noFood},
+	}}
+	mind := &fakeMind{raw: "hungry [STATE: arousal=0.0, valence=0.0] [ACTION: eat]"}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+	})
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	loop.Tick(&state, 1.0)
+
+	drainer.input = infrastructure.TickInput{}
+	result := loop.Tick(&state, 1.0)
+
+	if state.World == nil || state.World.Food {
+		t.Fatalf("expected food to stay unavailable after the input tick, got %+v", state.World)
+	}
+	if result.ActionOutcome.Executed || result.ActionOutcome.Reason != consciousness.OutcomeBlocked {
+		t.Fatalf("expected eat blocked by persistent world, got %+v", result.ActionOutcome)
+	}
+	if mind.capturedIn.World.Food {
+		t.Fatal("expected world state visible to the mind")
+	}
+	for _, a := range mind.capturedIn.Offered {
+		if a == motivation.ActionEat {
+			t.Fatalf("mind must never be offered an impossible action, got %v", mind.capturedIn.Offered)
+		}
+	}
+}
+
+func TestSimulationLoop_PerceivedMotivationCarriesIntoNextPrompt(t *testing.T) {
+	motivationComputer := &fakeMotivationComputer{result: motivation.MotivationState{
+		EnergyUrgency:     0.9,
+		SocialUrgency:     0.5,
+		ActiveGoalDrive:   motivation.DriveEnergy,
+		ActiveGoalUrgency: 0.9,
+	}}
+	mind := &fakeMind{raw: "I'm fine [STATE: arousal=0.0, valence=0.0] [ACTION: breathe] [DRIVE: energy=0.1]"}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:              &fakeInputDrainer{},
+		Biology:            &fakeBioEngine{},
+		Motivation:         motivationComputer,
+		Mind:               mind,
+		PerceptionHalfLife: 1000,
+	})
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	first := loop.Tick(&state, 1.0)
+	if first.PerceptionGap.Mean != 0 {
+		t.Fatalf("expected no gap before the mind reported anything, got %+v", first.PerceptionGap)
+	}
+
+	mind.raw = "still fine [STATE: arousal=0.0, valence=0.0] [ACTION: breathe]"
+	second := loop.Tick(&state, 1.0)
+
+	if mind.capturedIn.Prompt.Primary[0].Drive != motivation.DriveSocialConnection {
+		t.Fatalf("expected denied energy to drop out of the top prompt drive, got %s", mind.capturedIn.Prompt.Primary[0].Drive)
+	}
+	if mind.capturedIn.Motivation.EnergyUrgency != 0.9 {
+		t.Fatalf("raw motivation must still be available to the mind, got %f", mind.capturedIn.Motivation.EnergyUrgency)
+	}
+	if second.PerceptionGap.Denial <= 0 {
+		t.Fatalf("expected denial gap to be tracked, got %+v", second.PerceptionGap)
+	}
+	if state.Metrics.MeanPerceptionGap() <= 0 {
+		t.Fatalf("expected session perception gap metric, got %+v", state.Metrics)
+	}
+}
+
+type scriptedMind struct {
+	replies  []string
+	requests []infrastructure.MindRequest
+}
+
+func (f *scriptedMind) Respond(_ context.Context, in infrastructure.MindRequest) (string, error) {
+	f.requests = append(f.requests, in)
+	return f.replies[min(len(f.requests), len(f.replies))-1], nil
+}
+
+func TestSimulationLoop_CorrectiveRetryRecoversUnusableOutput(t *testing.T) {
+	mind := &scriptedMind{replies: []string{
+		"I want to lie down.",
+		"Lying down. [STATE: arousal=-0.2, valence=0.1] [ACTION: breathe]",
+	}}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:       &fakeInputDrainer{},
+		Biology:     &fakeBioEngine{},
+		Motivation:  &fakeMotivationComputer{},
+		Mind:        mind,
+		ParsePolicy: infrastructure.ParseFailurePolicy{Retries: 2},
+	})
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	result := loop.Tick(&state, 1.0)
+
+	if len(mind.requests) != 2 || result.ParseRetries != 1 {
+		t.Fatalf("expected one corrective retry, got calls=%d retries=%d", len(mind.requests), result.ParseRetries)
+	}
+	retry := mind.requests[1]
+	if retry.Attempt != 1 || retry.Correction == "" {
+		t.Fatalf("expected retry request to carry a correction, got attempt=%d correction=%q", retry.Attempt, retry.Correction)
+	}
+	if result.Parsed.Status != consciousness.ParseStatusRecovered || result.Parsed.Action != "breathe" {
+		t.Fatalf("expected recovered parse with action breathe, got %+v", result.Parsed)
+	}
+	if state.Metrics.ParseRecovered != 1 || state.Metrics.ParseFailures[consciousness.ParseMissingState] != 1 {
+		t.Fatalf("expected recovery counted under original reason, got %+v", state.Metrics)
+	}
+}
+
+func TestSimulationLoop_NoopFallbackDropsStaleAction(t *testing.T) {
+	mind := &scriptedMind{replies: []string{"Just noise."}}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:       &fakeInputDrainer{},
+		Biology:     &fakeBioEngine{},
+		Motivation:  &fakeMotivationComputer{},
+		Mind:        mind,
+		ParsePolicy: infrastructure.ParseFailurePolicy{Retries: 1, Fallback: infrastructure.FallbackNoop},
+	})
+
+	state := infrastructure.SimulationState{
+		Bio:         *biology.NewDefaultState(),
+		PriorParsed: consciousness.ParsedResponse{Action: "eat", State: consciousness.ParsedState{Arousal: 0.8}},
+	}
+	result := loop.Tick(&state, 1.0)
+
+	if result.Parsed.Status != consciousness.ParseStatusNoop || result.Parsed.Action != "" {
+		t.Fatalf("expected noop parse without action, got %+v", result.Parsed)
+	}
+	if result.ActionOutcome.Executed || result.ActionOutcome.Reason != consciousness.OutcomeIdle {
+		t.Fatalf("expected idle outcome, got %+v", result.ActionOutcome)
+	}
+	if len(mind.requests) != 2 {
+		t.Fatalf("expected original call plus one retry, got %d", len(mind.requests))
+	}
+	if rate := state.Metrics.ParseFailureRate(); rate != 1 {
+		t.Fatalf("expected failure rate 1 after one failed turn, got %f", rate)
+	}
+}
+
+func TestSimulationLoop_DefaultPolicyReusesPriorWithoutRetry(t *testing.T) {
+	mind := &scriptedMind{replies: []string{"Just noise."}}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      &fakeInputDrainer{},
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+	})
+
+	state := infrastructure.SimulationState{
+		Bio:         *biology.NewDefaultState(),
+		PriorParsed: consciousness.ParsedResponse{Action: "breathe"},
+	}
+	result := loop.Tick(&state, 1.0)
+
+	if len(mind.requests) != 1 {
+		t.Fatalf("default policy must not retry, got %d calls", len(mind.requests))
+	}
+	if result.Parsed.Status != consciousness.ParseStatusFailed || result.Parsed.Action != "breathe" {
+		t.Fatalf("expected failed parse reusing prior action, got %+v", result.Parsed)
+	}
+}
+
+func TestSimulationLoop_SpeechIsDeliveredAndCountsAsContactWhenHeard(t *testing.T) {
+	mind := &fakeMind{raw: "Someone's here. [SPEECH: Hello?] [STATE: arousal=0, valence=0] [ACTION: breathe]"}
+	speech := infrastructure.NewSpeechBroadcaster()
+	client, disconnect := speech.Subscribe(4)
+	defer disconnect()
+
+	nobody, somebody := false, true
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{World: infrastructure.WorldPatch{PeopleNearby: &nobody}}}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+		Speech:     speech,
+	})
+
+	bio := biology.NewDefaultState()
+	bio.SocialDeficit = 0.6
+	state := infrastructure.SimulationState{Bio: *bio}
+	result := loop.Tick(&state, 1.0)
+
+	if result.Speech == nil || result.Speech.Text != "Hello?" || result.Speech.Heard {
+		t.Fatalf("expected unheard utterance in result, got %+v", result.Speech)
+	}
+	if got := <-client; got.Text != "Hello?" {
+		t.Fatalf("expected client to receive speech, got %+v", got)
+	}
+	if state.Bio.SocialDeficit < 0.6 {
+		t.Fatalf("speaking alone must not reduce social deficit, got %f", state.Bio.SocialDeficit)
+	}
+
+	drainer.input.World = infrastructure.WorldPatch{PeopleNearby: &somebody}
+	result = loop.Tick(&state, 1.0)
+	if !result.Speech.Heard {
+		t.Fatalf("expected utterance to be heard with people nearby")
+	}
+	if state.Bio.SocialDeficit >= 0.6 {
+		t.Fatalf("speaking to someone must reduce social deficit, got %f", state.Bio.SocialDeficit)
+	}
+}
+
+func TestSimulationLoop_EpisodicMemoryFormsAndIsRecalledIntoPrompt(t *testing.T) {
+	episodic, err := memory.NewEpisodic(&memory.InMemoryStore{}, memory.Former{}, memory.RecallConfig{})
+	if err != nil {
+		t.Fatalf("new episodic: %v", err)
+	}
+	mind := &fakeMind{raw: "Food at last. [STATE: arousal=0.2, valence=0.5] [ACTION: eat]"}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      &fakeInputDrainer{input: infrastructure.TickInput{NowSeconds: 10}},
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+		Memory:     episodic,
+	})
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	first := loop.Tick(&state, 1.0)
+	if len(first.Formed) != 1 || first.Formed[0].Kind != memory.KindAction {
+		t.Fatalf("expected executed action to form an episode, got %+v", first.Formed)
+	}
+	if len(first.Prompt.Memories) != 0 {
+		t.Fatalf("expected nothing to recall before any episode exists, got %q", first.Prompt.Memories)
+	}
+
+	second := loop.Tick(&state, 1.0)
+	if len(second.Recalled) == 0 || second.Recalled[0].ID != first.Formed[0].ID {
+		t.Fatalf("expected earlier episode recalled, got %+v", second.Recalled)
+	}
+	if len(mind.capturedIn.Prompt.Memories) == 0 {
+		t.Fatalf("expected recalled memories in the mind prompt")
+	}
+}
+
+type scriptedBioEngine struct {
+	thresholds [][]biology.ThresholdEvent
+	ticks      int
+}
+
+func (f *scriptedBioEngine) Tick(s *biology.State, dt float64) biology.TickResult {
+	f.ticks++
+	if f.ticks <= len(f.thresholds) {
+		return biology.TickResult{Thresholds: f.thresholds[f.ticks-1]}
+	}
+	return biology.TickResult{}
+}
+
+func TestSimulationLoop_SalienceGateSkipsQuietTicksAndCarriesPriorParse(t *testing.T) {
+	mind := &fakeMind{raw: "Fine. [STATE: arousal=0.0, valence=0.0] [ACTION: eat]"}
+	drainer := &fakeInputDrainer{}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+		Salience:   &infrastructure.SalienceGate{},
+	})
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	first := loop.Tick(&state, 1.0)
+	if first.MindSkipped || first.Trigger != infrastructure.TriggerFirst {
+		t.Fatalf("expected first tick to consult the mind, got skipped=%v trigger=%q", first.MindSkipped, first.Trigger)
+	}
+	hungerAfterEat := state.Bio.Hunger
+
+	quiet := loop.Tick(&state, 1.0)
+	if !quiet.MindSkipped || mind.calls != 1 {
+		t.Fatalf("expected quiet tick to skip the mind, got skipped=%v calls=%d", quiet.MindSkipped, mind.calls)
+	}
+	if quiet.Parsed.Action != "eat" || quiet.Raw != "" {
+		t.Fatalf("expected prior parse carried forward without raw output, got %+v raw=%q", quiet.Parsed, quiet.Raw)
+	}
+	if quiet.ActionOutcome.Executed || state.Bio.Hunger != hungerAfterEat {
+		t.Fatalf("carried parse must not re-execute its action, outcome=%+v hunger=%f", quiet.ActionOutcome, state.Bio.Hunger)
+	}
+	if state.Metrics.GatedTicks != 1 || state.Metrics.Ticks != 2 {
+		t.Fatalf("expected one gated tick out of two, got %+v", state.Metrics)
+	}
+
+	drainer.input.ExternalText = "hello?"
+	spoken := loop.Tick(&state, 1.0)
+	if spoken.MindSkipped || spoken.Trigger != infrastructure.TriggerInput {
+		t.Fatalf("expected operator input to consult the mind, got skipped=%v trigger=%q", spoken.MindSkipped, spoken.Trigger)
+	}
+}
+
+func TestSimulationLoop_SalienceGateFiresOnBioDriftAndThresholdOnset(t *testing.T) {
+	stress := biology.ThresholdEvent{Variable: "stress", Severity: biology.Warning}
+	bioEngine := &scriptedBioEngine{thresholds: [][]biology.ThresholdEvent{nil, {stress}, {stress}}}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      &fakeInputDrainer{},
+		Biology:    bioEngine,
+		Motivation: &fakeMotivationComputer{},
+		Mind:       &fakeMind{raw: "Hm. [STATE: arousal=0.0, valence=0.0] [ACTION: breathe]"},
+		Cooldowns:  consciousness.ActionCooldowns{"breathe": 100},
+		Salience:   &infrastructure.SalienceGate{BioDelta: 0.2},
+	})
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	loop.Tick(&state, 1.0)
+
+	onset := loop.Tick(&state, 1.0)
+	if onset.Trigger != infrastructure.TriggerThreshold {
+		t.Fatalf("expected threshold onset to consult the mind, got %q", onset.Trigger)
+	}
+	if sustained := loop.Tick(&state, 1.0); !sustained.MindSkipped {
+		t.Fatalf("expected a sustained threshold not to consult the mind again, got %q", sustained.Trigger)
+	}
+
+	state.Bio.Hunger += 0.1
+	if small := loop.Tick(&state, 1.0); !small.MindSkipped {
+		t.Fatalf("expected drift below BioDelta to stay gated")
+	}
+	state.Bio.Hunger += 0.15
+	if drift := loop.Tick(&state, 1.0); drift.Trigger != infrastructure.TriggerBio {
+		t.Fatalf("expected accumulated drift past BioDelta to consult the mind, got %q", drift.Trigger)
+	}
+}
+
+type blockingMind struct {
+	release  chan struct{}
+	raw      string
+	mu       sync.Mutex
+	requests []infrastructure.MindRequest
+}
+
+func (f *blockingMind) Respond(ctx context.Context, in infrastructure.MindRequest) (string, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, in)
+	f.mu.Unlock()
+	select {
+	case <-f.release:
+		return f.raw, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (f *blockingMind) sent() []infrastructure.MindRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]infrastructure.MindRequest(nil), f.requests...)
+}
+
+func awaitMind(t *testing.T, loop *infrastructure.SimulationLoop) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if !loop.AwaitMind(ctx) {
+		t.Fatalf("expected an in-flight mind request to answer")
+	}
+}
+
+func TestSimulationLoop_AsyncMindKeepsBiologyTickingAndAppliesOnArrival(t *testing.T) {
+	mind := &blockingMind{release: make(chan struct{}), raw: "Food. [STATE: arousal=0.0, valence=0.3] [ACTION: eat]"}
+	bioEngine := &fakeBioEngine{}
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{NowSeconds: 1}}
+	hungry := &fakeMotivationComputer{result: motivation.MotivationState{
+		EnergyUrgency:     0.8,
+		ActiveGoalDrive:   motivation.DriveEnergy,
+		ActiveGoalUrgency: 0.8,
+	}}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    bioEngine,
+		Motivation: hungry,
+		Mind:       mind,
+		Async:      &infrastructure.AsyncMindConfig{},
+	})
+	defer loop.Close()
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	first := loop.Tick(&state, 1.0)
+	if !first.MindPending || !first.MindSkipped {
+		t.Fatalf("expected request in flight without a reply, got pending=%v skipped=%v", first.MindPending, first.MindSkipped)
+	}
+	drainer.input.NowSeconds = 2
+	second := loop.Tick(&state, 1.0)
+	if bioEngine.calls != 2 || !second.MindPending {
+		t.Fatalf("expected biology to keep ticking while the mind thinks, calls=%d pending=%v", bioEngine.calls, second.MindPending)
+	}
+
+	close(mind.release)
+	awaitMind(t, loop)
+	if sent := mind.sent(); len(sent) != 1 {
+		t.Fatalf("expected at most one request in flight, got %d", len(sent))
+	}
+	drainer.input.NowSeconds = 3
+	third := loop.Tick(&state, 1.0)
+
+	if third.MindSkipped || third.Arrival == nil || third.Arrival.DispatchTick != 1 || third.Arrival.AgeSeconds != 2 {
+		t.Fatalf("expected reply dispatched on tick 1 applied on arrival, got %+v", third.Arrival)
+	}
+	if !third.ActionOutcome.Executed || third.ActionOutcome.Action != "eat" {
+		t.Fatalf("expected fresh reply to execute its action, got %+v", third.ActionOutcome)
+	}
+}
+
+func TestSimulationLoop_AsyncReplyPastStaleAfterDropsAction(t *testing.T) {
+	mind := &blockingMind{release: make(chan struct{}), raw: "Food. [STATE: arousal=0.0, valence=0.3] [ACTION: eat]"}
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{NowSeconds: 0}}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+		Async:      &infrastructure.AsyncMindConfig{StaleAfter: 10},
+	})
+	defer loop.Close()
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	loop.Tick(&state, 1.0)
+	close(mind.release)
+	awaitMind(t, loop)
+
+	drainer.input.NowSeconds = 60
+	late := loop.Tick(&state, 1.0)
+	if late.ActionOutcome.Executed || late.ActionOutcome.Reason != consciousness.OutcomeStale {
+		t.Fatalf("expected stale reply to drop its action, got %+v", late.ActionOutcome)
+	}
+	if late.Parsed.Narrative != "Food." {
+		t.Fatalf("expected stale reply's narrative to still apply, got %q", late.Parsed.Narrative)
+	}
+}
+
+func TestSimulationLoop_AsyncTimeoutReportsErrorAndHoldsInput(t *testing.T) {
+	mind := &blockingMind{release: make(chan struct{}), raw: "Ok. [STATE: arousal=0.0, valence=0.0] [ACTION: breathe]"}
+	drainer := &fakeInputDrainer{}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+		Salience:   &infrastructure.SalienceGate{},
+		Async:      &infrastructure.AsyncMindConfig{Timeout: 10 * time.Millisecond},
+	})
+	defer loop.Close()
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	loop.Tick(&state, 1.0)
+	drainer.input.ExternalText = "are you there?"
+	loop.Tick(&state, 1.0)
+	drainer.input.ExternalText = ""
+	awaitMind(t, loop)
+
+	timedOut := loop.Tick(&state, 1.0)
+	if !errors.Is(timedOut.MindErr, context.DeadlineExceeded) || state.Metrics.MindErrors != 1 {
+		t.Fatalf("expected timeout error, got err=%v metrics=%+v", timedOut.MindErr, state.Metrics)
+	}
+	if timedOut.Trigger != infrastructure.TriggerInput {
+		t.Fatalf("expected input held during the flight to trigger the next request, got %q", timedOut.Trigger)
+	}
+	awaitMind(t, loop)
+	sent := mind.sent()
+	if got := sent[len(sent)-1].Input.ExternalText; got != "are you there?" {
+		t.Fatalf("expected held input in the next request, got %q", got)
+	}
+}
+
+// deafMind ignores ctx and answers only when released.
+type deafMind struct {
+	release chan struct{}
+	raw     string
+	calls   atomic.Int32
+}
+
+func (f *deafMind) Respond(context.Context, infrastructure.MindRequest) (string, error) {
+	f.calls.Add(1)
+	<-f.release
+	return f.raw, nil
+}
+
+func TestSimulationLoop_AsyncTimeoutDropsResponderThatIgnoresContext(t *testing.T) {
+	mind := &deafMind{release: make(chan struct{}), raw: "Late. [STATE: arousal=0.0, valence=0.0] [ACTION: eat]"}
+	defer close(mind.release)
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      &fakeInputDrainer{},
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+		Salience:   &infrastructure.SalienceGate{},
+		Async:      &infrastructure.AsyncMindConfig{Timeout: 10 * time.Millisecond},
+	})
+	defer loop.Close()
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	if first := loop.Tick(&state, 1.0); !first.MindPending {
+		t.Fatal("expected a request in flight")
+	}
+	time.Sleep(20 * time.Millisecond)
+	timedOut := loop.Tick(&state, 1.0)
+	if !errors.Is(timedOut.MindErr, context.DeadlineExceeded) {
+		t.Fatalf("expected the flight dropped as a timeout, got %v", timedOut.MindErr)
+	}
+	for wait := time.Now().Add(time.Second); mind.calls.Load() < 2 && time.Now().Before(wait); {
+		time.Sleep(time.Millisecond)
+	}
+	if calls := mind.calls.Load(); calls != 2 || !timedOut.MindPending {
+		t.Fatalf("a dropped flight should free the pipeline for the next request, got %d calls, pending=%v", calls, timedOut.MindPending)
+	}
+}
+
+func TestSimulationLoop_AwaitMindReturnsAtTheDeadline(t *testing.T) {
+	mind := &deafMind{release: make(chan struct{})}
+	defer close(mind.release)
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      &fakeInputDrainer{},
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+		Async:      &infrastructure.AsyncMindConfig{Timeout: 10 * time.Millisecond},
+	})
+	defer loop.Close()
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	loop.Tick(&state, 1.0)
+	awaitMind(t, loop)
+	if got := loop.Tick(&state, 1.0); !errors.Is(got.MindErr, context.DeadlineExceeded) {
+		t.Fatalf("expected the timeout applied on the next tick, got %v", got.MindErr)
+	}
+}
+
+type failingMind struct{}
+
+func (failingMind) Respond(context.Context, infrastructure.MindRequest) (string, error) {
+	return "", errors.New("model unavailable")
+}
+
+func TestSimulationLoop_SyncMindErrorCarriesPriorParse(t *testing.T) {
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      &fakeInputDrainer{},
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       failingMind{},
+	})
+
+	state := infrastructure.SimulationState{
+		Bio:         *biology.NewDefaultState(),
+		PriorParsed: consciousness.ParsedResponse{Action: "rest"},
+	}
+	result := loop.Tick(&state, 1.0)
+	if result.MindErr == nil || !result.MindSkipped || result.Parsed.Action != "rest" {
+		t.Fatalf("expected error with prior parse carried, got err=%v parsed=%+v", result.MindErr, result.Parsed)
+	}
+	if result.ActionOutcome.Executed {
+		t.Fatalf("expected no action on a failed mind call, got %+v", result.ActionOutcome)
+	}
+}
+
+func TestMeteredMind_RefusesOverBudgetAndCarriesPriorParse(t *testing.T) {
+	mind := &fakeMind{raw: "[STATE: arousal=0.2, valence=0.1] [ACTION: rest] Quiet."}
+	metered := infrastructure.NewMeteredMind(mind, infrastructure.MindMeterConfig{
+		Budget: infrastructure.MindBudget{SessionTokens: 150},
+	})
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      &fakeInputDrainer{},
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       metered,
+	})
+
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+	first := loop.Tick(&state, 1.0)
+	if first.MindErr != nil || first.Parsed.Action != "rest" {
+		t.Fatalf("expected first call within budget, got err=%v parsed=%+v", first.MindErr, first.Parsed)
+	}
+	second := loop.Tick(&state, 1.0)
+	if !errors.Is(second.MindErr, infrastructure.ErrMindBudget) || second.Parsed.Action != "rest" {
+		t.Fatalf("expected budget refusal with prior parse, got err=%v parsed=%+v", second.MindErr, second.Parsed)
+	}
+	if mind.calls != 1 {
+		t.Fatalf("refused call reached the mind: %d calls", mind.calls)
+	}
+
+	usage := metered.Usage()
+	if usage.Calls != 1 || usage.Denied != 1 || usage.OutputTokens == 0 {
+		t.Fatalf("unexpected usage %+v", usage)
+	}
+	lines := infrastructure.BuildTaggedOutputLines(second, second.Motivation, 1)
+	if !strings.Contains(strings.Join(lines, "\n"), "[MIND] over LLM budget") {
+		t.Fatalf("expected budget line, got %q", lines)
+	}
+	if got := infrastructure.MindUsageLine(usage); !strings.Contains(got, "1 calls skipped by budget") {
+		t.Fatalf("unexpected usage line %q", got)
+	}
+}
+
+func TestMeteredMind_PerMinuteWindowRollsOver(t *testing.T) {
+	now := time.Date(2024, 6, 15, 8, 0, 0, 0, time.UTC)
+	metered := infrastructure.NewMeteredMind(&fakeMind{raw: strings.Repeat("x", 40)}, infrastructure.MindMeterConfig{
+		Budget: infrastructure.MindBudget{TokensPerMinute: 8},
+		Now:    func() time.Time { return now },
+	})
+
+	if _, err := metered.Respond(context.Background(), infrastructure.MindRequest{}); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if _, err := metered.Respond(context.Background(), infrastructure.MindRequest{}); !errors.Is(err, infrastructure.ErrMindBudget) {
+		t.Fatalf("expected per-minute refusal, got %v", err)
+	}
+	now = now.Add(61 * time.Second)
+	if _, err := metered.Respond(context.Background(), infrastructure.MindRequest{}); err != nil {
+		t.Fatalf("call after the window rolled over: %v", err)
+	}
+}
+
+// speechRequest is a request whose estimate is tokens input tokens.
+func speechRequest(trigger infrastructure.MindTrigger, tokens int) infrastructure.MindRequest {
+	return infrastructure.MindRequest{
+		Trigger: trigger,
+		Input:   infrastructure.TickInput{Speech: []string{strings.Repeat("x", 4*tokens)}},
+	}
+}
+
+func TestMeteredMind_ReservesEstimateWhileInFlight(t *testing.T) {
+	mind := &blockingMind{release: make(chan struct{}), raw: "ok"}
+	metered := infrastructure.NewMeteredMind(mind, infrastructure.MindMeterConfig{
+		Budget: infrastructure.MindBudget{SessionTokens: 150},
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := metered.Respond(context.Background(), speechRequest(infrastructure.TriggerInput, 100))
+		done <- err
+	}()
+	for len(mind.sent()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := metered.Respond(context.Background(), speechRequest(infrastructure.TriggerInput, 100)); !errors.Is(err, infrastructure.ErrMindBudget) {
+		t.Fatalf("a second call must count the first one's reservation, got %v", err)
+	}
+	close(mind.release)
+	if err := <-done; err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if usage := metered.Usage(); usage.Calls != 1 || usage.InputTokens != 100 || usage.Denied != 1 {
+		t.Fatalf("the reservation should settle into recorded usage, got %+v", usage)
+	}
+}
+
+func TestMeteredMind_ReserveShedsSelfGeneratedCallsAndCostsTokens(t *testing.T) {
+	metered := infrastructure.NewMeteredMind(&fakeMind{raw: strings.Repeat("x", 400)}, infrastructure.MindMeterConfig{
+		Budget:  infrastructure.MindBudget{SessionTokens: 1000},
+		Pricing: infrastructure.MindPricing{InputPerMTok: 3, OutputPerMTok: 15},
+	})
+
+	if _, err := metered.Respond(context.Background(), speechRequest(infrastructure.TriggerInput, 700)); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if !metered.Degraded() {
+		t.Fatal("800 of 1000 tokens should reach the default 20% reserve")
+	}
+	_, err := metered.Respond(context.Background(), speechRequest(infrastructure.TriggerSpontaneous, 10))
+	if !errors.Is(err, infrastructure.ErrMindBudget) || !strings.Contains(err.Error(), "reserve") {
+		t.Fatalf("a spontaneous thought should be shed in the reserve, got %v", err)
+	}
+	if _, err := metered.Respond(context.Background(), speechRequest(infrastructure.TriggerInput, 10)); err != nil {
+		t.Fatalf("answering input may use the reserve: %v", err)
+	}
+
+	report := metered.Report()
+	if want := (710*3 + 200*15) / 1e6; math.Abs(report.CostUSD-want) > 1e-12 {
+		t.Errorf("cost = %v, want %v", report.CostUSD, want)
+	}
+	if spont := report.ByTrigger[infrastructure.TriggerSpontaneous]; spont.Denied != 1 || spont.Calls != 0 {
+		t.Errorf("spontaneous usage = %+v", spont)
+	}
+	if !strings.Contains(report.String(), "$0.0051") {
+		t.Errorf("report line should show the cost, got %q", report.String())
+	}
+}
+
+type countingCompleter struct {
+	raw   string
+	calls int
+}
+
+func (c *countingCompleter) Complete(_ context.Context, _ string) (string, error) {
+	c.calls++
+	return c.raw, nil
+}
+
+func TestMeteredMind_CompleterSharesTheBudgetAndIsShedInTheReserve(t *testing.T) {
+	metered := infrastructure.NewMeteredMind(&fakeMind{raw: "ok"}, infrastructure.MindMeterConfig{
+		Budget: infrastructure.MindBudget{SessionTokens: 1000},
+	})
+	model := &countingCompleter{raw: strings.Repeat("x", 400)}
+	evaluator := consciousness.LLMEvaluator{Model: metered.Completer(model, infrastructure.TriggerEvaluator)}
+
+	if _, err := evaluator.Evaluate(context.Background(), "a thought", consciousness.ParsedState{}); err == nil || errors.Is(err, infrastructure.ErrMindBudget) {
+		t.Fatalf("the first evaluation should reach the model (and fail to parse its reply), got %v", err)
+	}
+	if got := metered.Report().ByTrigger[infrastructure.TriggerEvaluator]; got.Calls != 1 || got.OutputTokens != 100 {
+		t.Fatalf("evaluator usage = %+v", got)
+	}
+
+	// A mind call brings the session past the reserve but not the limit.
+	if _, err := metered.Respond(context.Background(), speechRequest(infrastructure.TriggerInput, 500)); err != nil {
+		t.Fatalf("mind call: %v", err)
+	}
+	_, err := evaluator.Evaluate(context.Background(), "a thought", consciousness.ParsedState{})
+	if !errors.Is(err, infrastructure.ErrMindBudget) || model.calls != 1 {
+		t.Fatalf("evaluations should be shed in the reserve before reaching the model, got %v after %d calls", err, model.calls)
+	}
+}
+
+func TestMindSessionRecords_AppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.jsonl")
+	if got, err := infrastructure.LoadMindSessions(path); err != nil || got != nil {
+		t.Fatalf("a missing file holds no sessions: %v, %v", got, err)
+	}
+
+	metered := infrastructure.NewMeteredMind(&fakeMind{raw: "ok"}, infrastructure.MindMeterConfig{})
+	metered.Respond(context.Background(), speechRequest(infrastructure.TriggerInput, 10))
+	metered.Respond(context.Background(), speechRequest(infrastructure.TriggerBio, 5))
+	ended := time.Date(2024, 6, 15, 9, 0, 0, 0, time.UTC)
+	rec := metered.Report().SessionRecord("test-model", ended)
+	for range 2 {
+		if err := infrastructure.AppendMindSession(path, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loaded, err := infrastructure.LoadMindSessions(path)
+	if err != nil || len(loaded) != 2 {
+		t.Fatalf("loaded %d sessions, err %v", len(loaded), err)
+	}
+	got := loaded[1]
+	if got.Model != "test-model" || !got.EndedAt.Equal(ended) || len(got.Triggers) != 2 ||
+		got.Triggers[0].Trigger != infrastructure.TriggerBio || got.Triggers[1].InputTokens != 10 {
+		t.Errorf("unexpected record %+v", got)
+	}
+}
+
+type stubEvaluator struct {
+	pulses    []biology.BioPulse
+	err       error
+	narrative string
+}
+
+func (s *stubEvaluator) Evaluate(_ context.Context, narrative string, _ consciousness.ParsedState) ([]biology.BioPulse, error) {
+	s.narrative = narrative
+	return s.pulses, s.err
+}
+
+func TestSimulationLoop_SomaticModeSelectsEvaluatorPulses(t *testing.T) {
+	raw := "[STATE: arousal=0.8, valence=-0.6] [ACTION: journal] Nobody answers."
+	stressAfter := func(mode consciousness.SomaticMode, eval *stubEvaluator) (float64, infrastructure.TickResult) {
+		loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+			Input:      &fakeInputDrainer{},
+			Biology:    &fakeBioEngine{},
+			Motivation: &fakeMotivationComputer{},
+			Mind:       &fakeMind{raw: raw},
+			Activities: consciousness.ActivityCatalog{},
+			Somatic:    mode,
+			Evaluator:  eval,
+		})
+		state := infrastructure.SimulationState{Bio: *biology.NewDefaultState()}
+		result := loop.Tick(&state, 1.0)
+		return state.Bio.Stress, result
+	}
+
+	baseline, _ := stressAfter(consciousness.SomaticStatePulse, nil)
+	eval := &stubEvaluator{pulses: []biology.BioPulse{{Field: "stress", Amount: 0.01}}}
+	evaluated, result := stressAfter(consciousness.SomaticEvaluator, eval)
+	if eval.narrative != "Nobody answers." {
+		t.Fatalf("evaluator should see only the narrative, got %q", eval.narrative)
+	}
+	if result.EvaluatorErr != nil || evaluated >= baseline {
+		t.Fatalf("expected the milder evaluated pulse to replace the state pulse: state=%f evaluator=%f err=%v",
+			baseline, evaluated, result.EvaluatorErr)
+	}
+
+	failing := &stubEvaluator{err: errors.New("offline")}
+	fallback, result := stressAfter(consciousness.SomaticEvaluator, failing)
+	if result.EvaluatorErr == nil || math.Abs(fallback-baseline) > 1e-9 {
+		t.Fatalf("expected state pulse fallback with error: state=%f got=%f err=%v", baseline, fallback, result.EvaluatorErr)
+	}
+}
+
+func TestSimulationLoop_ThoughtSeederDrawsFromInputAndAbsorptionResistsIt(t *testing.T) {
+	seeder := consciousness.NewThoughtSeeder(consciousness.SeederConfig{
+		Schedule: consciousness.TickSchedule{EveryTicks: 1},
+		Seed:     3,
+	})
+	// With no drives, memories or inputs, drift is the only seed and deepens into a chain.
+	for tick := 1; tick <= 4; tick++ {
+		seeder.Next(consciousness.SeedSources{}, tick)
+	}
+	if !seeder.Absorption().Absorbed() || seeder.Absorption().Level < 1 {
+		t.Fatalf("expected a fully absorbed drift chain, got %+v", seeder.Absorption())
+	}
+
+	drainer := &fakeInputDrainer{input: infrastructure.TickInput{Speech: []string{"are you there?"}, ExternalText: "are you there?"}}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      drainer,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: none] Hm."},
+	})
+	state := infrastructure.SimulationState{
+		Bio:        *biology.NewDefaultState(),
+		Continuity: consciousness.NewContinuityBuffer(5),
+		Seeds:      seeder,
+	}
+
+	result := loop.Tick(&state, 1.0)
+	if result.ThoughtChainBroken {
+		t.Fatal("speech should not break a fully absorbed chain")
+	}
+	if result.SpontaneousThought == nil {
+		t.Fatal("expected a seeded spontaneous thought")
+	}
+
+	var echoes []string
+	for _, seed := range seeder.Candidates(consciousness.SeedSources{}, 6) {
+		if seed.Thought.Category == consciousness.ThoughtCategoryInput {
+			echoes = append(echoes, seed.Thought.Text)
+		}
+	}
+	if len(echoes) != 1 || echoes[0] != "“are you there?” keeps echoing." {
+		t.Fatalf("operator speech should become exactly one input seed, got %q", echoes)
+	}
+}
+
+func TestSimulationLoop_BeingSpokenToIsCompany(t *testing.T) {
+	adapter := infrastructure.NewInputAdapter(sense.NewParser(), func() int64 { return 0 })
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      adapter,
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: none] [SPEECH: Hi.] Someone is here."},
+		Activities: consciousness.ActivityCatalog{},
+	})
+	alone := infrastructure.DefaultWorldState()
+	alone.PeopleNearby = false
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState(), World: &alone}
+
+	if r := loop.Tick(&state, 1); r.Speech == nil || r.Speech.Heard {
+		t.Fatalf("nobody is around to hear the first reply: %+v", r.Speech)
+	}
+	adapter.Enqueue("hey, how are you?")
+	r := loop.Tick(&state, 1)
+	if r.Speech == nil || !r.Speech.Heard {
+		t.Fatalf("answering someone who spoke to you is heard: %+v", r.Speech)
+	}
+	if state.World.PeopleNearby {
+		t.Error("contact lasts for the tick and must not change the world")
+	}
+}
@@ -1,9 +1,11 @@
 package infrastructure
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/consciousness"
 	"github.com/marczahn/person/v2/internal/motivation"
 	"github.com/marczahn/person/v2/internal/output"
 )
@@ -14,12 +16,59 @@ func BuildTaggedOutputLines(result TickResult, previous motivation.MotivationSta
 	lines = append(lines, output.FormatDriveChangeLines(
 		output.SignificantDriveChanges(previous, result.Motivation, driveThreshold),
 	)...)
+	if !result.MindSkipped {
+		lines = append(lines, mindLines(result)...)
+	}
+	if errors.Is(result.MindErr, ErrMindBudget) {
+		lines = append(lines, output.FormatTaggedLine(output.SourceMIND, "over LLM budget, reusing prior state and action"))
+	}
+	return append(lines, activityLines(result)...)
+}
+
+func mindLines(result TickResult) []string {
+	var lines []string
 	if result.Parsed.Narrative != "" {
 		lines = append(lines, output.FormatTaggedLine(output.SourceMIND, result.Parsed.Narrative))
 	}
+	if result.Speech != nil {
+		lines = append(lines, output.FormatTaggedLine(output.SourceSPEECH, fmt.Sprintf("%q", result.Speech.Text)))
+	}
+	if line := parseStatusLine(result); line != "" {
+		lines = append(lines, output.FormatTaggedLine(output.SourceMIND, line))
+	}
+	return lines
+}
+
+func activityLines(result TickResult) []string {
+	var lines []string
+	if result.Interruption != nil {
+		lines = append(lines, output.FormatTaggedLine(
+			output.SourceACTION,
+			fmt.Sprintf("%s interrupted: %s", result.Interruption.Activity.Action, result.Interruption.Reason),
+		))
+	}
+	if result.Activity.Active() {
+		lines = append(lines, output.FormatTaggedLine(
+			output.SourceACTION,
+			fmt.Sprintf("%s (%.0fs of %.0fs)", result.Activity.Action, result.Activity.Elapsed, result.Activity.Duration),
+		))
+	}
 	return lines
 }
 
+func parseStatusLine(result TickResult) string {
+	switch result.Parsed.Status {
+	case consciousness.ParseStatusFailed:
+		return fmt.Sprintf("parse failed (%s), reusing prior state and action", result.Parsed.Reason)
+	case consciousness.ParseStatusNoop:
+		return fmt.Sprintf("parse failed (%s), doing nothing this tick", result.Parsed.Reason)
+	case consciousness.ParseStatusRecovered:
+		return fmt.Sprintf("parse recovered after %d corrective retries (%s)", result.ParseRetries, result.Parsed.Reason)
+	default:
+		return ""
+	}
+}
+
 func formatBIOLine(bioTick biology.TickResult) string {
 	if len(bioTick.Deltas) == 0 && len(bioTick.Thresholds) == 0 {
 		return output.FormatTaggedLine(output.SourceBIO, "no significant biological deltas")
@@ -42,3 +42,51 @@ func TestBuildTaggedOutputLines_EmitsBIOAndMINDAndSignificantDRIVES(t *testing.T
 		t.Fatalf("unexpected tagged output lines: got=%v want=%v", got, want)
 	}
 }
+
+func TestBuildTaggedOutputLines_ReportsParseFailure(t *testing.T) {
+	result := infrastructure.TickResult{
+		Parsed: consciousness.ParsedResponse{
+			Status: consciousness.ParseStatusFailed,
+			Reason: consciousness.ParseMissingAction,
+		},
+	}
+
+	got := infrastructure.BuildTaggedOutputLines(result, motivation.MotivationState{}, 0.15)
+	want := []string{
+		"[BIO] no significant biological deltas",
+		"[MIND] parse failed (missing_action), reusing prior state and action",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tagged output lines: got=%v want=%v", got, want)
+	}
+}
+
+func TestBuildTaggedOutputLines_EmitsSpeechSeparateFromThought(t *testing.T) {
+	result := infrastructure.TickResult{
+		Parsed: consciousness.ParsedResponse{Narrative: "I hope they answer.", Speech: "Hello?"},
+		Speech: &infrastructure.Utterance{Text: "Hello?"},
+	}
+
+	got := infrastructure.BuildTaggedOutputLines(result, motivation.MotivationState{}, 0.15)
+	want := []string{
+		"[BIO] no significant biological deltas",
+		"[MIND] I hope they answer.",
+		`[SPEECH] "Hello?"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tagged output lines: got=%v want=%v", got, want)
+	}
+}
+
+func TestBuildTaggedOutputLines_SkippedMindTickDoesNotRepeatNarrative(t *testing.T) {
+	result := infrastructure.TickResult{
+		MindSkipped: true,
+		Parsed:      consciousness.ParsedResponse{Narrative: "I should eat now."},
+	}
+
+	got := infrastructure.BuildTaggedOutputLines(result, motivation.MotivationState{}, 0.15)
+	want := []string{"[BIO] no significant biological deltas"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tagged output lines: got=%v want=%v", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+package infrastructure
+
+import "sync"
+
+// Utterance is something the person said aloud during a tick.
+type Utterance struct {
+	Text       string
+	NowSeconds int64
+	// Heard reports whether someone was present to hear it.
+	Heard bool
+}
+
+// SpeechSink delivers the person's speech to whoever is listening.
+type SpeechSink interface {
+	Deliver(u Utterance)
+}
+
+// SpeechBroadcaster fans utterances out to connected clients.
+// Delivery never blocks the simulation: a client whose buffer is full misses the utterance.
+type SpeechBroadcaster struct {
+	mu      sync.RWMutex
+	clients map[chan Utterance]struct{}
+}
+
+func NewSpeechBroadcaster() *SpeechBroadcaster {
+	return &SpeechBroadcaster{clients: make(map[chan Utterance]struct{})}
+}
+
+// Subscribe connects a client with the given buffer size. The returned function
+// disconnects it and closes the channel.
+func (b *SpeechBroadcaster) Subscribe(buffer int) (<-chan Utterance, func()) {
+	ch := make(chan Utterance, max(buffer, 1))
+	b.mu.Lock()
+	b.clients[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.clients, ch)
+			close(ch)
+		})
+	}
+}
+
+func (b *SpeechBroadcaster) Deliver(u Utterance) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.clients {
+		select {
+		case ch <- u:
+		default:
+			// Client too slow, drop utterance.
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package infrastructure
+
+import "github.com/marczahn/person/v2/internal/motivation"
+
+// WorldState is the persistent environment that gates what the person can do.
+// It is the single source for both the allowed action set and the motivation
+// action constraints, so the mind is never offered or granted an impossible action.
+type WorldState struct {
+	Food         bool
+	Water        bool
+	QuietSpace   bool
+	PeopleNearby bool
+	Explorable   bool
+	// CanMove is derived from a WorldModel with reachable locations; no input patches it.
+	CanMove bool
+}
+
+// DefaultWorldState returns an unrestricted world: everything is available.
+func DefaultWorldState() WorldState {
+	return WorldState{
+		Food:         true,
+		Water:        true,
+		QuietSpace:   true,
+		PeopleNearby: true,
+		Explorable:   true,
+	}
+}
+
+// WorldPatch carries world changes drained from input. Nil fields leave the world unchanged.
+type WorldPatch struct {
+	Food         *bool
+	Water        *bool
+	QuietSpace   *bool
+	PeopleNearby *bool
+	Explorable   *bool
+}
+
+// Apply returns w with all set patch fields applied.
+func (p WorldPatch) Apply(w WorldState) WorldState {
+	if p.Food != nil {
+		w.Food = *p.Food
+	}
+	if p.Water != nil {
+		w.Water = *p.Water
+	}
+	if p.QuietSpace != nil {
+		w.QuietSpace = *p.QuietSpace
+	}
+	if p.PeopleNearby != nil {
+		w.PeopleNearby = *p.PeopleNearby
+	}
+	if p.Explorable != nil {
+		w.Explorable = *p.Explorable
+	}
+	return w
+}
+
+// Empty reports whether the patch changes nothing.
+func (p WorldPatch) Empty() bool {
+	return p.Food == nil && p.Water == nil && p.QuietSpace == nil && p.PeopleNearby == nil && p.Explorable == nil
+}
+
+// AllowedActions derives the action gate from the world.
+func (w WorldState) AllowedActions() map[string]bool {
+	return map[string]bool{
+		string(motivation.ActionRest):      w.QuietSpace,
+		string(motivation.ActionEat):       w.Food,
+		string(motivation.ActionHydrate):   w.Water,
+		string(motivation.ActionReachOut):  w.PeopleNearby,
+		string(motivation.ActionJournal):   true,
+		string(motivation.ActionBreathe):   true,
+		string(motivation.ActionScanArea):  true,
+		string(motivation.ActionSeekWarm):  true,
+		string(motivation.ActionSeekCool):  true,
+		string(motivation.ActionMicroTask): true,
+		string(motivation.ActionMove):      w.CanMove,
+	}
+}
+
+// Constraints derives the motivation action constraints from the world.
+func (w WorldState) Constraints() motivation.ActionConstraints {
+	return motivation.ActionConstraints{
+		HasFood:         w.Food,
+		HasPeopleNearby: w.PeopleNearby,
+		CanRest:         w.QuietSpace,
+		CanExplore:      w.Explorable,
+		HasQuietSpace:   w.QuietSpace,
+		CanMove:         w.CanMove,
+	}
+}
+
+// OfferedActions returns the goal's action candidates that the world actually permits.
+func (w WorldState) OfferedActions(goal motivation.Drive) []motivation.Action {
+	allowed := w.AllowedActions()
+	candidates := motivation.ActionCandidatesFor(goal, w.Constraints())
+	out := make([]motivation.Action, 0, len(candidates))
+	for _, a := range candidates {
+		if allowed[string(a)] {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
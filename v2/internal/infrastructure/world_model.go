@@ -0,0 +1,362 @@
+package infrastructure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// Resource is a consumable stock held by objects.
+type Resource string
+
+const (
+	ResourceFood  Resource = "food"
+	ResourceWater Resource = "water"
+)
+
+// Unlimited marks a stock that never runs out (a tap, a river).
+const Unlimited = -1
+
+// actionCosts maps actions to the resource one execution consumes.
+var actionCosts = map[motivation.Action]Resource{
+	motivation.ActionEat:     ResourceFood,
+	motivation.ActionHydrate: ResourceWater,
+}
+
+// WorldObject is a thing at a location that holds resource stocks.
+type WorldObject struct {
+	Name string
+	// Stocks counts units per resource; Unlimited never runs out.
+	Stocks map[Resource]int
+}
+
+// Location is one place the person can be.
+type Location struct {
+	Name string
+	// Ambient is applied to the environment on arrival.
+	Ambient      AmbientPatch
+	Quiet        bool
+	PeopleNearby bool
+	Explorable   bool
+	// Exits name the locations reachable from here, in preference order.
+	Exits   []string
+	Objects []WorldObject
+	// withheld are resources input has made unavailable here, whatever the
+	// objects hold; their stocks return when the resource does.
+	withheld map[Resource]bool
+}
+
+// WorldModel is a small world of locations, objects and resource stocks.
+// It is the source the boolean WorldState is derived from: food is available
+// while an object here holds food, and eat consumes one unit of it.
+// Contract: all methods are deterministic; ties go to declaration order.
+type WorldModel struct {
+	locations []Location
+	index     map[string]int
+	current   int
+	// company are the other persons at the current location.
+	company []string
+}
+
+// NewWorldModel builds a model starting at start. Location names must be
+// unique and every exit must name a location.
+func NewWorldModel(start string, locations ...Location) (*WorldModel, error) {
+	m := &WorldModel{index: make(map[string]int, len(locations))}
+	for _, loc := range locations {
+		name := strings.TrimSpace(loc.Name)
+		if name == "" {
+			return nil, fmt.Errorf("location name must not be empty")
+		}
+		if _, dup := m.index[name]; dup {
+			return nil, fmt.Errorf("duplicate location %q", name)
+		}
+		loc.Name = name
+		loc.Exits = append([]string(nil), loc.Exits...)
+		loc.Objects = cloneObjects(loc.Objects)
+		m.index[name] = len(m.locations)
+		m.locations = append(m.locations, loc)
+	}
+	for _, loc := range m.locations {
+		for _, exit := range loc.Exits {
+			if _, ok := m.index[exit]; !ok {
+				return nil, fmt.Errorf("location %q: exit to unknown location %q", loc.Name, exit)
+			}
+		}
+	}
+	i, ok := m.index[strings.TrimSpace(start)]
+	if !ok {
+		return nil, fmt.Errorf("unknown start location %q", start)
+	}
+	m.current = i
+	return m, nil
+}
+
+// Here returns a copy of the current location.
+func (m *WorldModel) Here() Location {
+	return cloneLocation(m.locations[m.current])
+}
+
+// Location returns a copy of the named location.
+func (m *WorldModel) Location(name string) (Location, bool) {
+	i, ok := m.index[name]
+	if !ok {
+		return Location{}, false
+	}
+	return cloneLocation(m.locations[i]), true
+}
+
+// Stock returns the units of r available at the current location, or Unlimited.
+func (m *WorldModel) Stock(r Resource) int {
+	return availableAt(m.locations[m.current], r)
+}
+
+// State derives the boolean world the action gate and constraints read.
+func (m *WorldModel) State() WorldState {
+	w := m.stateAt(m.locations[m.current])
+	w.PeopleNearby = w.PeopleNearby || len(m.company) > 0
+	return w
+}
+
+func (m *WorldModel) stateAt(loc Location) WorldState {
+	return WorldState{
+		Food:         availableAt(loc, ResourceFood) != 0,
+		Water:        availableAt(loc, ResourceWater) != 0,
+		QuietSpace:   loc.Quiet,
+		PeopleNearby: loc.PeopleNearby,
+		Explorable:   loc.Explorable,
+		CanMove:      len(loc.Exits) > 0,
+	}
+}
+
+// SetCompany names the other persons at the current location. Company counts
+// as people nearby; it is not part of the location and does not move with it.
+func (m *WorldModel) SetCompany(names []string) {
+	m.company = append([]string(nil), names...)
+}
+
+// WorldEffect is what an executed action did to the world.
+type WorldEffect struct {
+	Action string
+	// Consumed is the resource used up, taken from Object.
+	Consumed Resource
+	Object   string
+	// From and To are set when the person moved.
+	From string
+	To   string
+}
+
+// Perform applies an executed action: eat and hydrate consume one unit from
+// the first object here holding the resource, and move goes to Destination(goal).
+// It reports false when the action does not touch the world.
+func (m *WorldModel) Perform(action string, goal motivation.Drive) (WorldEffect, bool) {
+	a := motivation.Action(action)
+	if a == motivation.ActionMove {
+		to, ok := m.Destination(goal)
+		if !ok {
+			return WorldEffect{}, false
+		}
+		from := m.locations[m.current].Name
+		m.current = m.index[to]
+		return WorldEffect{Action: action, From: from, To: to}, true
+	}
+	r, ok := actionCosts[a]
+	if !ok {
+		return WorldEffect{}, false
+	}
+	loc := &m.locations[m.current]
+	if loc.withheld[r] {
+		return WorldEffect{}, false
+	}
+	for i := range loc.Objects {
+		obj := &loc.Objects[i]
+		n := obj.Stocks[r]
+		if n == 0 {
+			continue
+		}
+		if n > 0 {
+			obj.Stocks[r] = n - 1
+		}
+		return WorldEffect{Action: action, Consumed: r, Object: obj.Name}, true
+	}
+	return WorldEffect{}, false
+}
+
+// Destination picks where a move toward goal leads: the exit offering the most
+// of the goal's other actions, the first exit on a tie.
+func (m *WorldModel) Destination(goal motivation.Drive) (string, bool) {
+	exits := m.locations[m.current].Exits
+	if len(exits) == 0 {
+		return "", false
+	}
+	best, bestScore := exits[0], -1
+	for _, exit := range exits {
+		there := m.stateAt(m.locations[m.index[exit]])
+		there.CanMove = false
+		score := len(there.OfferedActions(goal))
+		if score > bestScore {
+			best, bestScore = exit, score
+		}
+	}
+	return best, true
+}
+
+// MoveTo puts the person at a location directly, as an operator or game would.
+func (m *WorldModel) MoveTo(name string) error {
+	i, ok := m.index[strings.TrimSpace(name)]
+	if !ok {
+		return fmt.Errorf("unknown location %q", name)
+	}
+	m.current = i
+	return nil
+}
+
+// Sync carries boolean changes made to the derived world (keyword input,
+// expiring environment changes) back into the current location: food or water
+// that disappears is withheld here without touching the stocks, food or water
+// that appears returns them, adding one unit in an object named after the
+// resource when nothing is left.
+func (m *WorldModel) Sync(before, after WorldState) {
+	loc := &m.locations[m.current]
+	syncStock := func(r Resource, was, is bool) {
+		switch {
+		case was == is:
+		case !is:
+			if loc.withheld == nil {
+				loc.withheld = make(map[Resource]bool)
+			}
+			loc.withheld[r] = true
+		default:
+			delete(loc.withheld, r)
+			if stockAt(*loc, r) != 0 {
+				return
+			}
+			for i := range loc.Objects {
+				if loc.Objects[i].Name == string(r) {
+					loc.Objects[i].Stocks[r] = 1
+					return
+				}
+			}
+			loc.Objects = append(loc.Objects, WorldObject{Name: string(r), Stocks: map[Resource]int{r: 1}})
+		}
+	}
+	syncStock(ResourceFood, before.Food, after.Food)
+	syncStock(ResourceWater, before.Water, after.Water)
+	if before.QuietSpace != after.QuietSpace {
+		loc.Quiet = after.QuietSpace
+	}
+	if before.PeopleNearby != after.PeopleNearby {
+		loc.PeopleNearby = after.PeopleNearby
+	}
+	if before.Explorable != after.Explorable {
+		loc.Explorable = after.Explorable
+	}
+}
+
+// Line renders the current location for the mind.
+func (m *WorldModel) Line() string {
+	loc := m.locations[m.current]
+	var b strings.Builder
+	fmt.Fprintf(&b, "You are in the %s.", loc.Name)
+	var things []string
+	for _, obj := range loc.Objects {
+		if desc := describeObject(obj, loc.withheld); desc != "" {
+			things = append(things, desc)
+		}
+	}
+	if len(things) > 0 {
+		fmt.Fprintf(&b, " There is %s.", joinWithAnd(things))
+	}
+	switch len(m.company) {
+	case 0:
+	case 1:
+		fmt.Fprintf(&b, " %s is here.", m.company[0])
+	default:
+		fmt.Fprintf(&b, " %s are here.", joinWithAnd(m.company))
+	}
+	if len(loc.Exits) > 0 {
+		fmt.Fprintf(&b, " From here you can go to the %s.", joinWithAnd(loc.Exits))
+	}
+	return b.String()
+}
+
+// describeObject renders "a fridge (3 food)", or just "2 food" for the loose
+// stock Sync creates; an empty loose stock renders nothing, and withheld
+// resources count as empty.
+func describeObject(obj WorldObject, withheld map[Resource]bool) string {
+	resources := make([]string, 0, len(obj.Stocks))
+	for r := range obj.Stocks {
+		if !withheld[r] {
+			resources = append(resources, string(r))
+		}
+	}
+	sort.Strings(resources)
+	var parts []string
+	for _, r := range resources {
+		switch n := obj.Stocks[Resource(r)]; {
+		case n == Unlimited:
+			parts = append(parts, r)
+		case n > 0:
+			parts = append(parts, fmt.Sprintf("%d %s", n, r))
+		}
+	}
+	_, loose := obj.Stocks[Resource(obj.Name)]
+	switch {
+	case loose && len(obj.Stocks) == 1:
+		return strings.Join(parts, "")
+	case len(parts) == 0:
+		return "a " + obj.Name
+	}
+	return fmt.Sprintf("a %s (%s)", obj.Name, strings.Join(parts, ", "))
+}
+
+// availableAt is stockAt, or none while r is withheld at loc.
+func availableAt(loc Location, r Resource) int {
+	if loc.withheld[r] {
+		return 0
+	}
+	return stockAt(loc, r)
+}
+
+func stockAt(loc Location, r Resource) int {
+	total := 0
+	for _, obj := range loc.Objects {
+		n := obj.Stocks[r]
+		if n == Unlimited {
+			return Unlimited
+		}
+		if n > 0 {
+			total += n
+		}
+	}
+	return total
+}
+
+func cloneLocation(loc Location) Location {
+	loc.Exits = append([]string(nil), loc.Exits...)
+	loc.Objects = cloneObjects(loc.Objects)
+	if loc.withheld != nil {
+		withheld := make(map[Resource]bool, len(loc.withheld))
+		for r := range loc.withheld {
+			withheld[r] = true
+		}
+		loc.withheld = withheld
+	}
+	return loc
+}
+
+func cloneObjects(objects []WorldObject) []WorldObject {
+	if objects == nil {
+		return nil
+	}
+	out := make([]WorldObject, len(objects))
+	for i, obj := range objects {
+		stocks := make(map[Resource]int, len(obj.Stocks))
+		for r, n := range obj.Stocks {
+			stocks[r] = n
+		}
+		out[i] = WorldObject{Name: obj.Name, Stocks: stocks}
+	}
+	return out
+}
@@ -0,0 +1,222 @@
+package infrastructure_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/consciousness"
+	"github.com/marczahn/person/v2/internal/infrastructure"
+	"github.com/marczahn/person/v2/internal/motivation"
+	"github.com/marczahn/person/v2/internal/sense"
+)
+
+func flat() *infrastructure.WorldModel {
+	warm := 24.0
+	m, err := infrastructure.NewWorldModel("bedroom",
+		infrastructure.Location{
+			Name:  "bedroom",
+			Quiet: true,
+			Exits: []string{"hallway", "kitchen"},
+		},
+		infrastructure.Location{
+			Name:  "hallway",
+			Exits: []string{"bedroom", "kitchen"},
+		},
+		infrastructure.Location{
+			Name:    "kitchen",
+			Ambient: infrastructure.AmbientPatch{TemperatureC: &warm},
+			Exits:   []string{"hallway"},
+			Objects: []infrastructure.WorldObject{
+				{Name: "fridge", Stocks: map[infrastructure.Resource]int{infrastructure.ResourceFood: 2}},
+				{Name: "tap", Stocks: map[infrastructure.Resource]int{infrastructure.ResourceWater: infrastructure.Unlimited}},
+			},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func TestNewWorldModel_RejectsBrokenMaps(t *testing.T) {
+	cases := map[string][]infrastructure.Location{
+		"unnamed":      {{Name: " "}},
+		"duplicate":    {{Name: "a"}, {Name: "a"}},
+		"unknown exit": {{Name: "a", Exits: []string{"b"}}},
+	}
+	for name, locations := range cases {
+		if _, err := infrastructure.NewWorldModel("a", locations...); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := infrastructure.NewWorldModel("attic", infrastructure.Location{Name: "a"}); err == nil {
+		t.Error("unknown start: expected an error")
+	}
+}
+
+func TestWorldModel_DerivesGateFromLocation(t *testing.T) {
+	m := flat()
+	bedroom := m.State()
+	if bedroom.Food || bedroom.Water || !bedroom.QuietSpace || !bedroom.CanMove {
+		t.Fatalf("bedroom derived as %+v", bedroom)
+	}
+	if !bedroom.AllowedActions()["move"] || !bedroom.Constraints().CanMove || bedroom.AllowedActions()["eat"] {
+		t.Errorf("gate and constraints should follow the derived state: %+v", bedroom.AllowedActions())
+	}
+	offered := bedroom.OfferedActions(motivation.DriveEnergy)
+	if !containsAction(offered, motivation.ActionMove) {
+		t.Errorf("an energy goal without food here should offer move, got %v", offered)
+	}
+
+	if err := m.MoveTo("kitchen"); err != nil {
+		t.Fatal(err)
+	}
+	kitchen := m.State()
+	if !kitchen.Food || !kitchen.Water || kitchen.QuietSpace {
+		t.Errorf("kitchen derived as %+v", kitchen)
+	}
+	if containsAction(kitchen.OfferedActions(motivation.DriveEnergy), motivation.ActionMove) {
+		t.Error("move should not be offered for energy where there is food")
+	}
+}
+
+func TestWorldModel_ActionsConsumeStocks(t *testing.T) {
+	m := flat()
+	if _, ok := m.Perform("eat", motivation.DriveEnergy); ok {
+		t.Fatal("eating in the bedroom should not touch the world")
+	}
+	_ = m.MoveTo("kitchen")
+	for i := 0; i < 2; i++ {
+		effect, ok := m.Perform("eat", motivation.DriveEnergy)
+		if !ok || effect.Consumed != infrastructure.ResourceFood || effect.Object != "fridge" {
+			t.Fatalf("eat %d: effect=%+v ok=%v", i, effect, ok)
+		}
+	}
+	if m.Stock(infrastructure.ResourceFood) != 0 || m.State().Food {
+		t.Errorf("the fridge should be empty, stock=%d", m.Stock(infrastructure.ResourceFood))
+	}
+	for i := 0; i < 5; i++ {
+		m.Perform("hydrate", motivation.DriveEnergy)
+	}
+	if m.Stock(infrastructure.ResourceWater) != infrastructure.Unlimited || !m.State().Water {
+		t.Error("the tap should never run dry")
+	}
+}
+
+func TestWorldModel_MoveGoesWhereTheGoalIsServed(t *testing.T) {
+	m := flat()
+	// The hallway is listed first but only the kitchen has food.
+	effect, ok := m.Perform("move", motivation.DriveEnergy)
+	if !ok || effect.From != "bedroom" || effect.To != "kitchen" {
+		t.Fatalf("move = %+v, %v", effect, ok)
+	}
+	if m.Here().Name != "kitchen" {
+		t.Errorf("Here = %q", m.Here().Name)
+	}
+	// Nothing beyond the kitchen serves stimulation better: the first exit wins.
+	if to, _ := m.Destination(motivation.DriveStimulation); to != "hallway" {
+		t.Errorf("tie should go to the first exit, got %q", to)
+	}
+}
+
+func TestWorldModel_SyncCarriesKeywordChangesIntoLocation(t *testing.T) {
+	m := flat()
+	before := m.State()
+	after := before
+	after.Food, after.PeopleNearby = true, true
+	m.Sync(before, after)
+	if m.Stock(infrastructure.ResourceFood) != 1 || !m.Here().PeopleNearby {
+		t.Fatalf("food available should add one unit here, stock=%d", m.Stock(infrastructure.ResourceFood))
+	}
+	if !strings.Contains(m.Line(), "1 food") {
+		t.Errorf("Line = %q", m.Line())
+	}
+
+	_ = m.MoveTo("kitchen")
+	before = m.State()
+	after = before
+	after.Food = false
+	m.Sync(before, after)
+	if m.State().Food {
+		t.Error("no food should withhold the fridge")
+	}
+	if m.Stock(infrastructure.ResourceWater) != infrastructure.Unlimited {
+		t.Error("unrelated stocks must not change")
+	}
+}
+
+func TestSimulationLoop_TimedShortageKeepsStocks(t *testing.T) {
+	adapter := infrastructure.NewInputAdapter(sense.NewParser(), nil)
+	loop := newEnvironmentLoop(adapter)
+	places := flat()
+	_ = places.MoveTo("kitchen")
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState(), Places: places}
+
+	adapter.Enqueue("~no food for 10 minutes")
+	loop.Tick(&state, 1)
+	if state.World.Food || places.Stock(infrastructure.ResourceFood) != 0 {
+		t.Fatalf("food should be unavailable during the shortage, stock=%d", places.Stock(infrastructure.ResourceFood))
+	}
+	if strings.Contains(places.Line(), "food") {
+		t.Errorf("the mind should not be told about withheld food: %q", places.Line())
+	}
+
+	loop.Tick(&state, 600)
+	if !state.World.Food || places.Stock(infrastructure.ResourceFood) != 2 {
+		t.Fatalf("the fridge should hold its 2 units again after the shortage, stock=%d", places.Stock(infrastructure.ResourceFood))
+	}
+}
+
+func TestWorldModel_Line(t *testing.T) {
+	m := flat()
+	_ = m.MoveTo("kitchen")
+	want := "You are in the kitchen. There is a fridge (2 food) and a tap (water). From here you can go to the hallway."
+	if got := m.Line(); got != want {
+		t.Errorf("Line = %q\nwant   %q", got, want)
+	}
+}
+
+func TestSimulationLoop_ActionsChangeTheWorldModel(t *testing.T) {
+	mind := &fakeMind{raw: "[STATE: arousal=0.0, valence=0.0] [ACTION: move] I go and look for food."}
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:      &fakeInputDrainer{},
+		Biology:    &fakeBioEngine{},
+		Motivation: &fakeMotivationComputer{},
+		Mind:       mind,
+		Activities: consciousness.ActivityCatalog{},
+	})
+	state := infrastructure.SimulationState{Bio: *biology.NewDefaultState(), Places: flat()}
+
+	first := loop.Tick(&state, 1)
+	if !first.ActionOutcome.Executed || len(first.WorldEffects) != 1 || first.WorldEffects[0].To != "kitchen" {
+		t.Fatalf("move outcome=%+v effects=%+v", first.ActionOutcome, first.WorldEffects)
+	}
+	if !strings.Contains(first.Prompt.Surroundings, "You are in the bedroom.") {
+		t.Errorf("prompt should place the person, got %q", first.Prompt.Surroundings)
+	}
+	if state.Environment.TemperatureC != 24 || !state.World.Food {
+		t.Errorf("arriving in the kitchen should bring its warmth and food: env=%+v world=%+v", *state.Environment, *state.World)
+	}
+
+	mind.raw = "[STATE: arousal=0.0, valence=0.0] [ACTION: eat] Finally."
+	for i := 0; i < 3; i++ {
+		loop.Tick(&state, 1)
+	}
+	if state.Places.Stock(infrastructure.ResourceFood) != 0 || state.World.Food {
+		t.Fatalf("two meals should empty the fridge, stock=%d", state.Places.Stock(infrastructure.ResourceFood))
+	}
+	last := loop.Tick(&state, 1)
+	if last.ActionOutcome.Executed || last.ActionOutcome.Reason != consciousness.OutcomeBlocked {
+		t.Errorf("eating from an empty fridge should be blocked, got %+v", last.ActionOutcome)
+	}
+}
+
+func containsAction(actions []motivation.Action, want motivation.Action) bool {
+	for _, a := range actions {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package infrastructure_test
+
+import (
+	"testing"
+
+	"github.com/marczahn/person/v2/internal/infrastructure"
+)
+
+func TestWorldState_DerivesAllowedActionsAndConstraintsTogether(t *testing.T) {
+	world := infrastructure.DefaultWorldState()
+	world.PeopleNearby = false
+	world.Water = false
+
+	allowed := world.AllowedActions()
+	constraints := world.Constraints()
+	if allowed["reach_out"] || constraints.HasPeopleNearby {
+		t.Fatalf("expected reach_out gated consistently: allowed=%v constraints=%+v", allowed["reach_out"], constraints)
+	}
+	for _, a := range world.OfferedActions("energy") {
+		if a == "hydrate" {
+			t.Fatal("hydrate must not be offered without water")
+		}
+	}
+}
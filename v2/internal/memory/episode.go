@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// EpisodeKind names what made a tick worth remembering.
+type EpisodeKind string
+
+const (
+	KindThreshold EpisodeKind = "threshold"
+	KindAction    EpisodeKind = "action"
+	KindArousal   EpisodeKind = "arousal"
+	KindSpeech    EpisodeKind = "speech"
+)
+
+// DefaultArousalThreshold is the reported |arousal| at which a tick is salient on its own.
+const DefaultArousalThreshold = 0.6
+
+// Episode is one remembered moment: what happened, the body and drives at the
+// time, and how it felt. Seq numbers episodes across sessions.
+type Episode struct {
+	ID         string                     `json:"id"`
+	Seq        int                        `json:"seq"`
+	Kind       EpisodeKind                `json:"kind"`
+	Tick       int                        `json:"tick"`
+	NowSeconds int64                      `json:"now_seconds"`
+	Content    string                     `json:"content"`
+	Bio        biology.State              `json:"bio"`
+	Drives     motivation.MotivationState `json:"drives"`
+	Valence    float64                    `json:"valence"`
+	Arousal    float64                    `json:"arousal"`
+}
+
+// Moment is everything about one tick that episode formation looks at.
+type Moment struct {
+	Tick           int
+	NowSeconds     int64
+	Bio            biology.State
+	Drives         motivation.MotivationState
+	Thresholds     []biology.ThresholdEvent
+	ExecutedAction string
+	Arousal        float64
+	Valence        float64
+	Narrative      string
+	OperatorSpeech []string
+}
+
+// Former decides which ticks become episodes. It remembers which thresholds
+// were already active so that only onsets, not every tick above a threshold, are stored.
+// The zero value uses DefaultArousalThreshold.
+type Former struct {
+	ArousalThreshold float64
+	active           map[string]biology.Severity
+	// seq is the last sequence number handed out; Episodic resumes it from
+	// the stored episodes so IDs stay unique across sessions.
+	seq int
+}
+
+// Form returns the episodes a moment produces, in a fixed order:
+// threshold onsets, operator speech, executed action, strong arousal.
+func (f *Former) Form(m Moment) []Episode {
+	var out []Episode
+	add := func(kind EpisodeKind, content string) {
+		f.seq++
+		out = append(out, Episode{
+			ID:         fmt.Sprintf("ep-%d-%d", m.NowSeconds, f.seq),
+			Seq:        f.seq,
+			Kind:       kind,
+			Tick:       m.Tick,
+			NowSeconds: m.NowSeconds,
+			Content:    content,
+			Bio:        m.Bio,
+			Drives:     m.Drives,
+			Valence:    m.Valence,
+			Arousal:    m.Arousal,
+		})
+	}
+
+	current := make(map[string]biology.Severity, len(m.Thresholds))
+	for _, ev := range m.Thresholds {
+		current[ev.Variable] = max(current[ev.Variable], ev.Severity)
+	}
+	for _, ev := range m.Thresholds {
+		prev, was := f.active[ev.Variable]
+		if current[ev.Variable] != ev.Severity || (was && prev >= ev.Severity) {
+			continue
+		}
+		add(KindThreshold, ev.Description)
+	}
+	f.active = current
+
+	for _, said := range m.OperatorSpeech {
+		if said = strings.TrimSpace(said); said != "" {
+			add(KindSpeech, fmt.Sprintf("Someone said: %q", said))
+		}
+	}
+
+	if m.ExecutedAction != "" {
+		content := "I chose to " + strings.ReplaceAll(m.ExecutedAction, "_", " ") + "."
+		if s := firstSentence(m.Narrative); s != "" {
+			content += " " + s
+		}
+		add(KindAction, content)
+	}
+
+	threshold := f.ArousalThreshold
+	if threshold <= 0 {
+		threshold = DefaultArousalThreshold
+	}
+	if math.Abs(m.Arousal) >= threshold {
+		content := firstSentence(m.Narrative)
+		if content == "" {
+			content = "A strong surge of feeling."
+		}
+		add(KindArousal, content)
+	}
+	return out
+}
+
+// Salience is how strongly an episode was felt; capacity pruning forgets the
+// least salient first.
+func Salience(ep Episode) float64 {
+	return math.Abs(ep.Arousal) + math.Abs(ep.Valence)
+}
+
+// EpisodeLine renders an episode for the prompt, relative to now, without numbers.
+func EpisodeLine(ep Episode, nowSeconds int64) string {
+	line := agePhrase(nowSeconds-ep.NowSeconds) + ": " + ep.Content
+	switch {
+	case ep.Valence <= -0.3:
+		line += " It felt bad."
+	case ep.Valence >= 0.3:
+		line += " It felt good."
+	}
+	return line
+}
+
+func agePhrase(age int64) string {
+	switch {
+	case age < 120:
+		return "Moments ago"
+	case age < 3600:
+		return "Earlier"
+	case age < 86400:
+		return "Hours ago"
+	default:
+		return "Long ago"
+	}
+}
+
+func firstSentence(text string) string {
+	text = strings.TrimSpace(text)
+	if i := strings.IndexAny(text, ".!?\n"); i >= 0 {
+		return strings.TrimSpace(text[:i+1])
+	}
+	return text
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Episodic is the person's long-term episodic memory: it forms episodes from
+// salient moments, persists them, and recalls the ones relevant to the present.
+// It keeps at most RecallConfig.Capacity episodes, forgetting the least
+// salient, and compacts the store once it holds twice that many.
+type Episodic struct {
+	store    Store
+	episodes []Episode
+	former   Former
+	recall   RecallConfig
+	// stored counts the episodes in the store, forgotten ones included.
+	stored int
+}
+
+// NewEpisodic loads the stored episodes, keeping the most salient up to
+// capacity. Threshold onset tracking starts fresh, so a threshold still active
+// after a restart is remembered once more.
+func NewEpisodic(store Store, former Former, recall RecallConfig) (*Episodic, error) {
+	if store == nil {
+		panic(fmt.Errorf("episodic memory requires Store"))
+	}
+	episodes, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading episodes: %w", err)
+	}
+	for _, ep := range episodes {
+		former.seq = max(former.seq, ep.Seq)
+	}
+	e := &Episodic{
+		store:    store,
+		episodes: episodes,
+		former:   former,
+		recall:   recall,
+		stored:   len(episodes),
+	}
+	if err := e.forget(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Record forms episodes from the moment and persists them. Episodes that fail
+// to persist are still kept in memory for this session.
+func (e *Episodic) Record(m Moment) ([]Episode, error) {
+	formed := e.former.Form(m)
+	var firstErr error
+	for _, ep := range formed {
+		e.episodes = append(e.episodes, ep)
+		if err := e.store.Append(ep); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("persisting episode %s: %w", ep.ID, err)
+		} else if err == nil {
+			e.stored++
+		}
+	}
+	if err := e.forget(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return formed, firstErr
+}
+
+// forget drops the least salient episodes beyond capacity, older ones first
+// on a tie, and rewrites the store once it holds twice the capacity.
+func (e *Episodic) forget() error {
+	capacity := e.recall.Capacity
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	if excess := len(e.episodes) - capacity; excess > 0 {
+		order := make([]int, len(e.episodes))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return Salience(e.episodes[order[a]]) < Salience(e.episodes[order[b]])
+		})
+		drop := make(map[int]bool, excess)
+		for _, i := range order[:excess] {
+			drop[i] = true
+		}
+		kept := make([]Episode, 0, capacity)
+		for i, ep := range e.episodes {
+			if !drop[i] {
+				kept = append(kept, ep)
+			}
+		}
+		e.episodes = kept
+	}
+	if e.stored <= 2*capacity {
+		return nil
+	}
+	if err := e.store.Replace(e.episodes); err != nil {
+		return fmt.Errorf("compacting episodes: %w", err)
+	}
+	e.stored = len(e.episodes)
+	return nil
+}
+
+// Recall returns the episodes most relevant to the cue, best first.
+func (e *Episodic) Recall(cue Cue) []Episode {
+	return Recall(e.episodes, cue, e.recall)
+}
+
+// Episodes returns every remembered episode, oldest first.
+func (e *Episodic) Episodes() []Episode {
+	return append([]Episode(nil), e.episodes...)
+}
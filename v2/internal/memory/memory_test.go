@@ -0,0 +1,195 @@
+package memory_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/memory"
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+func TestFormer_StoresThresholdOnsetsOnlyOnce(t *testing.T) {
+	var former memory.Former
+	stress := biology.ThresholdEvent{Variable: "stress", Severity: biology.Mild, Description: "stress is building"}
+
+	first := former.Form(memory.Moment{Tick: 1, Thresholds: []biology.ThresholdEvent{stress}})
+	second := former.Form(memory.Moment{Tick: 2, Thresholds: []biology.ThresholdEvent{stress}})
+	if len(first) != 1 || first[0].Kind != memory.KindThreshold {
+		t.Fatalf("expected threshold onset episode, got %+v", first)
+	}
+	if len(second) != 0 {
+		t.Fatalf("expected sustained threshold not to form again, got %+v", second)
+	}
+
+	worse := biology.ThresholdEvent{Variable: "stress", Severity: biology.Warning, Description: "stress is overwhelming"}
+	escalated := former.Form(memory.Moment{Tick: 3, Thresholds: []biology.ThresholdEvent{worse}})
+	if len(escalated) != 1 || escalated[0].Content != "stress is overwhelming" {
+		t.Fatalf("expected escalation to form a new episode, got %+v", escalated)
+	}
+
+	former.Form(memory.Moment{Tick: 4})
+	again := former.Form(memory.Moment{Tick: 5, Thresholds: []biology.ThresholdEvent{stress}})
+	if len(again) != 1 {
+		t.Fatalf("expected re-onset after recovery to form again, got %+v", again)
+	}
+}
+
+func TestFormer_SalientMomentsFormEpisodesWithSnapshot(t *testing.T) {
+	var former memory.Former
+	bio := biology.NewDefaultState()
+	drives := motivation.MotivationState{ActiveGoalDrive: motivation.DriveEnergy, EnergyUrgency: 0.8}
+
+	got := former.Form(memory.Moment{
+		Tick:           7,
+		NowSeconds:     100,
+		Bio:            *bio,
+		Drives:         drives,
+		ExecutedAction: "eat",
+		Arousal:        0.8,
+		Valence:        0.4,
+		Narrative:      "Finally food. I feel better.",
+		OperatorSpeech: []string{"here you go"},
+	})
+
+	kinds := []memory.EpisodeKind{memory.KindSpeech, memory.KindAction, memory.KindArousal}
+	if len(got) != len(kinds) {
+		t.Fatalf("expected %d episodes, got %+v", len(kinds), got)
+	}
+	for i, kind := range kinds {
+		if got[i].Kind != kind {
+			t.Fatalf("episode %d: expected kind %q, got %q", i, kind, got[i].Kind)
+		}
+		if got[i].Bio.Energy != bio.Energy || got[i].Drives.ActiveGoalDrive != motivation.DriveEnergy || got[i].Valence != 0.4 {
+			t.Fatalf("episode %d must carry bio snapshot, drives and valence, got %+v", i, got[i])
+		}
+	}
+	if got[1].Content != "I chose to eat. Finally food." {
+		t.Fatalf("unexpected action content %q", got[1].Content)
+	}
+
+	if quiet := former.Form(memory.Moment{Tick: 8, Arousal: 0.2, Narrative: "Nothing."}); len(quiet) != 0 {
+		t.Fatalf("expected calm tick without events to form nothing, got %+v", quiet)
+	}
+}
+
+func TestRecall_ScoresDriveRelevanceStateSimilarityAndRecency(t *testing.T) {
+	hungry := *biology.NewDefaultState()
+	hungry.Hunger = 0.9
+	lonely := *biology.NewDefaultState()
+	lonely.SocialDeficit = 0.9
+
+	food := memory.Episode{ID: "food", NowSeconds: 0, Bio: hungry,
+		Drives: motivation.MotivationState{ActiveGoalDrive: motivation.DriveEnergy}}
+	people := memory.Episode{ID: "people", NowSeconds: 0, Bio: lonely,
+		Drives: motivation.MotivationState{ActiveGoalDrive: motivation.DriveSocialConnection}}
+
+	cue := memory.Cue{
+		Bio:        hungry,
+		Drives:     motivation.MotivationState{EnergyUrgency: 0.9, SocialUrgency: 0.1},
+		NowSeconds: 60,
+	}
+	got := memory.Recall([]memory.Episode{people, food}, cue, memory.RecallConfig{Max: 1})
+	if len(got) != 1 || got[0].ID != "food" {
+		t.Fatalf("expected drive- and state-congruent episode first, got %+v", got)
+	}
+
+	recent := food
+	recent.ID = "recent"
+	recent.NowSeconds = 50
+	if got := memory.Recall([]memory.Episode{food, recent}, cue, memory.RecallConfig{}); got[0].ID != "recent" {
+		t.Fatalf("expected recency to break otherwise equal episodes, got %+v", got)
+	}
+}
+
+func TestEpisodic_PersistsAcrossReload(t *testing.T) {
+	store := memory.NewFileStore(filepath.Join(t.TempDir(), "episodes.jsonl"))
+	episodic, err := memory.NewEpisodic(store, memory.Former{}, memory.RecallConfig{})
+	if err != nil {
+		t.Fatalf("new episodic: %v", err)
+	}
+	if len(episodic.Episodes()) != 0 {
+		t.Fatalf("expected empty memory from a missing file")
+	}
+
+	formed, err := episodic.Record(memory.Moment{
+		Tick:           1,
+		NowSeconds:     10,
+		ExecutedAction: "journal",
+		Drives:         motivation.MotivationState{ActiveGoalDrive: motivation.DriveIdentityCoherence},
+	})
+	if err != nil || len(formed) != 1 {
+		t.Fatalf("expected one persisted episode, got %+v err=%v", formed, err)
+	}
+
+	reloaded, err := memory.NewEpisodic(store, memory.Former{}, memory.RecallConfig{})
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	episodes := reloaded.Episodes()
+	if len(episodes) != 1 || episodes[0].Content != "I chose to journal." || episodes[0].Drives.ActiveGoalDrive != motivation.DriveIdentityCoherence {
+		t.Fatalf("expected episode to survive reload, got %+v", episodes)
+	}
+}
+
+func TestEpisodic_ForgetsTheLeastSalientBeyondCapacity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "episodes.jsonl")
+	store := memory.NewFileStore(path)
+	episodic, err := memory.NewEpisodic(store, memory.Former{}, memory.RecallConfig{Capacity: 3})
+	if err != nil {
+		t.Fatalf("new episodic: %v", err)
+	}
+	record := func(now int64, valence float64) {
+		t.Helper()
+		if _, err := episodic.Record(memory.Moment{NowSeconds: now, ExecutedAction: "rest", Valence: valence}); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	record(1, 0.5)
+	for now := int64(2); now <= 7; now++ {
+		record(now, 0)
+	}
+
+	episodes := episodic.Episodes()
+	if len(episodes) != 3 || episodes[0].NowSeconds != 1 || episodes[1].NowSeconds != 6 || episodes[2].NowSeconds != 7 {
+		t.Fatalf("expected the felt episode and the newest two, got %+v", episodes)
+	}
+	stored, err := store.Load()
+	if err != nil || len(stored) > 6 {
+		t.Fatalf("the store should be compacted at twice the capacity, holds %d (%v)", len(stored), err)
+	}
+
+	reloaded, err := memory.NewEpisodic(store, memory.Former{}, memory.RecallConfig{Capacity: 3})
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if got := reloaded.Episodes(); len(got) != 3 {
+		t.Fatalf("reload should keep the capacity, got %d episodes", len(got))
+	}
+}
+
+func TestEpisodic_IDsStayUniqueAcrossSessions(t *testing.T) {
+	store := &memory.InMemoryStore{}
+	ids := map[string]bool{}
+	for session := 0; session < 2; session++ {
+		episodic, err := memory.NewEpisodic(store, memory.Former{}, memory.RecallConfig{})
+		if err != nil {
+			t.Fatalf("new episodic: %v", err)
+		}
+		// Each session's clock and tick counter start over.
+		formed, _ := episodic.Record(memory.Moment{Tick: 1, NowSeconds: 0, ExecutedAction: "rest", OperatorSpeech: []string{"hi"}})
+		for _, ep := range formed {
+			if ids[ep.ID] {
+				t.Fatalf("session %d reused episode ID %s", session, ep.ID)
+			}
+			ids[ep.ID] = true
+		}
+	}
+}
+
+func TestEpisodeLine_RendersAgeAndFeelingWithoutNumbers(t *testing.T) {
+	ep := memory.Episode{NowSeconds: 0, Content: "Someone shouted at me.", Valence: -0.6}
+	if got := memory.EpisodeLine(ep, 600); got != "Earlier: Someone shouted at me. It felt bad." {
+		t.Fatalf("unexpected episode line %q", got)
+	}
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"math"
+	"sort"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+const (
+	DefaultRecallMax       = 3
+	DefaultRecencyHalfLife = 3600.0 // seconds of sim time
+	DefaultCapacity        = 500
+
+	driveWeight   = 0.40
+	stateWeight   = 0.35
+	recencyWeight = 0.25
+)
+
+// Cue is the present moment that recall is matched against.
+type Cue struct {
+	Bio        biology.State
+	Drives     motivation.MotivationState
+	NowSeconds int64
+}
+
+// RecallConfig bounds recall. Zero fields use the defaults above.
+type RecallConfig struct {
+	Max             int
+	RecencyHalfLife float64
+	// Capacity is how many episodes memory keeps to recall from.
+	Capacity int
+}
+
+// Recall returns the most relevant episodes for the cue, best first.
+// Ties keep the newer episode first, so output is deterministic.
+func Recall(episodes []Episode, cue Cue, cfg RecallConfig) []Episode {
+	limit := cfg.Max
+	if limit <= 0 {
+		limit = DefaultRecallMax
+	}
+	type scored struct {
+		episode Episode
+		score   float64
+	}
+	all := make([]scored, 0, len(episodes))
+	for _, ep := range episodes {
+		all = append(all, scored{episode: ep, score: Score(ep, cue, cfg)})
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].score != all[j].score {
+			return all[i].score > all[j].score
+		}
+		return all[i].episode.NowSeconds > all[j].episode.NowSeconds
+	})
+
+	out := make([]Episode, 0, min(limit, len(all)))
+	for i := 0; i < len(all) && i < limit; i++ {
+		out = append(out, all[i].episode)
+	}
+	return out
+}
+
+// Score combines drive relevance, state similarity and recency into 0-1.
+//   - drive relevance: how urgent the drive the episode served is right now
+//   - state similarity: state-dependent, mood-congruent recall (cf. v1 somaticSimilarity)
+//   - recency: exponential fade with the configured half-life
+func Score(ep Episode, cue Cue, cfg RecallConfig) float64 {
+	halfLife := cfg.RecencyHalfLife
+	if halfLife <= 0 {
+		halfLife = DefaultRecencyHalfLife
+	}
+	return driveWeight*driveRelevance(ep, cue) +
+		stateWeight*stateSimilarity(ep.Bio, cue.Bio) +
+		recencyWeight*recency(cue.NowSeconds-ep.NowSeconds, halfLife)
+}
+
+func driveRelevance(ep Episode, cue Cue) float64 {
+	if ep.Drives.ActiveGoalDrive == "" {
+		return 0
+	}
+	return math.Max(0, math.Min(1, cue.Drives.Urgency(ep.Drives.ActiveGoalDrive)))
+}
+
+// stateSimilarity returns 0-1 where 1 means identical bodies.
+func stateSimilarity(a, b biology.State) float64 {
+	dims := []struct {
+		aVal, bVal, maxRange float64
+	}{
+		{a.Energy, b.Energy, 1.0},
+		{a.Stress, b.Stress, 1.0},
+		{a.Mood, b.Mood, 1.0},
+		{a.PhysicalTension, b.PhysicalTension, 1.0},
+		{a.Hunger, b.Hunger, 1.0},
+		{a.SocialDeficit, b.SocialDeficit, 1.0},
+		{a.BodyTemp, b.BodyTemp, 8.0},
+	}
+
+	var sumSqDiff float64
+	for _, d := range dims {
+		normalized := (d.aVal - d.bVal) / d.maxRange
+		sumSqDiff += normalized * normalized
+	}
+	distance := math.Sqrt(sumSqDiff / float64(len(dims)))
+	return 1.0 - math.Min(distance, 1.0)
+}
+
+func recency(age int64, halfLife float64) float64 {
+	if age <= 0 {
+		return 1
+	}
+	return math.Exp(-math.Ln2 * float64(age) / halfLife)
+}
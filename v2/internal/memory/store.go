@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists episodes so memory survives restarts.
+type Store interface {
+	// Append stores one episode.
+	Append(ep Episode) error
+	// Load returns every stored episode in the order appended.
+	Load() ([]Episode, error)
+	// Replace stores exactly episodes, dropping everything else.
+	Replace(episodes []Episode) error
+}
+
+// InMemoryStore is a non-persistent Store for tests and throwaway sessions.
+type InMemoryStore struct {
+	mu       sync.Mutex
+	episodes []Episode
+}
+
+func (s *InMemoryStore) Append(ep Episode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.episodes = append(s.episodes, ep)
+	return nil
+}
+
+func (s *InMemoryStore) Load() ([]Episode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Episode(nil), s.episodes...), nil
+}
+
+func (s *InMemoryStore) Replace(episodes []Episode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.episodes = append([]Episode(nil), episodes...)
+	return nil
+}
+
+// FileStore appends episodes to a JSON-lines file.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	if path == "" {
+		panic(fmt.Errorf("file store requires a path"))
+	}
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Append(ep Episode) error {
+	line, err := json.Marshal(ep)
+	if err != nil {
+		return fmt.Errorf("encoding episode %s: %w", ep.ID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening memory file: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing episode %s: %w", ep.ID, err)
+	}
+	return f.Close()
+}
+
+// Replace rewrites the file through a temporary file renamed over it, so a
+// crash mid-write leaves the previous episodes intact.
+func (s *FileStore) Replace(episodes []Episode) error {
+	var data []byte
+	for _, ep := range episodes {
+		line, err := json.Marshal(ep)
+		if err != nil {
+			return fmt.Errorf("encoding episode %s: %w", ep.ID, err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("rewriting memory file: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("rewriting memory file: %w", err)
+	}
+	return nil
+}
+
+// Load returns no episodes and no error when the file does not exist yet.
+func (s *FileStore) Load() ([]Episode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening memory file: %w", err)
+	}
+	defer f.Close()
+
+	var out []Episode
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var ep Episode
+		if err := json.Unmarshal(scanner.Bytes(), &ep); err != nil {
+			return nil, fmt.Errorf("decoding memory file line %d: %w", line, err)
+		}
+		out = append(out, ep)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading memory file: %w", err)
+	}
+	return out, nil
+}
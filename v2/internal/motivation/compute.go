@@ -4,61 +4,10 @@ import (
 	"github.com/marczahn/person/v2/internal/biology"
 )
 
-var drivePriority = []Drive{
-	DriveSafety,
-	DriveEnergy,
-	DriveSocialConnection,
-	DriveIdentityCoherence,
-	DriveStimulation,
-}
-
-// Compute calculates drive urgencies and active goal deterministically.
+// Compute calculates drive urgencies and active goal deterministically
+// using the drives registered in DefaultRegistry.
 func Compute(bio biology.State, personality Personality, chronic ChronicState) MotivationState {
-	b := normalizedBio(bio)
-	p := clampedPersonality(personality)
-	c := clampedChronic(chronic)
-
-	energyBase := clamp01(0.65*(1-b.Energy) + 0.35*b.Hunger + 0.15*c.FatiguePressure)
-	socialBase := clamp01(b.SocialDeficit + 0.25*c.IsolationLoad)
-	stimBase := clamp01(0.60*(1-b.CognitiveCapacity) + 0.40*(1-b.Mood))
-	safetyBase := clamp01(0.50*b.Stress + 0.25*b.PhysicalTension + 0.25*tempDeviation(b.BodyTemp) + 0.20*c.ThreatLoad)
-	identityBase := clamp01(0.55*(1-b.Mood) + 0.45*(1-b.CognitiveCapacity) + 0.20*c.IdentityStrain)
-
-	energy := clamp01(energyBase * energyMultiplier(p))
-	social := clamp01(socialBase * socialMultiplier(p))
-	stim := clamp01(stimBase * stimulationMultiplier(p))
-	safety := clamp01(safetyBase * safetyMultiplier(p))
-	identity := clamp01(identityBase * identityMultiplier(p))
-
-	state := MotivationState{
-		EnergyUrgency:      energy,
-		SocialUrgency:      social,
-		StimulationUrgency: stim,
-		SafetyUrgency:      safety,
-		IdentityUrgency:    identity,
-	}
-	state.ActiveGoalDrive, state.ActiveGoalUrgency = selectActiveGoal(state)
-	return state
-}
-
-func selectActiveGoal(m MotivationState) (Drive, float64) {
-	driveValues := map[Drive]float64{
-		DriveEnergy:            m.EnergyUrgency,
-		DriveSocialConnection:  m.SocialUrgency,
-		DriveStimulation:       m.StimulationUrgency,
-		DriveSafety:            m.SafetyUrgency,
-		DriveIdentityCoherence: m.IdentityUrgency,
-	}
-
-	best := drivePriority[0]
-	bestValue := driveValues[best]
-	for _, d := range drivePriority[1:] {
-		if driveValues[d] > bestValue {
-			best = d
-			bestValue = driveValues[d]
-		}
-	}
-	return best, bestValue
+	return DefaultRegistry().Compute(bio, personality, chronic)
 }
 
 func normalizedBio(b biology.State) biology.State {
@@ -0,0 +1,123 @@
+package motivation
+
+import "sort"
+
+// DefaultConflictThreshold is the urgency both drives must reach before their
+// disagreement counts as a conflict.
+const DefaultConflictThreshold = 0.5
+
+type ConflictKind string
+
+const (
+	// ConflictApproachAvoidance: one drive's preferred action moves toward the
+	// world while the other's withdraws or stays put.
+	ConflictApproachAvoidance ConflictKind = "approach_avoidance"
+	// ConflictExclusiveActions: both drives are high and share no candidate action.
+	ConflictExclusiveActions ConflictKind = "exclusive_actions"
+)
+
+// Orientation classifies whether an action moves toward or away from the world.
+type Orientation string
+
+const (
+	OrientationApproach Orientation = "approach"
+	OrientationWithdraw Orientation = "withdraw"
+	OrientationNeutral  Orientation = "neutral"
+)
+
+// DriveConflict records two simultaneously high drives pulling toward incompatible actions.
+type DriveConflict struct {
+	Kind            ConflictKind
+	First           Drive
+	Second          Drive
+	FirstUrgency    float64
+	SecondUrgency   float64
+	FirstPreferred  Action
+	SecondPreferred Action
+	// Intensity is the weaker of the two urgencies: a conflict is only as strong
+	// as its less insistent side.
+	Intensity float64
+}
+
+// ActionOrientation returns the approach/withdraw orientation of a built-in action.
+// Unknown actions are neutral and never produce approach-avoidance conflicts.
+func ActionOrientation(a Action) Orientation {
+	switch a {
+	case ActionEat, ActionHydrate, ActionReachOut, ActionMicroTask, ActionSeekWarm, ActionSeekCool, ActionMove:
+		return OrientationApproach
+	case ActionRest, ActionBreathe, ActionScanArea, ActionJournal:
+		return OrientationWithdraw
+	default:
+		return OrientationNeutral
+	}
+}
+
+// DetectConflicts finds conflicts between drives in the default registry.
+func DetectConflicts(state MotivationState, c ActionConstraints, threshold float64) []DriveConflict {
+	return DefaultRegistry().DetectConflicts(state, c, threshold)
+}
+
+// DetectConflicts returns one conflict per pair of drives at or above threshold
+// whose candidate actions disagree. Output is ordered by descending intensity,
+// then by drive priority, and is deterministic.
+func (r *Registry) DetectConflicts(state MotivationState, c ActionConstraints, threshold float64) []DriveConflict {
+	type highDrive struct {
+		def     DriveDefinition
+		urgency float64
+		actions []Action
+	}
+
+	var high []highDrive
+	for _, def := range r.ByPriority() {
+		u := clamp01(state.Urgency(def.Drive))
+		if u < threshold {
+			continue
+		}
+		high = append(high, highDrive{def: def, urgency: u, actions: r.ActionCandidates(def.Drive, c)})
+	}
+
+	var out []DriveConflict
+	for i := 0; i < len(high); i++ {
+		for j := i + 1; j < len(high); j++ {
+			a, b := high[i], high[j]
+			if len(a.actions) == 0 || len(b.actions) == 0 {
+				continue
+			}
+			kind, ok := conflictKind(a.actions, b.actions)
+			if !ok {
+				continue
+			}
+			out = append(out, DriveConflict{
+				Kind:            kind,
+				First:           a.def.Drive,
+				Second:          b.def.Drive,
+				FirstUrgency:    a.urgency,
+				SecondUrgency:   b.urgency,
+				FirstPreferred:  a.actions[0],
+				SecondPreferred: b.actions[0],
+				Intensity:       min(a.urgency, b.urgency),
+			})
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Intensity > out[j].Intensity
+	})
+	return out
+}
+
+func conflictKind(first, second []Action) (ConflictKind, bool) {
+	fo := ActionOrientation(first[0])
+	so := ActionOrientation(second[0])
+	if fo != OrientationNeutral && so != OrientationNeutral && fo != so {
+		return ConflictApproachAvoidance, true
+	}
+	for _, a := range first {
+		for _, b := range second {
+			if a == b {
+				return "", false
+			}
+		}
+	}
+	return ConflictExclusiveActions, true
+}
@@ -0,0 +1,143 @@
+package motivation
+
+// BuiltinDrives returns the five core drive definitions in canonical order.
+func BuiltinDrives() []DriveDefinition {
+	return []DriveDefinition{
+		{
+			Drive:    DriveEnergy,
+			Priority: 1,
+			Inputs: []DriveInput{
+				{Signal: "energy", Weight: 0.65, Inverted: true},
+				{Signal: "hunger", Weight: 0.35},
+				{Signal: "fatigue_pressure", Weight: 0.15},
+			},
+			Multiplier: energyMultiplier,
+			Felt: [4]string{
+				"a faint pull toward rest and nourishment lingers in the background.",
+				"a noticeable fatigue-and-hunger pull is starting to build.",
+				"an insistent need for rest and nourishment is pressing into attention.",
+				"an urgent depletion is dominating attention and demanding recovery now.",
+			},
+			GoalPull:    "A pull toward food, water, and recovery keeps surfacing.",
+			ThoughtText: "Food and recovery keep intruding into thought.",
+			Candidates: func(c ActionConstraints) []Action {
+				actions := make([]Action, 0, 3)
+				if c.HasFood {
+					actions = append(actions, ActionEat)
+				}
+				if c.CanRest {
+					actions = append(actions, ActionRest)
+				}
+				if !c.HasFood && c.CanMove {
+					actions = append(actions, ActionMove)
+				}
+				return append(actions, ActionHydrate)
+			},
+		},
+		{
+			Drive:    DriveSocialConnection,
+			Priority: 2,
+			Inputs: []DriveInput{
+				{Signal: "social_deficit", Weight: 1.0},
+				{Signal: "isolation_load", Weight: 0.25},
+			},
+			Multiplier: socialMultiplier,
+			Felt: [4]string{
+				"a light sense of distance from others is present.",
+				"a growing wish for contact and response is becoming noticeable.",
+				"an insistent loneliness is pressing for connection.",
+				"an urgent need to reach someone is dominating focus.",
+			},
+			GoalPull:    "A pull toward contact and response keeps surfacing.",
+			ThoughtText: "The need for contact keeps returning to mind.",
+			Candidates: func(c ActionConstraints) []Action {
+				actions := make([]Action, 0, 2)
+				if c.HasPeopleNearby {
+					actions = append(actions, ActionReachOut)
+				} else if c.CanMove {
+					actions = append(actions, ActionMove)
+				}
+				return append(actions, ActionJournal)
+			},
+		},
+		{
+			Drive:    DriveStimulation,
+			Priority: 4,
+			Inputs: []DriveInput{
+				{Signal: "cognitive_capacity", Weight: 0.60, Inverted: true},
+				{Signal: "mood", Weight: 0.40, Inverted: true},
+			},
+			Multiplier: stimulationMultiplier,
+			Felt: [4]string{
+				"a mild restlessness for novelty hums in the background.",
+				"a noticeable urge for engagement and novelty is rising.",
+				"an insistent need for stimulation is pushing for action.",
+				"an urgent craving for meaningful engagement is taking over attention.",
+			},
+			GoalPull:    "A pull toward something engaging and novel keeps surfacing.",
+			ThoughtText: "A search for novelty keeps tugging at attention.",
+			Candidates: func(c ActionConstraints) []Action {
+				if c.CanExplore {
+					return []Action{ActionMicroTask, ActionScanArea}
+				}
+				if c.CanMove {
+					return []Action{ActionMicroTask, ActionMove}
+				}
+				return []Action{ActionMicroTask}
+			},
+		},
+		{
+			Drive:    DriveSafety,
+			Priority: 0,
+			Inputs: []DriveInput{
+				{Signal: "stress", Weight: 0.50},
+				{Signal: "physical_tension", Weight: 0.25},
+				{Signal: "body_temp", Weight: 0.25},
+				{Signal: "threat_load", Weight: 0.20},
+			},
+			Multiplier: safetyMultiplier,
+			Felt: [4]string{
+				"a faint vigilance remains in the background.",
+				"a noticeable need to check for safety is surfacing.",
+				"an insistent threat-sensitivity is narrowing attention.",
+				"an urgent need to secure safety is dominating attention.",
+			},
+			GoalPull:    "A pull toward checking safety and reducing threat keeps surfacing.",
+			ThoughtText: "Threat-checking keeps cycling through awareness.",
+			Candidates: func(c ActionConstraints) []Action {
+				actions := make([]Action, 0, 3)
+				actions = append(actions, ActionBreathe, ActionScanArea)
+				if c.HasQuietSpace {
+					actions = append(actions, ActionRest)
+				}
+				return actions
+			},
+		},
+		{
+			Drive:    DriveIdentityCoherence,
+			Priority: 3,
+			Inputs: []DriveInput{
+				{Signal: "mood", Weight: 0.55, Inverted: true},
+				{Signal: "cognitive_capacity", Weight: 0.45, Inverted: true},
+				{Signal: "identity_strain", Weight: 0.20},
+			},
+			Multiplier: identityMultiplier,
+			Felt: [4]string{
+				"a light pull to make sense of experience is present.",
+				"a noticeable tension about self-coherence is forming.",
+				"an insistent need to regain internal coherence is pressing.",
+				"an urgent need to stabilize meaning and identity is overwhelming focus.",
+			},
+			GoalPull:    "A pull toward making sense of experience keeps surfacing.",
+			ThoughtText: "A need to make sense of self keeps pressing forward.",
+			Candidates: func(c ActionConstraints) []Action {
+				actions := make([]Action, 0, 2)
+				actions = append(actions, ActionJournal)
+				if c.HasQuietSpace {
+					actions = append(actions, ActionBreathe)
+				}
+				return actions
+			},
+		},
+	}
+}
@@ -1,6 +1,8 @@
 package motivation_test
 
 import (
+	"math"
+	"reflect"
 	"testing"
 
 	"github.com/marczahn/person/v2/internal/biology"
@@ -40,7 +42,7 @@ func TestCompute_IsDeterministic(t *testing.T) {
 	first := motivation.Compute(b, p, c)
 	second := motivation.Compute(b, p, c)
 
-	if first != second {
+	if !reflect.DeepEqual(first, second) {
 		t.Fatalf("compute must be deterministic: first=%+v second=%+v", first, second)
 	}
 }
@@ -170,3 +172,144 @@ func TestActionCandidates_RespectConstraints(t *testing.T) {
 		}
 	}
 }
+
+func TestActionCandidates_MoveWhenTheNeedIsElsewhere(t *testing.T) {
+	has := func(actions []motivation.Action, want motivation.Action) bool {
+		for _, a := range actions {
+			if a == want {
+				return true
+			}
+		}
+		return false
+	}
+	c := motivation.ActionConstraints{CanMove: true, CanRest: true}
+	for _, goal := range []motivation.Drive{motivation.DriveEnergy, motivation.DriveSocialConnection, motivation.DriveStimulation} {
+		if !has(motivation.ActionCandidatesFor(goal, c), motivation.ActionMove) {
+			t.Errorf("%s: expected move when the need cannot be met here", goal)
+		}
+	}
+	c = motivation.ActionConstraints{CanMove: true, HasFood: true, HasPeopleNearby: true, CanExplore: true}
+	for _, goal := range []motivation.Drive{motivation.DriveEnergy, motivation.DriveSocialConnection, motivation.DriveStimulation} {
+		if has(motivation.ActionCandidatesFor(goal, c), motivation.ActionMove) {
+			t.Errorf("%s: move should not be offered when the need can be met here", goal)
+		}
+	}
+	if has(motivation.ActionCandidatesFor(motivation.DriveEnergy, motivation.ActionConstraints{}), motivation.ActionMove) {
+		t.Error("move requires somewhere to go")
+	}
+}
+
+func TestRegistry_BuiltinsMatchPackageCompute(t *testing.T) {
+	registry, err := motivation.NewRegistry(motivation.BuiltinDrives()...)
+	if err != nil {
+		t.Fatalf("builtin drives must register: %v", err)
+	}
+	b := baselineBio()
+	b.Energy = 0.3
+	b.Stress = 0.6
+
+	got := registry.Compute(b, baselinePersonality(), motivation.ChronicState{})
+	want := motivation.Compute(b, baselinePersonality(), motivation.ChronicState{})
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("registry compute must match package compute: got=%+v want=%+v", got, want)
+	}
+}
+
+func TestRegistry_CustomDriveComputesAndEmitsCandidates(t *testing.T) {
+	registry, err := motivation.NewRegistry(motivation.BuiltinDrives()...)
+	if err != nil {
+		t.Fatalf("builtin drives must register: %v", err)
+	}
+	autonomy := motivation.Drive("autonomy")
+	err = registry.Register(motivation.DriveDefinition{
+		Drive:    autonomy,
+		Priority: 5,
+		Inputs: []motivation.DriveInput{
+			{Signal: "physical_tension", Weight: 1.0},
+		},
+		Candidates: func(c motivation.ActionConstraints) []motivation.Action {
+			return []motivation.Action{"wander_off"}
+		},
+	})
+	if err != nil {
+		t.Fatalf("register custom drive failed: %v", err)
+	}
+
+	b := baselineBio()
+	b.PhysicalTension = 0.95
+	m := registry.Compute(b, baselinePersonality(), motivation.ChronicState{})
+
+	if math.Abs(m.Urgency(autonomy)-0.95) > 1e-9 {
+		t.Fatalf("expected custom drive urgency 0.95, got %f", m.Urgency(autonomy))
+	}
+	if m.ActiveGoalDrive != autonomy {
+		t.Fatalf("expected custom drive to become active goal, got %s", m.ActiveGoalDrive)
+	}
+	actions := registry.ActionCandidates(autonomy, motivation.ActionConstraints{})
+	if len(actions) != 1 || actions[0] != "wander_off" {
+		t.Fatalf("unexpected custom candidates: %v", actions)
+	}
+}
+
+func TestRegistry_RejectsDuplicateAndUnknownSignal(t *testing.T) {
+	registry, err := motivation.NewRegistry(motivation.BuiltinDrives()...)
+	if err != nil {
+		t.Fatalf("builtin drives must register: %v", err)
+	}
+	if err := registry.Register(motivation.DriveDefinition{Drive: motivation.DriveSafety}); err == nil {
+		t.Fatal("expected duplicate drive registration to fail")
+	}
+	err = registry.Register(motivation.DriveDefinition{
+		Drive:  "play",
+		Inputs: []motivation.DriveInput{{Signal: "boredom", Weight: 1}},
+	})
+	if err == nil {
+		t.Fatal("expected unknown input signal to fail")
+	}
+}
+
+func TestDetectConflicts_SafetyVersusSocialIsApproachAvoidance(t *testing.T) {
+	state := motivation.MotivationState{
+		SafetyUrgency: 0.8,
+		SocialUrgency: 0.7,
+		EnergyUrgency: 0.1,
+	}
+	c := motivation.ActionConstraints{HasPeopleNearby: true}
+
+	conflicts := motivation.DetectConflicts(state, c, motivation.DefaultConflictThreshold)
+	if len(conflicts) != 1 {
+		t.Fatalf("expected exactly one conflict, got %+v", conflicts)
+	}
+	got := conflicts[0]
+	if got.Kind != motivation.ConflictApproachAvoidance {
+		t.Fatalf("expected approach-avoidance conflict, got %s", got.Kind)
+	}
+	if got.First != motivation.DriveSafety || got.Second != motivation.DriveSocialConnection {
+		t.Fatalf("expected safety/social ordered by priority, got %s/%s", got.First, got.Second)
+	}
+	if got.Intensity != 0.7 {
+		t.Fatalf("expected intensity to be the weaker urgency 0.7, got %f", got.Intensity)
+	}
+}
+
+func TestDetectConflicts_BelowThresholdOrSharedActionsDoNotConflict(t *testing.T) {
+	state := motivation.MotivationState{SafetyUrgency: 0.8, SocialUrgency: 0.3}
+	if got := motivation.DetectConflicts(state, motivation.ActionConstraints{HasPeopleNearby: true}, 0.5); len(got) != 0 {
+		t.Fatalf("expected no conflict when one drive is low, got %+v", got)
+	}
+
+	// Social without people falls back to journaling, which identity also proposes.
+	state = motivation.MotivationState{SocialUrgency: 0.8, IdentityUrgency: 0.8}
+	if got := motivation.DetectConflicts(state, motivation.ActionConstraints{}, 0.5); len(got) != 0 {
+		t.Fatalf("expected no conflict when drives share an action, got %+v", got)
+	}
+}
+
+func TestDetectConflicts_DisjointSameOrientationIsExclusive(t *testing.T) {
+	state := motivation.MotivationState{EnergyUrgency: 0.9, StimulationUrgency: 0.6}
+
+	conflicts := motivation.DetectConflicts(state, motivation.ActionConstraints{HasFood: true}, 0.5)
+	if len(conflicts) != 1 || conflicts[0].Kind != motivation.ConflictExclusiveActions {
+		t.Fatalf("expected one exclusive-actions conflict, got %+v", conflicts)
+	}
+}
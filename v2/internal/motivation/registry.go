@@ -0,0 +1,241 @@
+package motivation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/marczahn/person/v2/internal/biology"
+)
+
+// DriveInput is one weighted contribution to a drive's base pressure.
+// Signal names a bio field ("energy", "body_temp", ...) or a chronic load
+// ("threat_load", ...). Inverted uses 1-value so that depletion adds pressure.
+// body_temp contributes its deviation from baseline scaled to [0,1].
+type DriveInput struct {
+	Signal   string
+	Weight   float64
+	Inverted bool
+}
+
+// DriveDefinition declares everything the layers need to know about one drive.
+//
+// Priority breaks urgency ties (lower wins). Felt holds the felt-experience
+// texts for the four urgency levels (faint, noticeable, insistent, urgent).
+// Candidates emits action candidates for the drive under world constraints.
+type DriveDefinition struct {
+	Drive       Drive
+	Priority    int
+	Inputs      []DriveInput
+	Multiplier  func(Personality) float64
+	Felt        [4]string
+	GoalPull    string
+	ThoughtText string
+	Candidates  func(ActionConstraints) []Action
+}
+
+// Registry holds the drive definitions known to the simulation.
+// Registration order is the canonical display order; Priority is the tie-break order.
+type Registry struct {
+	mu          sync.RWMutex
+	definitions []DriveDefinition
+	index       map[Drive]int
+}
+
+// NewRegistry creates a registry from the given definitions.
+func NewRegistry(defs ...DriveDefinition) (*Registry, error) {
+	r := &Registry{index: make(map[Drive]int, len(defs))}
+	for _, def := range defs {
+		if err := r.Register(def); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+var (
+	defaultRegistryOnce sync.Once
+	defaultRegistry     *Registry
+)
+
+// DefaultRegistry returns the process-wide registry seeded with the built-in drives.
+// Additional drives registered here are picked up by every layer.
+func DefaultRegistry() *Registry {
+	defaultRegistryOnce.Do(func() {
+		r, err := NewRegistry(BuiltinDrives()...)
+		if err != nil {
+			panic(fmt.Errorf("built-in drive definitions are invalid: %w", err))
+		}
+		defaultRegistry = r
+	})
+	return defaultRegistry
+}
+
+// Register adds one drive definition. Drive names must be unique.
+func (r *Registry) Register(def DriveDefinition) error {
+	name := Drive(strings.TrimSpace(string(def.Drive)))
+	if name == "" {
+		return fmt.Errorf("drive name must not be empty")
+	}
+	def.Drive = name
+	for _, in := range def.Inputs {
+		if !knownSignal(in.Signal) {
+			return fmt.Errorf("drive %q uses unknown input signal %q", name, in.Signal)
+		}
+	}
+	def.Inputs = append([]DriveInput(nil), def.Inputs...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.index[name]; exists {
+		return fmt.Errorf("drive %q is already registered", name)
+	}
+	r.index[name] = len(r.definitions)
+	r.definitions = append(r.definitions, def)
+	return nil
+}
+
+// Lookup returns the definition for drive.
+func (r *Registry) Lookup(drive Drive) (DriveDefinition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i, ok := r.index[drive]
+	if !ok {
+		return DriveDefinition{}, false
+	}
+	return r.definitions[i], true
+}
+
+// Definitions returns all definitions in registration order.
+func (r *Registry) Definitions() []DriveDefinition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]DriveDefinition(nil), r.definitions...)
+}
+
+// Drives returns all drive names in registration order.
+func (r *Registry) Drives() []Drive {
+	defs := r.Definitions()
+	out := make([]Drive, 0, len(defs))
+	for _, def := range defs {
+		out = append(out, def.Drive)
+	}
+	return out
+}
+
+// ByPriority returns all definitions ordered by tie-break priority.
+func (r *Registry) ByPriority() []DriveDefinition {
+	defs := r.Definitions()
+	sort.SliceStable(defs, func(i, j int) bool {
+		return defs[i].Priority < defs[j].Priority
+	})
+	return defs
+}
+
+// Compute calculates drive urgencies for every registered drive and selects the active goal.
+func (r *Registry) Compute(bio biology.State, personality Personality, chronic ChronicState) MotivationState {
+	b := normalizedBio(bio)
+	p := clampedPersonality(personality)
+	c := clampedChronic(chronic)
+
+	var state MotivationState
+	for _, def := range r.Definitions() {
+		base := clamp01(def.base(b, c))
+		multiplier := 1.0
+		if def.Multiplier != nil {
+			multiplier = def.Multiplier(p)
+		}
+		state.SetUrgency(def.Drive, clamp01(base*multiplier))
+	}
+	state.ActiveGoalDrive, state.ActiveGoalUrgency = r.selectActiveGoal(state)
+	return state
+}
+
+// ActionCandidates emits deterministic candidates for goal under constraints.
+// Unknown drives fall back to breathing.
+func (r *Registry) ActionCandidates(goal Drive, c ActionConstraints) []Action {
+	def, ok := r.Lookup(goal)
+	if !ok || def.Candidates == nil {
+		return []Action{ActionBreathe}
+	}
+	return def.Candidates(c)
+}
+
+func (r *Registry) selectActiveGoal(m MotivationState) (Drive, float64) {
+	ordered := r.ByPriority()
+	if len(ordered) == 0 {
+		return "", 0
+	}
+	best := ordered[0].Drive
+	bestValue := m.Urgency(best)
+	for _, def := range ordered[1:] {
+		if v := m.Urgency(def.Drive); v > bestValue {
+			best = def.Drive
+			bestValue = v
+		}
+	}
+	return best, bestValue
+}
+
+func (d DriveDefinition) base(b biology.State, c ChronicState) float64 {
+	sum := 0.0
+	for _, in := range d.Inputs {
+		v := signalValue(in.Signal, b, c)
+		if in.Inverted {
+			v = 1 - v
+		}
+		sum += in.Weight * v
+	}
+	return sum
+}
+
+// FeltFor returns the felt-experience text for urgency, or a generic fallback.
+func (d DriveDefinition) FeltFor(level int) string {
+	if level < 0 || level >= len(d.Felt) || d.Felt[level] == "" {
+		return "a general pressure is present."
+	}
+	return d.Felt[level]
+}
+
+func knownSignal(signal string) bool {
+	switch signal {
+	case "energy", "stress", "cognitive_capacity", "mood", "physical_tension",
+		"hunger", "social_deficit", "body_temp",
+		"threat_load", "isolation_load", "identity_strain", "fatigue_pressure":
+		return true
+	default:
+		return false
+	}
+}
+
+func signalValue(signal string, b biology.State, c ChronicState) float64 {
+	switch signal {
+	case "energy":
+		return b.Energy
+	case "stress":
+		return b.Stress
+	case "cognitive_capacity":
+		return b.CognitiveCapacity
+	case "mood":
+		return b.Mood
+	case "physical_tension":
+		return b.PhysicalTension
+	case "hunger":
+		return b.Hunger
+	case "social_deficit":
+		return b.SocialDeficit
+	case "body_temp":
+		return tempDeviation(b.BodyTemp)
+	case "threat_load":
+		return c.ThreatLoad
+	case "isolation_load":
+		return c.IsolationLoad
+	case "identity_strain":
+		return c.IdentityStrain
+	case "fatigue_pressure":
+		return c.FatiguePressure
+	default:
+		return 0
+	}
+}
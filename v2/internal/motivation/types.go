@@ -23,6 +23,8 @@ const (
 	ActionSeekWarm  Action = "seek_warmth"
 	ActionSeekCool  Action = "seek_cooling"
 	ActionMicroTask Action = "micro_task"
+	// ActionMove goes to a neighbouring location; the world picks where.
+	ActionMove Action = "move"
 )
 
 // Personality contains the 7 motivation multipliers.
@@ -46,12 +48,47 @@ type ChronicState struct {
 	FatiguePressure float64
 }
 
+// Field returns a load by its signal name ("threat_load", "isolation_load", ...).
+func (c ChronicState) Field(name string) (float64, bool) {
+	if p := c.field(name); p != nil {
+		return *p, true
+	}
+	return 0, false
+}
+
+// SetField sets a load by its signal name, clamped to [0,1].
+// It reports false for an unknown name.
+func (c *ChronicState) SetField(name string, v float64) bool {
+	p := c.field(name)
+	if p == nil {
+		return false
+	}
+	*p = clamp01(v)
+	return true
+}
+
+func (c *ChronicState) field(name string) *float64 {
+	switch name {
+	case "threat_load":
+		return &c.ThreatLoad
+	case "isolation_load":
+		return &c.IsolationLoad
+	case "identity_strain":
+		return &c.IdentityStrain
+	case "fatigue_pressure":
+		return &c.FatiguePressure
+	}
+	return nil
+}
+
 type ActionConstraints struct {
 	HasFood         bool
 	HasPeopleNearby bool
 	CanRest         bool
 	CanExplore      bool
 	HasQuietSpace   bool
+	// CanMove is set when another location is reachable.
+	CanMove bool
 }
 
 type MotivationState struct {
@@ -61,6 +98,51 @@ type MotivationState struct {
 	SafetyUrgency      float64
 	IdentityUrgency    float64
 
+	// Extra holds urgencies of registered drives beyond the five core drives.
+	Extra map[Drive]float64
+
 	ActiveGoalDrive   Drive
 	ActiveGoalUrgency float64
 }
+
+// Urgency returns the urgency of drive, including registered non-core drives.
+func (m MotivationState) Urgency(drive Drive) float64 {
+	switch drive {
+	case DriveEnergy:
+		return m.EnergyUrgency
+	case DriveSocialConnection:
+		return m.SocialUrgency
+	case DriveStimulation:
+		return m.StimulationUrgency
+	case DriveSafety:
+		return m.SafetyUrgency
+	case DriveIdentityCoherence:
+		return m.IdentityUrgency
+	default:
+		return m.Extra[drive]
+	}
+}
+
+// SetUrgency sets the urgency of drive. Extra is copied on write so that
+// copies of a MotivationState never share mutations.
+func (m *MotivationState) SetUrgency(drive Drive, value float64) {
+	switch drive {
+	case DriveEnergy:
+		m.EnergyUrgency = value
+	case DriveSocialConnection:
+		m.SocialUrgency = value
+	case DriveStimulation:
+		m.StimulationUrgency = value
+	case DriveSafety:
+		m.SafetyUrgency = value
+	case DriveIdentityCoherence:
+		m.IdentityUrgency = value
+	default:
+		next := make(map[Drive]float64, len(m.Extra)+1)
+		for k, v := range m.Extra {
+			next[k] = v
+		}
+		next[drive] = value
+		m.Extra = next
+	}
+}
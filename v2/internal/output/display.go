@@ -19,15 +19,9 @@ func SignificantDriveChanges(previous, current motivation.MotivationState, thres
 	}
 
 	var out []DriveChange
-	for _, drive := range []motivation.Drive{
-		motivation.DriveEnergy,
-		motivation.DriveSocialConnection,
-		motivation.DriveStimulation,
-		motivation.DriveSafety,
-		motivation.DriveIdentityCoherence,
-	} {
-		prevValue := driveValue(previous, drive)
-		currValue := driveValue(current, drive)
+	for _, drive := range motivation.DefaultRegistry().Drives() {
+		prevValue := previous.Urgency(drive)
+		currValue := current.Urgency(drive)
 		if math.Abs(currValue-prevValue) >= threshold {
 			out = append(out, DriveChange{
 				Name:     drive,
@@ -50,20 +44,3 @@ func FormatDriveChangeLines(changes []DriveChange) []string {
 	}
 	return out
 }
-
-func driveValue(state motivation.MotivationState, drive motivation.Drive) float64 {
-	switch drive {
-	case motivation.DriveEnergy:
-		return state.EnergyUrgency
-	case motivation.DriveSocialConnection:
-		return state.SocialUrgency
-	case motivation.DriveStimulation:
-		return state.StimulationUrgency
-	case motivation.DriveSafety:
-		return state.SafetyUrgency
-	case motivation.DriveIdentityCoherence:
-		return state.IdentityUrgency
-	default:
-		return 0
-	}
-}
@@ -8,6 +8,8 @@ const (
 	SourceBIO    SourceTag = "BIO"
 	SourceDRIVES SourceTag = "DRIVES"
 	SourceMIND   SourceTag = "MIND"
+	SourceACTION SourceTag = "ACTION"
+	SourceSPEECH SourceTag = "SPEECH"
 )
 
 func FormatTaggedLine(tag SourceTag, message string) string {
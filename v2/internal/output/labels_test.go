@@ -12,6 +12,8 @@ func TestFormatTaggedLine_UsesExpectedTagPrefix(t *testing.T) {
 		{name: "bio", tag: SourceBIO, msg: "stress threshold crossed", want: "[BIO] stress threshold crossed"},
 		{name: "drives", tag: SourceDRIVES, msg: "energy +0.20", want: "[DRIVES] energy +0.20"},
 		{name: "mind", tag: SourceMIND, msg: "I should rest for a moment.", want: "[MIND] I should rest for a moment."},
+		{name: "action", tag: SourceACTION, msg: "rest (30s of 90s)", want: "[ACTION] rest (30s of 90s)"},
+		{name: "speech", tag: SourceSPEECH, msg: `"Is anyone there?"`, want: `[SPEECH] "Is anyone there?"`},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,200 @@
+package scenario
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/infrastructure"
+)
+
+// When a bio assertion is checked.
+const (
+	// CheckEnd checks the state after the last tick (no suffix, or "at end").
+	CheckEnd = "end"
+	// CheckBy passes if the condition held at any tick up to T ("by t=600").
+	CheckBy = "by"
+	// CheckAt checks the first tick at or after T ("at t=600").
+	CheckAt = "at"
+	// CheckAlways requires the condition on every tick ("always").
+	CheckAlways = "always"
+)
+
+// Assertion is one parsed pass/fail check. Bio assertions set Condition;
+// action assertions set Action with bounds on how often it executed.
+type Assertion struct {
+	Raw string
+
+	Condition infrastructure.BioCondition
+	Check     string
+	// T is the time bound in simulated seconds for CheckBy, CheckAt and
+	// action assertions with "by t=".
+	T float64
+
+	Action string
+	Min    int
+	// Max is the most executions allowed; -1 is unbounded.
+	Max int
+}
+
+// Sample is the observable outcome of one tick.
+type Sample struct {
+	T   float64
+	Bio biology.State
+	// Executed are the actions that executed this tick, queued ones included.
+	Executed []string
+}
+
+// Result is the verdict on one assertion.
+type Result struct {
+	Assertion string
+	Passed    bool
+	Detail    string
+}
+
+var (
+	bioAssertion    = regexp.MustCompile(`^(.+?)(?:\s+(by|at)\s+t\s*=\s*([0-9]*\.?[0-9]+)s?|\s+(always)|\s+at\s+end)?$`)
+	actionAssertion = regexp.MustCompile(`^([a-z_]+)\s+(?:executed\s+(at least|at most|exactly)\s+(once|twice|[0-9]+\s+times?)|(never)\s+executed)(?:\s+by\s+t\s*=\s*([0-9]*\.?[0-9]+)s?)?$`)
+)
+
+// ParseAssertion parses one assertion:
+//
+//	<field> <op> <value> [by t=<s> | at t=<s> | always | at end]
+//	<action> executed at least|at most|exactly once|twice|<n> times [by t=<s>]
+//	<action> never executed [by t=<s>]
+func ParseAssertion(raw string) (Assertion, error) {
+	text := strings.Join(strings.Fields(strings.ToLower(raw)), " ")
+	if m := actionAssertion.FindStringSubmatch(text); m != nil {
+		a := Assertion{Raw: raw, Action: m[1], Max: -1}
+		if m[4] != "" {
+			a.Max = 0
+		} else {
+			n, err := count(m[3])
+			if err != nil {
+				return Assertion{}, fmt.Errorf("assertion %q: %w", raw, err)
+			}
+			switch m[2] {
+			case "at least":
+				a.Min = n
+			case "at most":
+				a.Max = n
+			case "exactly":
+				a.Min, a.Max = n, n
+			}
+		}
+		if m[5] != "" {
+			a.T, _ = strconv.ParseFloat(m[5], 64)
+		}
+		return a, nil
+	}
+
+	m := bioAssertion.FindStringSubmatch(text)
+	if m == nil {
+		return Assertion{}, fmt.Errorf("assertion %q is not understood", raw)
+	}
+	c, err := ParseCondition(m[1])
+	if err != nil {
+		return Assertion{}, fmt.Errorf("assertion %q: %w", raw, err)
+	}
+	a := Assertion{Raw: raw, Condition: c, Check: CheckEnd}
+	switch {
+	case m[2] != "":
+		a.Check = m[2]
+		a.T, _ = strconv.ParseFloat(m[3], 64)
+	case m[4] != "":
+		a.Check = CheckAlways
+	}
+	return a, nil
+}
+
+func count(word string) (int, error) {
+	switch word {
+	case "once":
+		return 1, nil
+	case "twice":
+		return 2, nil
+	}
+	return strconv.Atoi(strings.Fields(word)[0])
+}
+
+// Evaluate checks the assertion against a run's samples, oldest first.
+func (a Assertion) Evaluate(samples []Sample) Result {
+	passed, detail := a.evaluate(samples)
+	return Result{Assertion: a.Raw, Passed: passed, Detail: detail}
+}
+
+func (a Assertion) evaluate(samples []Sample) (bool, string) {
+	if len(samples) == 0 {
+		return false, "no ticks ran"
+	}
+	if a.Action != "" {
+		return a.evaluateAction(samples)
+	}
+
+	field := a.Condition.Field
+	value := func(s Sample) float64 {
+		v, _ := s.Bio.Field(field)
+		return v
+	}
+	switch a.Check {
+	case CheckBy:
+		var last *Sample
+		for i := range samples {
+			s := samples[i]
+			if s.T > a.T {
+				break
+			}
+			if a.Condition.Holds(s.Bio) {
+				return true, fmt.Sprintf("%s=%.3f at t=%g", field, value(s), s.T)
+			}
+			last = &samples[i]
+		}
+		if last == nil {
+			return false, fmt.Sprintf("no tick ran by t=%g", a.T)
+		}
+		return false, fmt.Sprintf("never held by t=%g; %s=%.3f at t=%g", a.T, field, value(*last), last.T)
+	case CheckAt:
+		for _, s := range samples {
+			if s.T >= a.T {
+				return a.Condition.Holds(s.Bio), fmt.Sprintf("%s=%.3f at t=%g", field, value(s), s.T)
+			}
+		}
+		return false, fmt.Sprintf("run ended at t=%g before t=%g", samples[len(samples)-1].T, a.T)
+	case CheckAlways:
+		for _, s := range samples {
+			if !a.Condition.Holds(s.Bio) {
+				return false, fmt.Sprintf("%s=%.3f at t=%g", field, value(s), s.T)
+			}
+		}
+		return true, fmt.Sprintf("held for %d ticks", len(samples))
+	default:
+		s := samples[len(samples)-1]
+		return a.Condition.Holds(s.Bio), fmt.Sprintf("%s=%.3f at end (t=%g)", field, value(s), s.T)
+	}
+}
+
+func (a Assertion) evaluateAction(samples []Sample) (bool, string) {
+	n := 0
+	first := -1.0
+	for _, s := range samples {
+		if a.T > 0 && s.T > a.T {
+			break
+		}
+		for _, action := range s.Executed {
+			if action == a.Action {
+				if n == 0 {
+					first = s.T
+				}
+				n++
+			}
+		}
+	}
+	passed := n >= a.Min && (a.Max < 0 || n <= a.Max)
+	detail := fmt.Sprintf("executed %d times", n)
+	if n > 0 {
+		detail += fmt.Sprintf(", first at t=%g", first)
+	}
+	return passed, detail
+}
@@ -0,0 +1,367 @@
+// Package scenario loads reproducible experiments from YAML and runs them headlessly.
+package scenario
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/infrastructure"
+	"github.com/marczahn/person/v2/internal/motivation"
+)
+
+// Defaults for fields a scenario file leaves out.
+const (
+	DefaultDT       = 10.0
+	DefaultMaxTicks = 10000
+)
+
+// File is a scenario file: the starting person, the environments it may
+// switch between, a timed script of inputs and the assertions that decide
+// whether the run passes.
+type File struct {
+	Name string `yaml:"name"`
+	Seed int64  `yaml:"seed"`
+	// DT is simulated seconds per tick; MaxTicks bounds runs whose script never ends.
+	DT       float64 `yaml:"dt"`
+	MaxTicks int     `yaml:"max_ticks"`
+	// Bio overrides the default starting state by field name ("hunger": 0.6).
+	Bio         map[string]float64 `yaml:"bio"`
+	Personality Personality        `yaml:"personality"`
+	Chronic     Chronic            `yaml:"chronic"`
+	// World places the person among locations, objects and stocks. Without it
+	// the world is the boolean default toggled by keywords.
+	World *World `yaml:"world"`
+	// Scenarios are named environment descriptors the script can activate.
+	Scenarios map[string][]string `yaml:"scenarios"`
+	Script    []Step              `yaml:"script"`
+	End       End                 `yaml:"end"`
+	Mind      Mind                `yaml:"mind"`
+	// Assertions are checked against the run ("hunger < 0.5 by t=600").
+	Assertions []string `yaml:"assertions"`
+}
+
+// Personality mirrors motivation.Personality with snake_case keys.
+type Personality struct {
+	StressSensitivity    float64 `yaml:"stress_sensitivity"`
+	EnergyResilience     float64 `yaml:"energy_resilience"`
+	Curiosity            float64 `yaml:"curiosity"`
+	SelfObservation      float64 `yaml:"self_observation"`
+	FrustrationTolerance float64 `yaml:"frustration_tolerance"`
+	RiskAversion         float64 `yaml:"risk_aversion"`
+	SocialFactor         float64 `yaml:"social_factor"`
+}
+
+// Chronic mirrors motivation.ChronicState with snake_case keys.
+type Chronic struct {
+	ThreatLoad      float64 `yaml:"threat_load"`
+	IsolationLoad   float64 `yaml:"isolation_load"`
+	IdentityStrain  float64 `yaml:"identity_strain"`
+	FatiguePressure float64 `yaml:"fatigue_pressure"`
+}
+
+// World is a map of locations; see infrastructure.WorldModel.
+type World struct {
+	Start     string     `yaml:"start"`
+	Locations []Location `yaml:"locations"`
+}
+
+// Location is one place. Nil ambient levels leave the current level on arrival.
+type Location struct {
+	Name         string   `yaml:"name"`
+	TemperatureC *float64 `yaml:"temperature"`
+	Noise        *float64 `yaml:"noise"`
+	Light        *float64 `yaml:"light"`
+	Crowding     *float64 `yaml:"crowding"`
+	Quiet        bool     `yaml:"quiet"`
+	People       bool     `yaml:"people"`
+	Explorable   bool     `yaml:"explorable"`
+	Exits        []string `yaml:"exits"`
+	Objects      []Object `yaml:"objects"`
+}
+
+// Object holds resource stocks ("food", "water"); -1 never runs out.
+type Object struct {
+	Name   string         `yaml:"name"`
+	Stocks map[string]int `yaml:"stocks"`
+}
+
+// Step is one timed script step; see infrastructure.ScriptStep.
+type Step struct {
+	At         float64  `yaml:"at"`
+	Activate   string   `yaml:"activate"`
+	Deactivate bool     `yaml:"deactivate"`
+	Inject     []string `yaml:"inject"`
+	// Perturb lists perturbations ("ramp body_temp -2 over 300") starting at
+	// the step's time; see infrastructure.ParsePerturbation.
+	Perturb  []string `yaml:"perturb"`
+	Every    float64  `yaml:"every"`
+	Times    int      `yaml:"times"`
+	Branches []Branch `yaml:"branches"`
+}
+
+// Branch is one weighted alternative of a step.
+type Branch struct {
+	Label  string  `yaml:"label"`
+	Weight float64 `yaml:"weight"`
+	Steps  []Step  `yaml:"steps"`
+}
+
+// End stops the run after a duration or once any condition ("body_temp < 35") holds.
+type End struct {
+	After float64  `yaml:"after"`
+	When  []string `yaml:"when"`
+}
+
+// Mind modes.
+const (
+	// MindDeterministic takes the first action the world offers, or none.
+	MindDeterministic = "deterministic"
+	// MindReplay returns the recorded replies in order, repeating the last one.
+	MindReplay = "replay"
+)
+
+// Mind selects the stand-in for the language model. Empty Mode is deterministic.
+type Mind struct {
+	Mode   string   `yaml:"mode"`
+	Replay []string `yaml:"replay"`
+}
+
+// Load reads and parses a scenario file.
+func Load(path string) (File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, fmt.Errorf("read scenario: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes a scenario file, fills defaults and validates it.
+// Unknown keys are rejected so typos do not silently change an experiment.
+func Parse(data []byte) (File, error) {
+	var f File
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil {
+		return File{}, fmt.Errorf("decode scenario: %w", err)
+	}
+	if f.DT == 0 {
+		f.DT = DefaultDT
+	}
+	if f.MaxTicks == 0 {
+		f.MaxTicks = DefaultMaxTicks
+	}
+	if f.Mind.Mode == "" {
+		f.Mind.Mode = MindDeterministic
+	}
+	if err := f.Validate(); err != nil {
+		return File{}, err
+	}
+	return f, nil
+}
+
+// Validate checks everything a run needs before it starts.
+func (f File) Validate() error {
+	if f.DT <= 0 {
+		return fmt.Errorf("scenario %q: dt must be positive", f.Name)
+	}
+	if f.MaxTicks < 0 {
+		return fmt.Errorf("scenario %q: max_ticks must not be negative", f.Name)
+	}
+	for name := range f.Bio {
+		if _, ok := (biology.State{}).Field(name); !ok {
+			return fmt.Errorf("scenario %q: unknown bio field %q", f.Name, name)
+		}
+	}
+	switch f.Mind.Mode {
+	case MindDeterministic, "":
+	case MindReplay:
+		if len(f.Mind.Replay) == 0 {
+			return fmt.Errorf("scenario %q: replay mind requires at least one reply", f.Name)
+		}
+	default:
+		return fmt.Errorf("scenario %q: unknown mind mode %q", f.Name, f.Mind.Mode)
+	}
+	for _, a := range f.Assertions {
+		if _, err := ParseAssertion(a); err != nil {
+			return fmt.Errorf("scenario %q: %w", f.Name, err)
+		}
+	}
+	if _, err := f.WorldModel(); err != nil {
+		return err
+	}
+	script, err := f.ScriptDefinition()
+	if err != nil {
+		return err
+	}
+	if err := script.Validate(); err != nil {
+		return err
+	}
+	for _, name := range activated(script.Steps) {
+		if _, ok := f.Scenarios[name]; !ok {
+			return fmt.Errorf("scenario %q: script activates unknown scenario %q", f.Name, name)
+		}
+	}
+	return nil
+}
+
+// BioState returns the starting biology: defaults with the file's overrides.
+func (f File) BioState() biology.State {
+	s := *biology.NewDefaultState()
+	for name, v := range f.Bio {
+		s.SetField(name, v)
+	}
+	return s
+}
+
+// MotivationPersonality converts the file's personality.
+func (f File) MotivationPersonality() motivation.Personality {
+	return motivation.Personality(f.Personality)
+}
+
+// MotivationChronic converts the file's chronic load.
+func (f File) MotivationChronic() motivation.ChronicState {
+	return motivation.ChronicState(f.Chronic)
+}
+
+// WorldModel builds a fresh world model, or nil when the file has no world.
+func (f File) WorldModel() (*infrastructure.WorldModel, error) {
+	if f.World == nil {
+		return nil, nil
+	}
+	locations := make([]infrastructure.Location, 0, len(f.World.Locations))
+	for _, l := range f.World.Locations {
+		loc := infrastructure.Location{
+			Name: l.Name,
+			Ambient: infrastructure.AmbientPatch{
+				TemperatureC: l.TemperatureC,
+				Noise:        l.Noise,
+				Light:        l.Light,
+				Crowding:     l.Crowding,
+			},
+			Quiet:        l.Quiet,
+			PeopleNearby: l.People,
+			Explorable:   l.Explorable,
+			Exits:        l.Exits,
+		}
+		for _, o := range l.Objects {
+			obj := infrastructure.WorldObject{Name: o.Name, Stocks: make(map[infrastructure.Resource]int, len(o.Stocks))}
+			for r, n := range o.Stocks {
+				switch infrastructure.Resource(r) {
+				case infrastructure.ResourceFood, infrastructure.ResourceWater:
+				default:
+					return nil, fmt.Errorf("scenario %q: object %q: unknown resource %q", f.Name, o.Name, r)
+				}
+				if n < infrastructure.Unlimited {
+					return nil, fmt.Errorf("scenario %q: object %q: stock of %s must be -1 or more", f.Name, o.Name, r)
+				}
+				obj.Stocks[infrastructure.Resource(r)] = n
+			}
+			loc.Objects = append(loc.Objects, obj)
+		}
+		locations = append(locations, loc)
+	}
+	m, err := infrastructure.NewWorldModel(f.World.Start, locations...)
+	if err != nil {
+		return nil, fmt.Errorf("scenario %q: world: %w", f.Name, err)
+	}
+	return m, nil
+}
+
+// ScriptDefinition converts the script and end conditions.
+func (f File) ScriptDefinition() (infrastructure.ScenarioScript, error) {
+	steps, err := convertSteps(f.Script)
+	if err != nil {
+		return infrastructure.ScenarioScript{}, fmt.Errorf("scenario %q: script: %w", f.Name, err)
+	}
+	script := infrastructure.ScenarioScript{
+		Name:  f.Name,
+		Steps: steps,
+		End:   infrastructure.ScriptEnd{After: f.End.After},
+	}
+	for _, raw := range f.End.When {
+		c, err := ParseCondition(raw)
+		if err != nil {
+			return infrastructure.ScenarioScript{}, fmt.Errorf("scenario %q: end: %w", f.Name, err)
+		}
+		script.End.When = append(script.End.When, c)
+	}
+	return script, nil
+}
+
+func convertSteps(steps []Step) ([]infrastructure.ScriptStep, error) {
+	if len(steps) == 0 {
+		return nil, nil
+	}
+	out := make([]infrastructure.ScriptStep, 0, len(steps))
+	for _, s := range steps {
+		step := infrastructure.ScriptStep{
+			At:         s.At,
+			Activate:   s.Activate,
+			Deactivate: s.Deactivate,
+			Inject:     s.Inject,
+			Every:      s.Every,
+			Times:      s.Times,
+		}
+		for _, spec := range s.Perturb {
+			p, err := infrastructure.ParsePerturbation(spec)
+			if err != nil {
+				return nil, err
+			}
+			if p.At != 0 {
+				return nil, fmt.Errorf("perturbation %q: a script step sets the time with its own at", spec)
+			}
+			step.Perturb = append(step.Perturb, p)
+		}
+		for _, b := range s.Branches {
+			branchSteps, err := convertSteps(b.Steps)
+			if err != nil {
+				return nil, err
+			}
+			step.Branches = append(step.Branches, infrastructure.ScriptBranch{
+				Label:  b.Label,
+				Weight: b.Weight,
+				Steps:  branchSteps,
+			})
+		}
+		out = append(out, step)
+	}
+	return out, nil
+}
+
+func activated(steps []infrastructure.ScriptStep) []string {
+	var names []string
+	for _, s := range steps {
+		if s.Activate != "" {
+			names = append(names, s.Activate)
+		}
+		for _, b := range s.Branches {
+			names = append(names, activated(b.Steps)...)
+		}
+	}
+	return names
+}
+
+var conditionPattern = regexp.MustCompile(`^([a-z_]+)\s*(<=|>=|<|>)\s*(-?[0-9]*\.?[0-9]+)$`)
+
+// ParseCondition parses "<field> <op> <value>", e.g. "body_temp < 35".
+func ParseCondition(raw string) (infrastructure.BioCondition, error) {
+	m := conditionPattern.FindStringSubmatch(strings.TrimSpace(raw))
+	if m == nil {
+		return infrastructure.BioCondition{}, fmt.Errorf("condition %q: want \"<field> <op> <value>\"", raw)
+	}
+	if _, ok := (biology.State{}).Field(m[1]); !ok {
+		return infrastructure.BioCondition{}, fmt.Errorf("condition %q: unknown field %q", raw, m[1])
+	}
+	v, err := strconv.ParseFloat(m[3], 64)
+	if err != nil {
+		return infrastructure.BioCondition{}, fmt.Errorf("condition %q: %w", raw, err)
+	}
+	return infrastructure.BioCondition{Field: m[1], Op: m[2], Value: v}, nil
+}
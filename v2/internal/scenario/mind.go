@@ -0,0 +1,67 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/marczahn/person/v2/internal/infrastructure"
+)
+
+// DeterministicMind stands in for the language model: it reports a neutral
+// state and takes the first action the world offers for the active goal.
+// Contract: the same request always yields the same reply.
+type DeterministicMind struct{}
+
+func (DeterministicMind) Respond(ctx context.Context, in infrastructure.MindRequest) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	action := "none"
+	if len(in.Offered) > 0 {
+		action = string(in.Offered[0])
+	}
+	return fmt.Sprintf("[STATE: arousal=0.0, valence=0.0] [ACTION: %s] I take stock and carry on.", action), nil
+}
+
+// ReplayMind returns recorded replies in order and repeats the last one once
+// the recording is exhausted.
+type ReplayMind struct {
+	replies []string
+	next    int
+}
+
+// NewReplayMind creates a replay mind. It requires at least one reply.
+func NewReplayMind(replies []string) *ReplayMind {
+	if len(replies) == 0 {
+		panic(fmt.Errorf("replay mind requires at least one reply"))
+	}
+	return &ReplayMind{replies: append([]string(nil), replies...)}
+}
+
+func (m *ReplayMind) Respond(ctx context.Context, _ infrastructure.MindRequest) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	reply := m.replies[min(m.next, len(m.replies)-1)]
+	m.next++
+	return reply, nil
+}
+
+// LoadReplay reads recorded mind replies from a YAML list of strings.
+func LoadReplay(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read replay: %w", err)
+	}
+	var replies []string
+	if err := yaml.Unmarshal(data, &replies); err != nil {
+		return nil, fmt.Errorf("decode replay: %w", err)
+	}
+	if len(replies) == 0 {
+		return nil, fmt.Errorf("replay %s holds no replies", path)
+	}
+	return replies, nil
+}
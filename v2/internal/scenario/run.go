@@ -0,0 +1,146 @@
+package scenario
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/infrastructure"
+	"github.com/marczahn/person/v2/internal/motivation"
+	"github.com/marczahn/person/v2/internal/sense"
+)
+
+// Report is the outcome of a headless run.
+type Report struct {
+	Name      string
+	Ticks     int
+	Clock     float64
+	EndReason string
+	Results   []Result
+	// Samples are the per-tick observations the assertions were checked against.
+	Samples []Sample
+	Events  []infrastructure.ScriptEvent
+	// Perturbations are the changes the script forced, attributed and in order.
+	Perturbations []infrastructure.AttributedDelta
+}
+
+// Passed reports whether every assertion passed.
+func (r Report) Passed() bool {
+	for _, res := range r.Results {
+		if !res.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the failing assertions.
+func (r Report) Failed() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if !res.Passed {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Run executes f headlessly with the file's stand-in mind.
+// Contract: the same file yields the same report.
+func Run(f File) (Report, error) {
+	var mind infrastructure.MindResponder = DeterministicMind{}
+	if f.Mind.Mode == MindReplay {
+		mind = NewReplayMind(f.Mind.Replay)
+	}
+	return RunWith(f, mind)
+}
+
+// RunWith executes f headlessly against mind. The mind is consulted
+// synchronously on every tick.
+func RunWith(f File, mind infrastructure.MindResponder) (Report, error) {
+	if err := f.Validate(); err != nil {
+		return Report{}, err
+	}
+	if mind == nil {
+		panic(fmt.Errorf("scenario run requires MindResponder"))
+	}
+	script, err := f.ScriptDefinition()
+	if err != nil {
+		return Report{}, err
+	}
+	assertions := make([]Assertion, 0, len(f.Assertions))
+	for _, raw := range f.Assertions {
+		a, err := ParseAssertion(raw)
+		if err != nil {
+			return Report{}, err
+		}
+		assertions = append(assertions, a)
+	}
+
+	var runner *infrastructure.ScriptRunner
+	adapter := infrastructure.NewInputAdapter(sense.NewParser(), func() int64 { return int64(runner.Clock()) })
+	injector := infrastructure.NewScenarioInjector(adapter)
+	names := make([]string, 0, len(f.Scenarios))
+	for name := range f.Scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := injector.Register(name, f.Scenarios[name]); err != nil {
+			return Report{}, fmt.Errorf("scenario %q: %w", f.Name, err)
+		}
+	}
+	perturber := infrastructure.NewPerturber()
+	adapter.HandlePerturbations(perturber)
+	runner, err = infrastructure.NewScriptRunner(script, injector, adapter, perturber, f.Seed)
+	if err != nil {
+		return Report{}, err
+	}
+
+	loop := infrastructure.NewSimulationLoop(infrastructure.SimulationLoopDeps{
+		Input:         injector,
+		Biology:       biology.NewEngineWithSeed(biology.DefaultConfig(), f.Seed),
+		Motivation:    motivation.DefaultRegistry(),
+		Mind:          mind,
+		Perturbations: perturber,
+	})
+	defer loop.Close()
+	places, err := f.WorldModel()
+	if err != nil {
+		return Report{}, err
+	}
+	state := infrastructure.SimulationState{
+		Bio:         f.BioState(),
+		Personality: f.MotivationPersonality(),
+		Chronic:     f.MotivationChronic(),
+		Places:      places,
+	}
+
+	report := Report{Name: f.Name}
+	report.EndReason = runner.Run(loop, &state, f.DT, f.MaxTicks, func(tick infrastructure.ScriptTick) {
+		report.Ticks++
+		report.Samples = append(report.Samples, Sample{
+			T:        runner.Clock(),
+			Bio:      state.Bio,
+			Executed: executed(tick.Result),
+		})
+	})
+	report.Clock = runner.Clock()
+	report.Events = runner.History()
+	report.Perturbations = perturber.History()
+	for _, a := range assertions {
+		report.Results = append(report.Results, a.Evaluate(report.Samples))
+	}
+	return report, nil
+}
+
+func executed(r infrastructure.TickResult) []string {
+	var actions []string
+	if r.QueuedOutcome != nil && r.QueuedOutcome.Executed {
+		actions = append(actions, r.QueuedOutcome.Action)
+	}
+	if r.ActionOutcome.Executed && r.ActionOutcome.Action != "" {
+		actions = append(actions, r.ActionOutcome.Action)
+	}
+	return actions
+}
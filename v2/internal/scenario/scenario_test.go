@@ -0,0 +1,279 @@
+package scenario_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marczahn/person/v2/internal/biology"
+	"github.com/marczahn/person/v2/internal/scenario"
+)
+
+const minimal = `
+name: minimal
+seed: 3
+dt: 5
+bio:
+  hunger: 0.6
+personality:
+  curiosity: 0.7
+script:
+  - at: 10
+    inject: ["~food available"]
+end:
+  after: 60
+assertions:
+  - "energy >= 0 always"
+`
+
+func TestParse_FillsDefaultsAndConverts(t *testing.T) {
+	f, err := scenario.Parse([]byte(minimal))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if f.DT != 5 || f.MaxTicks != scenario.DefaultMaxTicks || f.Mind.Mode != scenario.MindDeterministic {
+		t.Errorf("defaults not filled: dt=%v max=%d mind=%q", f.DT, f.MaxTicks, f.Mind.Mode)
+	}
+	if bio := f.BioState(); bio.Hunger != 0.6 || bio.Energy != biology.NewDefaultState().Energy {
+		t.Errorf("BioState should override hunger only, got %+v", bio)
+	}
+	if f.MotivationPersonality().Curiosity != 0.7 {
+		t.Errorf("personality not converted: %+v", f.MotivationPersonality())
+	}
+	script, err := f.ScriptDefinition()
+	if err != nil || len(script.Steps) != 1 || script.Steps[0].Inject[0] != "~food available" || script.End.After != 60 {
+		t.Errorf("script not converted: %+v, %v", script, err)
+	}
+}
+
+func TestParse_RejectsInvalidFiles(t *testing.T) {
+	cases := map[string]string{
+		"unknown key":      minimal + "colour: blue\n",
+		"unknown bio":      strings.Replace(minimal, "hunger: 0.6", "cortisol: 0.6", 1),
+		"bad assertion":    minimal + "  - \"hunger is fine\"\n",
+		"unknown scenario": strings.Replace(minimal, `inject: ["~food available"]`, "activate: storm", 1),
+		"replay w/o reply": minimal + "mind:\n  mode: replay\n",
+		"bad end":          strings.Replace(minimal, "after: 60", "when: [\"hunger ~ 1\"]", 1),
+		"bad perturbation": strings.Replace(minimal, `inject: ["~food available"]`, `perturb: ["ramp stress 0.5"]`, 1),
+		"timed perturb":    strings.Replace(minimal, `inject: ["~food available"]`, `perturb: ["set stress 0.5 at 30"]`, 1),
+	}
+	for name, doc := range cases {
+		if _, err := scenario.Parse([]byte(doc)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseAssertion_Forms(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want scenario.Assertion
+	}{
+		{"hunger < 0.5 by t=600", scenario.Assertion{Check: scenario.CheckBy, T: 600}},
+		{"Hunger < 0.5  at t = 30s", scenario.Assertion{Check: scenario.CheckAt, T: 30}},
+		{"stress <= 0.9 always", scenario.Assertion{Check: scenario.CheckAlways}},
+		{"stress <= 0.9 at end", scenario.Assertion{Check: scenario.CheckEnd}},
+		{"stress <= 0.9", scenario.Assertion{Check: scenario.CheckEnd}},
+		{"eat executed at least once", scenario.Assertion{Action: "eat", Min: 1, Max: -1}},
+		{"eat executed at least 3 times by t=90", scenario.Assertion{Action: "eat", Min: 3, Max: -1, T: 90}},
+		{"rest executed at most twice", scenario.Assertion{Action: "rest", Max: 2}},
+		{"rest executed exactly 1 time", scenario.Assertion{Action: "rest", Min: 1, Max: 1}},
+		{"reach_out never executed", scenario.Assertion{Action: "reach_out"}},
+	}
+	for _, tc := range cases {
+		got, err := scenario.ParseAssertion(tc.raw)
+		if err != nil {
+			t.Errorf("%q: %v", tc.raw, err)
+			continue
+		}
+		if got.Check != tc.want.Check || got.T != tc.want.T || got.Action != tc.want.Action ||
+			got.Min != tc.want.Min || got.Max != tc.want.Max {
+			t.Errorf("%q = %+v, want %+v", tc.raw, got, tc.want)
+		}
+	}
+	for _, raw := range []string{"", "hunger", "cortisol < 1", "eat happened", "hunger < 0.5 by noon"} {
+		if _, err := scenario.ParseAssertion(raw); err == nil {
+			t.Errorf("%q: expected an error", raw)
+		}
+	}
+}
+
+func TestAssertion_EvaluateTimeBounds(t *testing.T) {
+	samples := make([]scenario.Sample, 0, 4)
+	for i, hunger := range []float64{0.8, 0.6, 0.4, 0.7} {
+		bio := *biology.NewDefaultState()
+		bio.Hunger = hunger
+		var executed []string
+		if i == 1 {
+			executed = []string{"eat"}
+		}
+		samples = append(samples, scenario.Sample{T: float64(i+1) * 10, Bio: bio, Executed: executed})
+	}
+	cases := map[string]bool{
+		"hunger < 0.5 by t=30":               true,
+		"hunger < 0.5 by t=20":               false,
+		"hunger < 0.5 at t=25":               true,
+		"hunger < 0.5 at t=50":               false,
+		"hunger < 0.5 at end":                false,
+		"hunger <= 0.8 always":               true,
+		"hunger < 0.8 always":                false,
+		"eat executed at least once":         true,
+		"eat executed at least once by t=10": false,
+		"eat executed at most 0 times":       false,
+		"rest never executed":                true,
+	}
+	for raw, want := range cases {
+		a, err := scenario.ParseAssertion(raw)
+		if err != nil {
+			t.Fatalf("%q: %v", raw, err)
+		}
+		if got := a.Evaluate(samples); got.Passed != want {
+			t.Errorf("%q passed=%v (%s), want %v", raw, got.Passed, got.Detail, want)
+		}
+	}
+}
+
+func TestRun_ExampleScenarioPassesDeterministically(t *testing.T) {
+	f, err := scenario.Load("../../scenarios/hungry_cold_room.yaml")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	first, err := scenario.Run(f)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !first.Passed() {
+		t.Fatalf("example failed: %+v", first.Failed())
+	}
+	if first.EndReason != "after 240s" || first.Ticks != 120 {
+		t.Errorf("end = %q after %d ticks", first.EndReason, first.Ticks)
+	}
+	second, _ := scenario.Run(f)
+	if !reflect.DeepEqual(first.Results, second.Results) || !reflect.DeepEqual(first.Events, second.Events) {
+		t.Error("the same file should yield the same report")
+	}
+}
+
+func TestRun_ReplayMindDrivesActions(t *testing.T) {
+	doc := minimal + `  - "rest executed exactly once"
+  - "eat never executed"
+mind:
+  mode: replay
+  replay:
+    - "[STATE: arousal=0.0, valence=0.0] [ACTION: rest] Lie down."
+    - "[STATE: arousal=0.0, valence=0.0] [ACTION: none] Nothing."
+`
+	f, err := scenario.Parse([]byte(doc))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	report, err := scenario.Run(f)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !report.Passed() {
+		t.Fatalf("replay run failed: %+v", report.Failed())
+	}
+}
+
+func TestRun_ReportsFailures(t *testing.T) {
+	f, err := scenario.Parse([]byte(minimal + "  - \"body_temp > 40 by t=30\"\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	report, err := scenario.Run(f)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	failed := report.Failed()
+	if report.Passed() || len(failed) != 1 || failed[0].Assertion != "body_temp > 40 by t=30" {
+		t.Fatalf("expected the hunger assertion to fail, got %+v", report.Results)
+	}
+	if !strings.Contains(failed[0].Detail, "never held by t=30") {
+		t.Errorf("detail = %q", failed[0].Detail)
+	}
+}
+
+func TestRun_WorldModelIsConsumed(t *testing.T) {
+	doc := `
+name: one_meal
+dt: 5
+bio:
+  hunger: 0.9
+  energy: 0.3
+world:
+  start: bedroom
+  locations:
+    - name: bedroom
+      exits: [kitchen]
+    - name: kitchen
+      temperature: 23
+      exits: [bedroom]
+      objects:
+        - name: fridge
+          stocks: {food: 1}
+script:
+  - at: 0
+    inject: ["*stretches*"]
+end:
+  after: 60
+assertions:
+  - "move executed at least once by t=5"
+  - "eat executed exactly once"
+`
+	f, err := scenario.Parse([]byte(doc))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	report, err := scenario.Run(f)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !report.Passed() {
+		t.Fatalf("world run failed: %+v", report.Failed())
+	}
+
+	bad := strings.Replace(doc, "food: 1", "bread: 1", 1)
+	if _, err := scenario.Parse([]byte(bad)); err == nil {
+		t.Error("unknown resources should be rejected")
+	}
+}
+
+func TestRun_PerturbationsAreForcedAndAttributed(t *testing.T) {
+	doc := `
+name: forced
+dt: 10
+script:
+  - at: 120
+    perturb: ["set stress 0.9", "add threat_load 0.5"]
+  # Thermoregulation pulls toward 36.6, so the ramp has to outpace it.
+  - at: 60
+    perturb: ["ramp body_temp -12 over 300"]
+end:
+  after: 400
+assertions:
+  - "stress >= 0.8 at t=120"
+  - "body_temp < 35 by t=360"
+`
+	f, err := scenario.Parse([]byte(doc))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	report, err := scenario.Run(f)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !report.Passed() {
+		t.Fatalf("assertions failed: %+v", report.Failed())
+	}
+	fields := map[string]int{}
+	for _, d := range report.Perturbations {
+		if d.Source != "script forced" {
+			t.Errorf("delta %+v should be attributed to the script", d)
+		}
+		fields[d.Field]++
+	}
+	if fields["stress"] != 1 || fields["threat_load"] != 1 || fields["body_temp"] != 30 {
+		t.Errorf("perturbation deltas per field = %v", fields)
+	}
+}
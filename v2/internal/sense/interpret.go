@@ -0,0 +1,557 @@
+package sense
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Cue is something an action or environment description can assert.
+type Cue string
+
+const (
+	// Rate cues describe ongoing conditions.
+	CueCold  Cue = "cold"
+	CueHeat  Cue = "heat"
+	CueNoise Cue = "noise"
+	CueCalm  Cue = "calm"
+	CueCrowd Cue = "crowd"
+	CueDark  Cue = "dark"
+	CueLight Cue = "light"
+
+	// Pulse cues describe what someone does to the person.
+	CueViolence Cue = "violence"
+	CueComfort  Cue = "comfort"
+	CueFeeding  Cue = "feeding"
+
+	// World cues assert whether something is available.
+	CueFood       Cue = "food"
+	CueWater      Cue = "water"
+	CueQuietSpace Cue = "quiet_space"
+	CuePeople     Cue = "people"
+	CueExplorable Cue = "explorable"
+)
+
+// Effect is one graded cue found in an input.
+type Effect struct {
+	Cue Cue
+	// Negated means the input asserts the cue's absence: "not cold", "no food",
+	// "alone" (for CuePeople).
+	Negated bool
+	// Intensity scales the cue's effect: 1 is plain, below 1 mild, above 1 strong (0..2).
+	Intensity float64
+	// Confidence is how sure the reading is (0..1). Hedges and questions lower it.
+	Confidence float64
+	// Phrase is the matched text.
+	Phrase string
+}
+
+// Interpretation is everything an input was read to mean, in text order.
+type Interpretation struct {
+	Kind    InputKind
+	Effects []Effect
+	// For is how long the described conditions hold, in seconds ("for 10 minutes").
+	// Zero means until changed.
+	For float64
+	// Addressed is set for speech directed at the person: it says "you" or greets.
+	Addressed bool
+}
+
+// Interpreter reads graded effects out of a parsed input. A Parser that also
+// implements Interpreter replaces the default lexicon interpreter.
+type Interpreter interface {
+	Interpret(in ParsedInput) Interpretation
+}
+
+// LexiconInterpreter reads speech, actions and environment descriptions
+// against a fixed English lexicon with word boundaries, negation scope and
+// intensity adverbs. It needs no network.
+type LexiconInterpreter struct{}
+
+func NewInterpreter() *LexiconInterpreter {
+	return &LexiconInterpreter{}
+}
+
+// lexeme is one lexicon entry. Every form is matched as whole tokens.
+type lexeme struct {
+	cue        Cue
+	forms      []string
+	intensity  float64
+	confidence float64
+	// absent marks words that deny the cue by themselves ("alone", "trapped").
+	absent bool
+}
+
+func lex(cue Cue, intensity, confidence float64, forms ...string) lexeme {
+	return lexeme{cue: cue, forms: forms, intensity: intensity, confidence: confidence}
+}
+
+func absentLex(cue Cue, confidence float64, forms ...string) lexeme {
+	return lexeme{cue: cue, forms: forms, intensity: 1, confidence: confidence, absent: true}
+}
+
+var actionLexicon = []lexeme{
+	lex(CueViolence, 1, 1,
+		"punch", "punches", "punched", "punching",
+		"hit", "hits", "hitting",
+		"kick", "kicks", "kicked", "kicking",
+		"slap", "slaps", "slapped", "slapping",
+		"strike", "strikes", "struck",
+		"shove", "shoves", "shoved", "shoving",
+		"attack", "attacks", "attacked", "attacking"),
+	lex(CueViolence, 1.5, 1, "beat", "beats", "beaten", "beating"),
+	lex(CueViolence, 0.5, 1, "push", "pushes", "pushed", "pushing", "poke", "pokes", "poked"),
+	lex(CueComfort, 1, 1,
+		"hug", "hugs", "hugged", "hugging",
+		"comfort", "comforts", "comforted", "comforting",
+		"reassure", "reassures", "reassured", "reassuring",
+		"embrace", "embraces", "embraced"),
+	lex(CueComfort, 0.8, 0.8, "support", "supports", "supported", "supporting", "cares for", "takes care of"),
+	lex(CueComfort, 0.6, 0.8, "pat", "pats", "patted", "smile", "smiles", "smiled"),
+	lex(CueFeeding, 1, 1, "feed", "feeds", "fed", "feeding", "meal", "meals", "snack", "snacks"),
+	lex(CueFeeding, 1, 0.8, "food"),
+}
+
+var environmentLexicon = []lexeme{
+	lex(CueCold, 0.6, 1, "chilly", "cool", "nippy"),
+	lex(CueCold, 1, 1, "cold", "cold wind", "draft", "draughty"),
+	lex(CueCold, 1.6, 1, "freezing", "frigid", "icy", "frozen", "bitter cold"),
+	lex(CueHeat, 0.5, 1, "warm", "balmy"),
+	lex(CueHeat, 1, 1, "hot", "heat", "heatwave"),
+	lex(CueHeat, 1.7, 1, "scorching", "sweltering", "boiling", "blistering", "searing"),
+	lex(CueNoise, 0.7, 1, "noisy", "noise"),
+	lex(CueNoise, 1, 1, "loud", "chaos", "chaotic", "shouting", "yelling"),
+	lex(CueNoise, 1.3, 1, "sirens", "alarm", "alarms", "screaming"),
+	lex(CueNoise, 1.7, 1, "deafening", "blaring", "explosion", "explosions"),
+	lex(CueCalm, 1, 1, "quiet", "calm", "peaceful", "tranquil", "silent", "silence"),
+	lex(CueCalm, 0.8, 0.8, "safe"),
+	lex(CueCrowd, 1, 1, "crowd", "crowded", "crowds", "packed", "crammed", "throng"),
+	lex(CueDark, 0.5, 1, "dim", "dusk", "gloomy", "shadowy"),
+	lex(CueDark, 1, 1, "dark", "darkness", "unlit", "dark night", "moonless night", "night falls", "night has fallen"),
+	lex(CueDark, 1.2, 1, "pitch black", "pitch dark", "blackout"),
+	lex(CueLight, 1, 1, "bright", "sunny", "sunlight", "daylight", "well lit", "brightly lit", "lights on"),
+	lex(CueLight, 1.4, 1, "glaring", "blinding", "floodlit"),
+
+	lex(CueFood, 1, 1, "food available", "food is available", "kitchen stocked", "meal nearby", "has food"),
+	lex(CueFood, 1, 0.8, "food", "meal", "meals", "snacks", "groceries"),
+	absentLex(CueFood, 1, "starving"),
+	lex(CueWater, 1, 1, "water available", "drinkable water", "drinking water"),
+	lex(CueWater, 1, 0.8, "water"),
+	lex(CueQuietSpace, 1, 1, "quiet space", "quiet room", "safe resting place", "resting place", "place to rest", "bed"),
+	lex(CueQuietSpace, 1, 0.8, "can rest", "could rest", "somewhere to rest", "room to rest"),
+	absentLex(CueQuietSpace, 1, "cannot rest", "can not rest", "nowhere to rest", "no rest"),
+	lex(CuePeople, 1, 1, "people nearby", "someone nearby", "others around", "somebody nearby"),
+	lex(CuePeople, 1, 0.7, "people", "someone", "somebody", "strangers", "friends", "neighbours", "neighbors"),
+	absentLex(CuePeople, 1, "alone", "nobody around", "no one around", "nobody", "no one", "deserted"),
+	lex(CueExplorable, 1, 1, "can explore", "open area", "open space", "explore", "wander"),
+	absentLex(CueExplorable, 1, "locked in", "confined", "trapped", "locked up", "shut in"),
+}
+
+// negators open a negation scope over the following words.
+var negators = map[string]bool{
+	"not": true, "no": true, "never": true, "without": true, "cannot": true,
+	"nor": true, "neither": true, "none": true, "hardly": true, "lacks": true, "lacking": true,
+}
+
+// trailingNegations deny a cue named just before them ("cold no more", "heat is gone").
+var trailingNegations = [][]string{
+	{"no", "longer"}, {"no", "more"}, {"unavailable"}, {"gone"}, {"missing"},
+	{"impossible"}, {"stopped"}, {"ended"}, {"faded"}, {"subsided"}, {"over", "now"},
+}
+
+// modifiers scale intensity when they precede a cue.
+var modifiers = []struct {
+	words  []string
+	factor float64
+}{
+	{[]string{"a", "bit"}, 0.5},
+	{[]string{"a", "little"}, 0.5},
+	{[]string{"a", "tad"}, 0.5},
+	{[]string{"kind", "of"}, 0.7},
+	{[]string{"sort", "of"}, 0.7},
+	{[]string{"slightly"}, 0.5},
+	{[]string{"mildly"}, 0.6},
+	{[]string{"somewhat"}, 0.7},
+	{[]string{"barely"}, 0.3},
+	{[]string{"fairly"}, 0.9},
+	{[]string{"quite"}, 1.2},
+	{[]string{"pretty"}, 1.2},
+	{[]string{"so"}, 1.3},
+	{[]string{"too"}, 1.3},
+	{[]string{"really"}, 1.4},
+	{[]string{"very"}, 1.5},
+	{[]string{"bitterly"}, 1.6},
+	{[]string{"extremely"}, 1.8},
+	{[]string{"incredibly"}, 1.8},
+	{[]string{"unbearably"}, 1.8},
+	{[]string{"painfully"}, 1.6},
+}
+
+// postModifiers scale intensity when they follow an action ("hits you hard").
+var postModifiers = map[string]float64{
+	"hard": 1.5, "brutally": 1.7, "violently": 1.7, "viciously": 1.7, "repeatedly": 1.4,
+	"tightly": 1.3, "warmly": 1.2, "gently": 0.7, "softly": 0.7, "lightly": 0.6, "playfully": 0.5,
+}
+
+// hedges anywhere in a clause lower the confidence of its cues.
+var hedges = map[string]bool{
+	"maybe": true, "perhaps": true, "might": true, "probably": true, "possibly": true,
+	"seems": true, "seem": true, "apparently": true, "supposedly": true,
+}
+
+// breaks end a clause, and with it any negation or modifier scope.
+var breaks = map[string]bool{
+	"but": true, "though": true, "although": true, "however": true, "yet": true, "while": true, "whereas": true,
+}
+
+const (
+	negationScope = 3 // words a negator reaches forward
+	trailingScope = 3 // words after a cue searched for a trailing negation
+	modifierScope = 2 // words before a cue searched for a modifier
+	hedgeFactor   = 0.6
+	questionScale = 0.4
+	maxIntensity  = 2.0
+)
+
+type token struct {
+	word   string
+	clause int
+}
+
+type clauseInfo struct {
+	question bool
+	hedged   bool
+}
+
+// Interpret reads the cues in a speech, action or environment input. Speech
+// also yields one CueQuestion per clause that asks something.
+// Contract: effects are in text order, longer phrases first where they start
+// together; a phrase nested in a longer phrase of the same cue is reported
+// once, for the longer phrase.
+func (i *LexiconInterpreter) Interpret(in ParsedInput) Interpretation {
+	out := Interpretation{Kind: in.Kind}
+	var lexicon []lexeme
+	switch in.Kind {
+	case InputSpeech:
+		lexicon = speechLexicon
+	case InputAction:
+		lexicon = actionLexicon
+	case InputEnvironment:
+		lexicon = environmentLexicon
+	default:
+		return out
+	}
+
+	tokens, clauses := tokenize(in.Content)
+	out.For = duration(tokens)
+	type span struct {
+		cue        Cue
+		start, end int
+	}
+	var taken []span
+	covered := func(cue Cue, start, end int) bool {
+		for _, s := range taken {
+			if s.cue == cue && start >= s.start && end <= s.end {
+				return true
+			}
+		}
+		return false
+	}
+
+	type match struct {
+		lexeme
+		start, end int
+	}
+	var matches []match
+	for _, entry := range lexicon {
+		for _, form := range entry.forms {
+			words := strings.Fields(form)
+			for i := range tokens {
+				if matchWords(tokens, i, words) {
+					matches = append(matches, match{lexeme: entry, start: i, end: i + len(words)})
+				}
+			}
+		}
+	}
+	// Longer phrases first so nested shorter forms of the same cue are skipped.
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if la, lb := a.end-a.start, b.end-b.start; la != lb {
+			return la > lb
+		}
+		return a.start < b.start
+	})
+	var kept []match
+	for _, m := range matches {
+		if covered(m.cue, m.start, m.end) {
+			continue
+		}
+		taken = append(taken, span{cue: m.cue, start: m.start, end: m.end})
+		kept = append(kept, m)
+	}
+	var effects []placedEffect
+	for _, m := range kept {
+		clause := clauses[tokens[m.start].clause]
+		negated := m.absent
+		if negationsBefore(tokens, m.start)%2 == 1 {
+			negated = !negated
+		}
+		if negatedAfter(tokens, m.end) {
+			negated = !negated
+		}
+
+		intensity := m.intensity * modifierBefore(tokens, m.start)
+		if in.Kind == InputAction {
+			intensity *= modifierAfter(tokens, m.end)
+		}
+
+		confidence := m.confidence
+		if clause.hedged {
+			confidence *= hedgeFactor
+		}
+		// A question is itself a speech act, not doubt about what was said.
+		if clause.question && in.Kind != InputSpeech {
+			confidence *= questionScale
+		}
+
+		effects = append(effects, placedEffect{start: m.start, Effect: Effect{
+			Cue:        m.cue,
+			Negated:    negated,
+			Intensity:  min(max(intensity, 0), maxIntensity),
+			Confidence: min(max(confidence, 0), 1),
+			Phrase:     phrase(tokens[m.start:m.end]),
+		}})
+	}
+	if in.Kind == InputSpeech {
+		effects = append(effects, questions(tokens, clauses)...)
+	}
+	sort.SliceStable(effects, func(i, j int) bool { return effects[i].start < effects[j].start })
+	for _, e := range effects {
+		out.Effects = append(out.Effects, e.Effect)
+	}
+	if in.Kind == InputSpeech {
+		out.Addressed = addressed(tokens, out.Effects)
+	}
+	return out
+}
+
+// placedEffect is an effect with the token it starts at, for text order.
+type placedEffect struct {
+	start int
+	Effect
+}
+
+func phrase(tokens []token) string {
+	words := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		words = append(words, t.word)
+	}
+	return strings.Join(words, " ")
+}
+
+// tokenize lowercases text and splits it into words. Punctuation and contrast
+// conjunctions end a clause; "?" marks its clause as a question.
+func tokenize(text string) ([]token, []clauseInfo) {
+	var (
+		tokens  []token
+		clauses = []clauseInfo{{}}
+		word    strings.Builder
+	)
+	newClause := func() {
+		if clauses[len(clauses)-1] != (clauseInfo{}) || clauseHasTokens(tokens, len(clauses)-1) {
+			clauses = append(clauses, clauseInfo{})
+		}
+	}
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		w := strings.Trim(word.String(), "'")
+		word.Reset()
+		if w == "" {
+			return
+		}
+		if breaks[w] {
+			newClause()
+			return
+		}
+		current := len(clauses) - 1
+		if hedges[w] {
+			clauses[current].hedged = true
+		}
+		tokens = append(tokens, expand(w, current)...)
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		case r == '\'' || r == '’':
+			word.WriteRune('\'')
+		case r == '-':
+			// Hyphenated words split: "ice-cold" reads as "ice cold".
+			flush()
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			if r == '?' {
+				clauses[len(clauses)-1].question = true
+			}
+			if strings.ContainsRune(",.;:!?()", r) {
+				newClause()
+			}
+		}
+	}
+	flush()
+	return tokens, clauses
+}
+
+func clauseHasTokens(tokens []token, clause int) bool {
+	return len(tokens) > 0 && tokens[len(tokens)-1].clause == clause
+}
+
+// expand splits contracted negations ("isn't" -> "is not", "can't" -> "can not").
+func expand(w string, clause int) []token {
+	switch w {
+	case "can't", "cant":
+		return []token{{word: "can", clause: clause}, {word: "not", clause: clause}}
+	case "won't":
+		return []token{{word: "will", clause: clause}, {word: "not", clause: clause}}
+	}
+	if stem, ok := strings.CutSuffix(w, "n't"); ok && stem != "" {
+		return []token{{word: stem, clause: clause}, {word: "not", clause: clause}}
+	}
+	return []token{{word: w, clause: clause}}
+}
+
+func matchWords(tokens []token, at int, words []string) bool {
+	if at+len(words) > len(tokens) {
+		return false
+	}
+	clause := tokens[at].clause
+	for i, w := range words {
+		if tokens[at+i].word != w || tokens[at+i].clause != clause {
+			return false
+		}
+	}
+	return true
+}
+
+// negationsBefore counts negators in scope before start, so "not without food"
+// cancels out.
+func negationsBefore(tokens []token, start int) int {
+	if start >= len(tokens) {
+		return 0
+	}
+	clause := tokens[start].clause
+	n := 0
+	for i := start - 1; i >= 0 && i >= start-negationScope; i-- {
+		if tokens[i].clause != clause {
+			break
+		}
+		if negators[tokens[i].word] {
+			n++
+		}
+	}
+	return n
+}
+
+func negatedAfter(tokens []token, end int) bool {
+	if end == 0 || end > len(tokens) {
+		return false
+	}
+	clause := tokens[end-1].clause
+	for i := end; i < len(tokens) && i < end+trailingScope; i++ {
+		if tokens[i].clause != clause {
+			return false
+		}
+		for _, phrase := range trailingNegations {
+			if matchWords(tokens, i, phrase) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// modifierBefore returns the factor of the nearest modifier ending just before
+// start, or 1.
+func modifierBefore(tokens []token, start int) float64 {
+	if start >= len(tokens) {
+		return 1
+	}
+	clause := tokens[start].clause
+	for end := start; end > 0 && end >= start-modifierScope+1; end-- {
+		if tokens[end-1].clause != clause {
+			break
+		}
+		for _, m := range modifiers {
+			from := end - len(m.words)
+			if from >= 0 && matchWords(tokens, from, m.words) {
+				return m.factor
+			}
+		}
+	}
+	return 1
+}
+
+func modifierAfter(tokens []token, end int) float64 {
+	if end == 0 || end > len(tokens) {
+		return 1
+	}
+	clause := tokens[end-1].clause
+	for i := end; i < len(tokens) && i < end+3; i++ {
+		if tokens[i].clause != clause {
+			break
+		}
+		if f, ok := postModifiers[tokens[i].word]; ok {
+			return f
+		}
+	}
+	return 1
+}
+
+// durationUnits converts unit words to seconds.
+var durationUnits = map[string]float64{
+	"s": 1, "sec": 1, "secs": 1, "second": 1, "seconds": 1,
+	"m": 60, "min": 60, "mins": 60, "minute": 60, "minutes": 60,
+	"h": 3600, "hr": 3600, "hrs": 3600, "hour": 3600, "hours": 3600,
+}
+
+// duration finds "for 10 minutes", "for 30s" or "for an hour" and returns
+// seconds, or 0 when the input gives no duration.
+func duration(tokens []token) float64 {
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].word != "for" {
+			continue
+		}
+		amount := tokens[i+1].word
+		unitAt := i + 2
+		var n float64
+		switch amount {
+		case "a", "an", "one":
+			n = 1
+		default:
+			digits := strings.TrimRightFunc(amount, unicode.IsLetter)
+			parsed, err := strconv.ParseFloat(digits, 64)
+			if err != nil || parsed <= 0 {
+				continue
+			}
+			n = parsed
+			if suffix := amount[len(digits):]; suffix != "" {
+				if unit, ok := durationUnits[suffix]; ok {
+					return n * unit
+				}
+				continue
+			}
+		}
+		if unitAt < len(tokens) {
+			if unit, ok := durationUnits[tokens[unitAt].word]; ok {
+				return n * unit
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,229 @@
+package sense_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/marczahn/person/v2/internal/sense"
+)
+
+type wantEffect struct {
+	cue       sense.Cue
+	negated   bool
+	intensity float64
+	// unsure marks readings below the adapter's 0.5 confidence floor.
+	unsure bool
+}
+
+func TestInterpreter_Corpus(t *testing.T) {
+	interp := sense.NewInterpreter()
+	env := sense.InputEnvironment
+	act := sense.InputAction
+	sp := sense.InputSpeech
+
+	tests := []struct {
+		name    string
+		kind    sense.InputKind
+		content string
+		want    []wantEffect
+	}{
+		// Plain environment cues keep the legacy rates at intensity 1.
+		{"plain cold", env, "cold room", []wantEffect{{cue: sense.CueCold, intensity: 1}}},
+		{"plain heat", env, "it is hot", []wantEffect{{cue: sense.CueHeat, intensity: 1}}},
+		{"plain loud", env, "loud music", []wantEffect{{cue: sense.CueNoise, intensity: 1}}},
+		{"plain quiet", env, "quiet evening", []wantEffect{{cue: sense.CueCalm, intensity: 1}}},
+		{"sirens", env, "sirens outside", []wantEffect{{cue: sense.CueNoise, intensity: 1.3}}},
+		{"empty input", env, "", nil},
+		{"no cue", env, "a grey afternoon", nil},
+
+		// Lexical intensity.
+		{"chilly is mild", env, "chilly", []wantEffect{{cue: sense.CueCold, intensity: 0.6}}},
+		{"freezing is strong", env, "freezing", []wantEffect{{cue: sense.CueCold, intensity: 1.6}}},
+		{"scorching is strong", env, "scorching", []wantEffect{{cue: sense.CueHeat, intensity: 1.7}}},
+		{"warm is mild heat", env, "warm breeze", []wantEffect{{cue: sense.CueHeat, intensity: 0.5}}},
+		{"deafening", env, "deafening noise", []wantEffect{{cue: sense.CueNoise, intensity: 1.7}, {cue: sense.CueNoise, intensity: 0.7}}},
+
+		// Intensity adverbs.
+		{"a bit hot", env, "a bit hot", []wantEffect{{cue: sense.CueHeat, intensity: 0.5}}},
+		{"a little cold", env, "a little cold", []wantEffect{{cue: sense.CueCold, intensity: 0.5}}},
+		{"slightly loud", env, "slightly loud", []wantEffect{{cue: sense.CueNoise, intensity: 0.5}}},
+		{"very cold", env, "very cold", []wantEffect{{cue: sense.CueCold, intensity: 1.5}}},
+		{"extremely hot", env, "extremely hot", []wantEffect{{cue: sense.CueHeat, intensity: 1.8}}},
+		{"intensifier and strong word clamp", env, "extremely freezing", []wantEffect{{cue: sense.CueCold, intensity: 2}}},
+		{"kind of chilly", env, "kind of chilly", []wantEffect{{cue: sense.CueCold, intensity: 0.42}}},
+		{"a bit too cold", env, "a bit too cold", []wantEffect{{cue: sense.CueCold, intensity: 1.3}}},
+		{"modifier out of reach", env, "very much like a cold", []wantEffect{{cue: sense.CueCold, intensity: 1}}},
+		{"modifier in other clause", env, "very nice, cold", []wantEffect{{cue: sense.CueCold, intensity: 1}}},
+
+		// Negation scope.
+		{"not cold anymore", env, "it is not cold anymore", []wantEffect{{cue: sense.CueCold, negated: true, intensity: 1}}},
+		{"no longer loud", env, "no longer loud", []wantEffect{{cue: sense.CueNoise, negated: true, intensity: 1}}},
+		{"isn't hot", env, "it isn't hot", []wantEffect{{cue: sense.CueHeat, negated: true, intensity: 1}}},
+		{"curly apostrophe", env, "it isn’t hot", []wantEffect{{cue: sense.CueHeat, negated: true, intensity: 1}}},
+		{"never quiet", env, "never quiet here", []wantEffect{{cue: sense.CueCalm, negated: true, intensity: 1}}},
+		{"not very cold", env, "not very cold", []wantEffect{{cue: sense.CueCold, negated: true, intensity: 1.5}}},
+		{"negation out of scope", env, "no idea why it is so cold", []wantEffect{{cue: sense.CueCold, intensity: 1.3}}},
+		{"negation stops at comma", env, "not loud, cold", []wantEffect{{cue: sense.CueNoise, negated: true, intensity: 1}, {cue: sense.CueCold, intensity: 1}}},
+		{"negation stops at but", env, "not loud but cold", []wantEffect{{cue: sense.CueNoise, negated: true, intensity: 1}, {cue: sense.CueCold, intensity: 1}}},
+		{"trailing no more", env, "the cold no more", []wantEffect{{cue: sense.CueCold, negated: true, intensity: 1}}},
+		{"heat is gone", env, "the heat is gone", []wantEffect{{cue: sense.CueHeat, negated: true, intensity: 1}}},
+		{"noise has stopped", env, "the noise has stopped", []wantEffect{{cue: sense.CueNoise, negated: true, intensity: 0.7}}},
+		{"sirens subsided", env, "sirens subsided, calm", []wantEffect{{cue: sense.CueNoise, negated: true, intensity: 1.3}, {cue: sense.CueCalm, intensity: 1}}},
+
+		// Word boundaries.
+		{"hotel is not hot", env, "a hotel lobby", nil},
+		{"scold is not cold", env, "they scold each other", nil},
+		{"shotgun is not hot", env, "shotgun", nil},
+		{"coldness suffix ignored", env, "colder", nil},
+		{"hyphen splits", env, "ice-cold water", []wantEffect{{cue: sense.CueCold, intensity: 1}, {cue: sense.CueWater, intensity: 1}}},
+
+		// Hedges and questions lower confidence.
+		{"maybe cold", env, "maybe cold", []wantEffect{{cue: sense.CueCold, intensity: 1}}},
+		{"maybe safe", env, "maybe safe", []wantEffect{{cue: sense.CueCalm, intensity: 0.8, unsure: true}}},
+		{"question", env, "is it cold?", []wantEffect{{cue: sense.CueCold, intensity: 1, unsure: true}}},
+		{"question only its clause", env, "is it cold? loud", []wantEffect{{cue: sense.CueCold, intensity: 1, unsure: true}, {cue: sense.CueNoise, intensity: 1}}},
+
+		// World availability.
+		{"no food", env, "no food available", []wantEffect{{cue: sense.CueFood, negated: true, intensity: 1}}},
+		{"food is available", env, "food is available", []wantEffect{{cue: sense.CueFood, intensity: 1}}},
+		{"without food", env, "without food", []wantEffect{{cue: sense.CueFood, negated: true, intensity: 1}}},
+		{"food unavailable", env, "food unavailable", []wantEffect{{cue: sense.CueFood, negated: true, intensity: 1}}},
+		{"not without food", env, "not without food", []wantEffect{{cue: sense.CueFood, intensity: 1}}},
+		{"no water", env, "no water", []wantEffect{{cue: sense.CueWater, negated: true, intensity: 1}}},
+		{"drinkable water", env, "drinkable water nearby", []wantEffect{{cue: sense.CueWater, intensity: 1}}},
+		{"cannot rest", env, "cannot rest", []wantEffect{{cue: sense.CueQuietSpace, negated: true, intensity: 1}}},
+		{"can't rest", env, "you can't rest", []wantEffect{{cue: sense.CueQuietSpace, negated: true, intensity: 1}}},
+		{"resting place gone", env, "the resting place is gone", []wantEffect{{cue: sense.CueQuietSpace, negated: true, intensity: 1}}},
+		{"nowhere to rest", env, "nowhere to rest", []wantEffect{{cue: sense.CueQuietSpace, negated: true, intensity: 1}}},
+		{"somewhere to rest", env, "somewhere to rest nearby", []wantEffect{{cue: sense.CueQuietSpace, intensity: 1}}},
+		{"rest of the day", env, "the rest of the day is busy", nil},
+		{"rest is not a place", env, "the rest of them left", nil},
+		{"quiet space", env, "quiet space available", []wantEffect{{cue: sense.CueQuietSpace, intensity: 1}, {cue: sense.CueCalm, intensity: 1}}},
+		{"no quiet space", env, "no quiet space", []wantEffect{{cue: sense.CueQuietSpace, negated: true, intensity: 1}, {cue: sense.CueCalm, negated: true, intensity: 1}}},
+		{"alone", env, "you are alone", []wantEffect{{cue: sense.CuePeople, negated: true, intensity: 1}}},
+		{"not alone", env, "you are not alone", []wantEffect{{cue: sense.CuePeople, intensity: 1}}},
+		{"nobody around", env, "nobody around", []wantEffect{{cue: sense.CuePeople, negated: true, intensity: 1}}},
+		{"no one around", env, "no one around", []wantEffect{{cue: sense.CuePeople, negated: true, intensity: 1}}},
+		{"people nearby", env, "people nearby", []wantEffect{{cue: sense.CuePeople, intensity: 1}}},
+		{"locked in", env, "locked in a cell", []wantEffect{{cue: sense.CueExplorable, negated: true, intensity: 1}}},
+		{"no longer confined", env, "no longer confined", []wantEffect{{cue: sense.CueExplorable, intensity: 1}}},
+		{"cannot explore", env, "cannot explore", []wantEffect{{cue: sense.CueExplorable, negated: true, intensity: 1}}},
+		{"mixed clauses", env, "no food here, but water is available", []wantEffect{{cue: sense.CueFood, negated: true, intensity: 1}, {cue: sense.CueWater, intensity: 1}}},
+
+		// Light and crowding.
+		{"crowded", env, "a crowded station", []wantEffect{{cue: sense.CueCrowd, intensity: 1}}},
+		{"pitch black", env, "pitch black", []wantEffect{{cue: sense.CueDark, intensity: 1.2}}},
+		{"dim", env, "a dim corridor", []wantEffect{{cue: sense.CueDark, intensity: 0.5}}},
+		{"no longer dark", env, "no longer dark", []wantEffect{{cue: sense.CueDark, negated: true, intensity: 1}}},
+		{"very bright", env, "very bright", []wantEffect{{cue: sense.CueLight, intensity: 1.5}}},
+		{"dark night", env, "a dark night", []wantEffect{{cue: sense.CueDark, intensity: 1}}},
+		{"night falls", env, "night falls", []wantEffect{{cue: sense.CueDark, intensity: 1}}},
+		{"well lit", env, "a well lit hall", []wantEffect{{cue: sense.CueLight, intensity: 1}}},
+		{"night light is not darkness", env, "a night light glows", nil},
+		{"night shift is not darkness", env, "the night shift starts", nil},
+		{"lit cigarette is not light", env, "she lit a cigarette", nil},
+
+		// Actions.
+		{"punch", act, "someone punches you", []wantEffect{{cue: sense.CueViolence, intensity: 1}}},
+		{"punch hard", act, "punches you hard", []wantEffect{{cue: sense.CueViolence, intensity: 1.5}}},
+		{"pushes gently", act, "pushes gently", []wantEffect{{cue: sense.CueViolence, intensity: 0.35}}},
+		{"does not hit", act, "raises a hand but does not hit you", []wantEffect{{cue: sense.CueViolence, negated: true, intensity: 1}}},
+		{"doesn't hit", act, "doesn't hit you", []wantEffect{{cue: sense.CueViolence, negated: true, intensity: 1}}},
+		{"careful is not care", act, "careful", nil},
+		{"hits is not hitchhike", act, "hitchhikes away", nil},
+		{"hug tightly", act, "hugs you tightly", []wantEffect{{cue: sense.CueComfort, intensity: 1.3}}},
+		{"takes care of you", act, "takes care of you", []wantEffect{{cue: sense.CueComfort, intensity: 0.8}}},
+		{"feeds you", act, "feeds you a meal", []wantEffect{{cue: sense.CueFeeding, intensity: 1}, {cue: sense.CueFeeding, intensity: 1}}},
+		{"refuses food", act, "gives you no food", []wantEffect{{cue: sense.CueFeeding, negated: true, intensity: 1}}},
+		{"action lexicon only for actions", env, "someone punches the wall", []wantEffect{{cue: sense.CuePeople, intensity: 1}}},
+
+		// Speech acts.
+		{"speech reads no environment", sp, "it is so cold", nil},
+		{"greeting", sp, "hello there", []wantEffect{{cue: sense.CueGreeting, intensity: 1}}},
+		{"greeting and question", sp, "hi, how are you?", []wantEffect{{cue: sense.CueGreeting, intensity: 1}, {cue: sense.CueGreeting, intensity: 1}, {cue: sense.CueQuestion, intensity: 1}}},
+		{"unmarked question", sp, "where did you sleep", []wantEffect{{cue: sense.CueQuestion, intensity: 1}}},
+		{"question keeps its insult", sp, "are you stupid?", []wantEffect{{cue: sense.CueQuestion, intensity: 1}, {cue: sense.CueInsult, intensity: 1}}},
+		{"really stupid", sp, "you are really stupid", []wantEffect{{cue: sense.CueInsult, intensity: 1.4}}},
+		{"not stupid", sp, "you're not stupid", []wantEffect{{cue: sense.CueInsult, negated: true, intensity: 1}}},
+		{"praise", sp, "well done, I'm proud of you", []wantEffect{{cue: sense.CuePraise, intensity: 1}, {cue: sense.CuePraise, intensity: 1}}},
+		{"threat", sp, "I'll kill you", []wantEffect{{cue: sense.CueThreat, intensity: 1.6}}},
+		{"won't hurt you", sp, "I won't hurt you", []wantEffect{{cue: sense.CueThreat, negated: true, intensity: 1}}},
+		{"hostile tone", sp, "ugh, go away", []wantEffect{{cue: sense.CueHostility, intensity: 1}, {cue: sense.CueHostility, intensity: 1}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := interp.Interpret(sense.ParsedInput{Kind: tc.kind, Content: tc.content})
+			if got.Kind != tc.kind {
+				t.Fatalf("kind = %q, want %q", got.Kind, tc.kind)
+			}
+			if len(got.Effects) != len(tc.want) {
+				t.Fatalf("effects = %+v, want %+v", got.Effects, tc.want)
+			}
+			for i, w := range tc.want {
+				e := got.Effects[i]
+				if e.Cue != w.cue || e.Negated != w.negated || math.Abs(e.Intensity-w.intensity) > 1e-9 {
+					t.Errorf("effect %d = %+v, want %+v", i, e, w)
+				}
+				if unsure := e.Confidence < 0.5; unsure != w.unsure {
+					t.Errorf("effect %d confidence = %v, want unsure=%v", i, e.Confidence, w.unsure)
+				}
+			}
+		})
+	}
+}
+
+func TestInterpreter_SpeechToneAndAddress(t *testing.T) {
+	interp := sense.NewInterpreter()
+	tests := []struct {
+		content   string
+		tone      string
+		addressed bool
+	}{
+		{"hello, thank you so much", "warm", true},
+		{"you are worthless", "hostile", true},
+		{"I'll hurt you, or else", "hostile", true},
+		{"the bus is late", "neutral", false},
+		{"you're not stupid", "neutral", true},
+		{"that was clever", "warm", false},
+	}
+	for _, tc := range tests {
+		got := interp.Interpret(sense.ParsedInput{Kind: sense.InputSpeech, Content: tc.content})
+		tone := "neutral"
+		switch {
+		case got.Tone() >= 0.2:
+			tone = "warm"
+		case got.Tone() <= -0.2:
+			tone = "hostile"
+		}
+		if tone != tc.tone || got.Addressed != tc.addressed {
+			t.Errorf("%q: tone=%v (%s) addressed=%v, want %s addressed=%v", tc.content, got.Tone(), tone, got.Addressed, tc.tone, tc.addressed)
+		}
+		if got.Tone() < -1 || got.Tone() > 1 {
+			t.Errorf("%q: tone %v out of range", tc.content, got.Tone())
+		}
+	}
+}
+
+func TestInterpreter_Durations(t *testing.T) {
+	interp := sense.NewInterpreter()
+	tests := []struct {
+		content string
+		want    float64
+	}{
+		{"freezing cold", 0},
+		{"freezing cold for 10 minutes", 600},
+		{"loud for 30s", 30},
+		{"dark for an hour", 3600},
+		{"hot for 2 h", 7200},
+		{"quiet for a while", 0},
+		{"waiting for someone", 0},
+		{"cold for 5min", 300},
+	}
+	for _, tc := range tests {
+		got := interp.Interpret(sense.ParsedInput{Kind: sense.InputEnvironment, Content: tc.content})
+		if got.For != tc.want {
+			t.Errorf("%q: For = %v, want %v", tc.content, got.For, tc.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package sense
+
+// Speech cues describe what an utterance does to the listener.
+const (
+	CueGreeting Cue = "greeting"
+	CueQuestion Cue = "question"
+	CuePraise   Cue = "praise"
+	CueInsult   Cue = "insult"
+	CueThreat   Cue = "threat"
+	// Tone cues colour an utterance without being an act of their own.
+	CueWarmth    Cue = "warmth"
+	CueHostility Cue = "hostility"
+)
+
+// SpeechActs are the speech cues that name an act, in tag order.
+var SpeechActs = []Cue{CueGreeting, CueQuestion, CuePraise, CueInsult, CueThreat}
+
+var speechLexicon = []lexeme{
+	lex(CueGreeting, 1, 1,
+		"hi", "hello", "hey", "hiya", "howdy", "greetings", "welcome",
+		"good morning", "good afternoon", "good evening", "nice to meet you", "how are you"),
+	lex(CuePraise, 1, 1,
+		"well done", "good job", "great job", "nice work", "good work", "proud of you",
+		"thank you", "thanks", "brilliant", "wonderful", "amazing", "impressive"),
+	lex(CuePraise, 0.7, 0.8, "great", "clever", "smart", "lovely", "beautiful"),
+	lex(CueInsult, 1, 1,
+		"idiot", "stupid", "moron", "fool", "dumb", "pathetic", "useless", "loser", "ugly", "shut up"),
+	lex(CueInsult, 1.5, 1, "worthless", "disgusting", "hate you", "nobody likes you"),
+	lex(CueThreat, 1, 1,
+		"hurt you", "or else", "watch your back", "regret this", "make you pay", "you'll regret"),
+	lex(CueThreat, 1.6, 1, "kill you", "beat you up", "end you"),
+	lex(CueWarmth, 1, 1, "please", "dear", "sorry", "love", "friend", "glad", "welcome back"),
+	lex(CueHostility, 1, 1, "damn", "whatever", "go away", "leave me alone", "get lost", "ugh"),
+}
+
+// toneWeights is how much each speech cue at intensity 1 moves the tone.
+var toneWeights = map[Cue]float64{
+	CueGreeting:  0.3,
+	CuePraise:    0.6,
+	CueWarmth:    0.4,
+	CueInsult:    -0.7,
+	CueThreat:    -1,
+	CueHostility: -0.4,
+}
+
+// secondPerson marks speech as directed at the listener.
+var secondPerson = map[string]bool{
+	"you": true, "your": true, "yours": true, "yourself": true,
+	"you're": true, "you've": true, "you'll": true, "you'd": true, "ya": true,
+}
+
+// questionOpeners start a clause that asks something even without "?".
+var questionOpeners = map[string]bool{
+	"what": true, "why": true, "where": true, "when": true, "who": true, "how": true, "which": true,
+	"do": true, "does": true, "did": true, "are": true, "can": true, "could": true, "would": true, "will": true,
+}
+
+const unmarkedQuestionConfidence = 0.7
+
+// questions reads one question effect per asking clause, placed at the
+// clause's first word: a clause ending in "?" for sure, one opening with a
+// question word less so.
+func questions(tokens []token, clauses []clauseInfo) []placedEffect {
+	var out []placedEffect
+	for c := range clauses {
+		start, end := -1, -1
+		for i, t := range tokens {
+			if t.clause != c {
+				continue
+			}
+			if start < 0 {
+				start = i
+			}
+			end = i + 1
+		}
+		if start < 0 {
+			continue
+		}
+		confidence := 1.0
+		if !clauses[c].question {
+			if !questionOpeners[tokens[start].word] {
+				continue
+			}
+			confidence = unmarkedQuestionConfidence
+		}
+		out = append(out, placedEffect{start: start, Effect: Effect{
+			Cue:        CueQuestion,
+			Intensity:  1,
+			Confidence: confidence,
+			Phrase:     phrase(tokens[start:end]),
+		}})
+	}
+	return out
+}
+
+// addressed reports whether speech is directed at the listener: it speaks to
+// "you" or greets.
+func addressed(tokens []token, effects []Effect) bool {
+	for _, t := range tokens {
+		if secondPerson[t.word] {
+			return true
+		}
+	}
+	for _, e := range effects {
+		if e.Cue == CueGreeting && !e.Negated {
+			return true
+		}
+	}
+	return false
+}
+
+// Tone is the overall warmth of a speech interpretation from -1 (hostile) to
+// 1 (warm). Each cue counts in proportion to its confidence; negated cues do
+// not count.
+func (in Interpretation) Tone() float64 {
+	tone := 0.0
+	for _, e := range in.Effects {
+		if !e.Negated {
+			tone += toneWeights[e.Cue] * e.Intensity * e.Confidence
+		}
+	}
+	return min(max(tone, -1), 1)
+}